@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"crabi-test/internal/infrastructure/pldmock"
+)
+
+// pld-mock levanta un servicio PLD simulado con el contrato de /check-blacklist, para
+// ejecutar la API sin acceso al proveedor real (PLD_SERVICE_URL=http://localhost:3000).
+//
+// Uso:
+//
+//	go run ./cmd/pld-mock
+//	go run ./cmd/pld-mock -fixture fixture.json -addr :3000
+//	go run ./cmd/pld-mock -latency 2s -status 503
+func main() {
+	addr := flag.String("addr", defaultAddr(), "Dirección en la que escucha el mock")
+	fixturePath := flag.String("fixture", "", "Archivo JSON con identidades en lista negra y fallas (por defecto el fixture incluido)")
+	latency := flag.Duration("latency", 0, "Latencia agregada a todas las respuestas")
+	status := flag.Int("status", 0, "Código HTTP con el que fallan todas las respuestas")
+	retryAfter := flag.String("retry-after", "", "Encabezado Retry-After enviado junto con -status")
+	malformed := flag.Bool("malformed", false, "Responder 201 con un cuerpo JSON inválido")
+	flag.Parse()
+
+	fixture := pldmock.DefaultFixture()
+	if *fixturePath != "" {
+		loaded, err := pldmock.LoadFixture(*fixturePath)
+		if err != nil {
+			log.Fatal("Error cargando fixture:", err)
+		}
+		fixture = loaded
+	}
+
+	server := pldmock.NewServer(fixture)
+	server.Logger = log.New(os.Stdout, "pld-mock: ", log.LstdFlags)
+
+	if *latency > 0 || *status != 0 || *malformed {
+		server.SetFault(pldmock.Fault{
+			Latency:    pldmock.Duration(*latency),
+			StatusCode: *status,
+			RetryAfter: *retryAfter,
+			Malformed:  *malformed,
+		})
+	}
+
+	log.Printf("Mock PLD escuchando en %s (%d identidades en lista negra, %d fallas por identidad)", *addr, len(fixture.Blacklist), len(fixture.Faults))
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatal("Error iniciando mock PLD:", err)
+	}
+}
+
+// defaultAddr escucha en PLD_MOCK_PORT o en el puerto 3000
+func defaultAddr() string {
+	if port := os.Getenv("PLD_MOCK_PORT"); port != "" {
+		return ":" + port
+	}
+	return ":3000"
+}
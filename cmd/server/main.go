@@ -3,14 +3,17 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"crabi-test/internal/infrastructure/database/sqlite"
+	"crabi-test/internal/infrastructure/http/middleware"
 	"crabi-test/internal/infrastructure/http/routes"
 	"crabi-test/pkg/validator"
 
 	_ "crabi-test/docs" // Importar docs generados
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -47,6 +50,11 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Precisión de iat, nbf y exp en los tokens JWT, para todo el proceso. Con la precisión por
+	// defecto de la librería (segundos), cerrar todas las sesiones de un usuario demoraría hasta
+	// dos segundos para que sus tokens nuevos no queden revocados (ver AuthService.RevokeAllTokens)
+	jwt.TimePrecision = time.Millisecond
+
 	// Inicializar base de datos
 	db, err := sqlite.InitDB()
 	if err != nil {
@@ -60,20 +68,18 @@ func main() {
 	// Middleware de validación personalizada
 	r.Use(validator.CustomValidator())
 
+	// Idioma de los mensajes según Accept-Language
+	r.Use(middleware.Language())
+
+	// Traducción de errores del dominio a respuestas HTTP
+	r.Use(middleware.ErrorHandler())
+
 	// Configurar rutas
 	routes.SetupRoutes(r, db)
 
 	// Documentación Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Ruta de health check
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "OK",
-			"message": "Crabi API is running",
-		})
-	})
-
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
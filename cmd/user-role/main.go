@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"crabi-test/internal/adapters/repositories"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/database/sqlite"
+
+	"github.com/joho/godotenv"
+)
+
+// user-role cambia el rol de una cuenta existente. El registro público siempre crea usuarios
+// con rol user; los administradores se designan con este comando, que solo promueve cuentas
+// activas y sin marca de PLD. La base de datos se indica con -db o DB_PATH.
+//
+// Uso:
+//
+//	go run ./cmd/user-role -db crabi.db -email compliance@crabi.com
+//	go run ./cmd/user-role -db crabi.db -email compliance@crabi.com -role user
+func main() {
+	email := flag.String("email", "", "Email de la cuenta")
+	role := flag.String("role", domain.RoleAdmin, "Rol a asignar ("+domain.RoleAdmin+" o "+domain.RoleUser+")")
+	dbPath := flag.String("db", "", "Ruta de la base de datos SQLite (por defecto DB_PATH)")
+	flag.Parse()
+
+	// Cargar variables de entorno desde .env
+	if err := godotenv.Load(); err != nil {
+		log.Println("No se encontró archivo .env, usando variables de entorno del sistema")
+	}
+
+	if *dbPath == "" {
+		*dbPath = os.Getenv("DB_PATH")
+	}
+	if *email == "" || *dbPath == "" || (*role != domain.RoleAdmin && *role != domain.RoleUser) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if sqlite.IsInMemory(*dbPath) {
+		log.Fatal("La base de datos en memoria no contiene usuarios; indique un archivo con -db o DB_PATH")
+	}
+
+	db, err := sqlite.OpenDB(*dbPath)
+	if err != nil {
+		log.Fatal("Error inicializando base de datos:", err)
+	}
+	defer db.Close()
+
+	repo := repositories.NewUserRepository(db)
+	ctx := context.Background()
+
+	user, err := repo.GetByEmail(ctx, *email)
+	if err != nil {
+		log.Fatal("Error obteniendo usuario:", err)
+	}
+	if user == nil {
+		log.Fatalf("No existe una cuenta con el email %s", *email)
+	}
+
+	updated, err := repo.SetRole(ctx, user.ID, *role, time.Now())
+	if err != nil {
+		log.Fatal("Error actualizando rol:", err)
+	}
+	if !updated {
+		log.Fatalf("La cuenta %s no puede ser administradora: debe estar activa y sin marca de PLD", *email)
+	}
+
+	fmt.Printf("%s: rol %s\n", *email, *role)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"crabi-test/internal/adapters/repositories"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/database/sqlite"
+	"crabi-test/internal/infrastructure/watchlist"
+
+	"github.com/joho/godotenv"
+)
+
+// watchlist-import importa un archivo de lista de sanciones en la base de datos SQLite
+// para que lo use el proveedor PLD local (PLD_PROVIDER=local). La base de datos se indica con
+// -db o DB_PATH; a diferencia del servidor no hay ruta por defecto, y se rechaza una base en
+// memoria porque la importación se perdería al terminar.
+//
+// Uso:
+//
+//	go run ./cmd/watchlist-import -db crabi.db -source ofac_sdn -file sdn.csv
+//	go run ./cmd/watchlist-import -db crabi.db -source un_consolidated -file consolidated.xml
+//	go run ./cmd/watchlist-import -db crabi.db -source uif_lpb -file lpb.csv -version 2024-03
+//	go run ./cmd/watchlist-import -db crabi.db -list
+func main() {
+	source := flag.String("source", "", "Lista a importar ("+strings.Join(domain.WatchlistSources, ", ")+")")
+	file := flag.String("file", "", "Ruta del archivo de la lista")
+	version := flag.String("version", "", "Etiqueta de versión (por defecto la fecha de publicación o de importación)")
+	list := flag.Bool("list", false, "Mostrar las versiones vigentes de cada lista")
+	dbPath := flag.String("db", "", "Ruta de la base de datos SQLite (por defecto DB_PATH)")
+	flag.Parse()
+
+	// Cargar variables de entorno desde .env
+	if err := godotenv.Load(); err != nil {
+		log.Println("No se encontró archivo .env, usando variables de entorno del sistema")
+	}
+
+	if *dbPath == "" {
+		*dbPath = os.Getenv("DB_PATH")
+	}
+	if *dbPath == "" {
+		fmt.Fprintln(os.Stderr, "Indique la base de datos con -db o DB_PATH")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if sqlite.IsInMemory(*dbPath) {
+		log.Fatal("La base de datos en memoria no conserva la importación; indique un archivo con -db o DB_PATH")
+	}
+
+	db, err := sqlite.OpenDB(*dbPath)
+	if err != nil {
+		log.Fatal("Error inicializando base de datos:", err)
+	}
+	defer db.Close()
+
+	repo := repositories.NewWatchlistRepository(db)
+	ctx := context.Background()
+
+	if *list {
+		versions, err := repo.ListActiveVersions(ctx)
+		if err != nil {
+			log.Fatal("Error obteniendo versiones:", err)
+		}
+		if len(versions) == 0 {
+			fmt.Println("No hay listas importadas")
+		}
+		for _, v := range versions {
+			fmt.Printf("%-16s versión %-12s %6d entradas  importada %s  sha256 %s\n",
+				v.Source, v.Version, v.EntryCount, v.ImportedAt.Format("2006-01-02 15:04:05"), v.Checksum[:12])
+		}
+		return
+	}
+
+	if *source == "" || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatal("Error leyendo archivo:", err)
+	}
+
+	imported, err := watchlist.NewImporter(repo).Import(ctx, *source, *version, data)
+	if errors.Is(err, watchlist.ErrListUnchanged) {
+		fmt.Printf("%s: sin cambios, versión vigente %s\n", *source, imported.Version)
+		return
+	}
+	if err != nil {
+		log.Fatal("Error importando lista:", err)
+	}
+
+	fmt.Printf("%s: importada versión %s con %d entradas\n", imported.Source, imported.Version, imported.EntryCount)
+}
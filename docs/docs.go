@@ -24,9 +24,57 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Autentica un usuario con email y contraseña",
+        "/admin/mfa/policies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Indica para cada rol si exige MFA (requiere rol de administrador)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Listar políticas MFA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAPolicyListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/mfa/policies/{role}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Indica si el rol exige MFA (requiere rol de administrador). Los usuarios del rol que no tengan MFA activado solo pueden usar los endpoints de /users/me/mfa hasta activarlo",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,17 +82,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "mfa"
                 ],
-                "summary": "Autenticar usuario",
+                "summary": "Configurar la política MFA de un rol",
                 "parameters": [
                     {
-                        "description": "Credenciales de login",
-                        "name": "credentials",
+                        "enum": [
+                            "user",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Rol",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Política",
+                        "name": "policy",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.LoginRequest"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAPolicyRequest"
                         }
                     }
                 ],
@@ -52,33 +111,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.LoginResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAPolicyResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/users": {
-            "post": {
-                "description": "Crea un nuevo usuario validando contra el servicio PLD",
+        "/admin/rejected-applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista las solicitudes de alta rechazadas por el servicio PLD, filtradas por rango de fechas (solo administradores)",
                 "consumes": [
                     "application/json"
                 ],
@@ -86,56 +156,117 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "compliance"
                 ],
-                "summary": "Crear un nuevo usuario",
+                "summary": "Listar solicitudes rechazadas",
                 "parameters": [
                     {
-                        "description": "Datos del usuario",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Fecha inicial (YYYY-MM-DD o RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha final inclusiva (YYYY-MM-DD o RFC3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.RejectedApplicationListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rescreenings": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Inicia en segundo plano la revalidación de todos los usuarios contra el servicio PLD. Si hay una ejecución interrumpida se reanuda (solo administradores)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Iniciar re-screening",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.RescreeningRunResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "409": {
-                        "description": "Usuario en lista negra",
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/users/me": {
+        "/admin/rescreenings/latest": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Obtiene la información del usuario autenticado",
+                "description": "Obtiene el estado de la ejecución de re-screening más reciente (solo administradores)",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,45 +274,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "compliance"
                 ],
-                "summary": "Obtener información del usuario autenticado",
+                "summary": "Obtener último re-screening",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.RescreeningRunResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista las altas con screening no concluyente pendientes de revisión manual, de la más antigua a la más reciente (solo administradores)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Listar cola de revisión",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewQueueResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
+        "/admin/reviews/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Obtiene la información de un usuario por su ID",
+                "description": "Aprueba un alta pendiente de revisión. La justificación es obligatoria y queda registrada en el log de auditoría (solo administradores)",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,9 +366,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "compliance"
                 ],
-                "summary": "Obtener usuario por ID",
+                "summary": "Aprobar alta pendiente",
                 "parameters": [
                     {
                         "type": "integer",
@@ -199,42 +376,71 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Justificación de la decisión",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewDecisionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewDecisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/reviews/{id}/decisions": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Elimina un usuario por su ID",
+                "description": "Obtiene el log de auditoría de decisiones de revisión de un usuario (solo administradores)",
                 "consumes": [
                     "application/json"
                 ],
@@ -242,9 +448,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "compliance"
                 ],
-                "summary": "Eliminar usuario",
+                "summary": "Listar decisiones de revisión",
                 "parameters": [
                     {
                         "type": "integer",
@@ -258,116 +464,2371 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewDecisionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "crabi-test_internal_infrastructure_http_dto.CreateUserRequest": {
-            "description": "Solicitud para crear un nuevo usuario",
-            "type": "object",
-            "required": [
-                "email",
-                "id_number",
-                "name",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "description": "@Description Email del usuario (debe ser único)\n@Example \"juan.perez@email.com\"\n@Required",
-                    "type": "string",
-                    "example": "juan.perez@email.com"
-                },
-                "id_number": {
-                    "description": "@Description Número de identificación personal\n@Example \"12345678\"\n@Required",
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 8,
-                    "example": "12345678"
-                },
-                "name": {
-                    "description": "@Description Nombre completo del usuario\n@Example \"Juan Pérez\"\n@Required",
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2,
-                    "example": "Juan Pérez"
-                },
-                "password": {
-                    "description": "@Description Contraseña del usuario (mínimo 8 caracteres)\n@Example \"password123\"\n@Required",
-                    "type": "string",
-                    "minLength": 8,
-                    "example": "password123"
-                }
-            }
-        },
-        "crabi-test_internal_infrastructure_http_dto.ErrorResponse": {
-            "description": "Respuesta de error",
-            "type": "object",
-            "properties": {
-                "details": {
-                    "description": "@Description Detalles adicionales del error\n@Example \"El campo email es requerido\"",
-                    "type": "string",
-                    "example": "El campo email es requerido"
-                },
-                "error": {
-                    "description": "@Description Mensaje de error\n@Example \"Error de validación\"",
-                    "type": "string",
-                    "example": "Error de validación"
-                }
-            }
         },
-        "crabi-test_internal_infrastructure_http_dto.LoginRequest": {
-            "description": "Solicitud para autenticarse en el sistema",
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
+        "/admin/reviews/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rechaza un alta pendiente de revisión. La justificación es obligatoria y queda registrada en el log de auditoría (solo administradores)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Rechazar alta pendiente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Justificación de la decisión",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewDecisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Autentica un usuario con email y contraseña. Retorna un token JWT de corta duración y un refresh token para renovarlo. Si el usuario tiene MFA activado responde 202 con un desafío que se completa en /auth/mfa/verify",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Autenticar usuario",
+                "parameters": [
+                    {
+                        "description": "Credenciales de login",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.LoginResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "El usuario debe completar el segundo factor",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuario pendiente de revisión o rechazado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiados logins con MFA pendientes",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoca el token de acceso usado en la solicitud y, si se envía, la sesión del refresh token. Con all_sessions revoca todos los tokens de acceso y refresh tokens del usuario",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Cerrar sesión",
+                "parameters": [
+                    {
+                        "description": "Refresh token y alcance del cierre",
+                        "name": "logout",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Completa el inicio de sesión de un usuario con MFA activado con un código de la aplicación autenticadora o un código de recuperación. Cada código incorrecto consume un intento del desafío",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Completar el login con MFA",
+                "parameters": [
+                    {
+                        "description": "Desafío y código",
+                        "name": "verification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Desafío inválido, vencido o sin intentos, o código incorrecto",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuario pendiente de revisión o rechazado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Envía al usuario un token de restablecimiento de un solo uso y con vencimiento. La respuesta es la misma esté o no registrado el email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Solicitar restablecimiento de contraseña",
+                "parameters": [
+                    {
+                        "description": "Email de la cuenta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Establece una nueva contraseña con el token de restablecimiento recibido. El token se invalida al usarse y se cierran todas las sesiones del usuario",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Restablecer la contraseña",
+                "parameters": [
+                    {
+                        "description": "Token y nueva contraseña",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido, vencido o ya usado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Intercambia un refresh token por un nuevo token JWT y un nuevo refresh token. Cada refresh token se puede usar una sola vez; reutilizar uno ya renovado revoca toda la sesión",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Renovar el token de acceso",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token inválido, vencido, revocado o reutilizado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuario pendiente de revisión o rechazado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/problems/{code}": {
+            "get": {
+                "description": "Describe el tipo de problema identificado por el campo type de las respuestas de error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "errors"
+                ],
+                "summary": "Describir un tipo de problema",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "blacklisted",
+                        "description": "Código estable del error",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemTypeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/screenings/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recibe un lote de prospectos (JSON, CSV en el cuerpo con Content-Type text/csv, o archivo CSV en el campo \"file\" de un formulario multipart) y lo valida contra el servicio PLD en segundo plano. El CSV debe tener encabezado con las columnas id_number, name y email (solo administradores)",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Enviar lote de screening",
+                "parameters": [
+                    {
+                        "description": "Lote en formato JSON",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.BatchScreeningRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.BatchScreeningJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/screenings/batch/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene el estado de un lote de screening y el resultado de cada fila (solo administradores)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Obtener lote de screening",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del lote",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.BatchScreeningResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/screenings/batch/{id}/results.csv": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Descarga en CSV el resultado de cada fila de un lote de screening (solo administradores)",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Descargar resultados de un lote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del lote",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV con columnas line, id_number, name, email, status, reason, match_score, provider, screening_id, error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Crea un nuevo usuario validando contra el servicio PLD. Si el screening no es concluyente el usuario se crea con estado pending_review y no puede iniciar sesión hasta que cumplimiento lo apruebe",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Crear un nuevo usuario",
+                "parameters": [
+                    {
+                        "description": "Datos del usuario",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Email registrado o usuario en lista negra",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "503": {
+                        "description": "Servicio PLD no disponible",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información del usuario autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Obtener información del usuario autenticado",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza parcialmente el perfil del usuario autenticado; solo cambian los campos incluidos. Si cambia la identidad se valida de nuevo contra el servicio PLD: una identidad en lista negra rechaza el cambio y marca la cuenta, y una coincidencia no concluyente deja al usuario pendiente de revisión",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Actualizar el perfil del usuario autenticado",
+                "parameters": [
+                    {
+                        "description": "Campos a actualizar",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Email registrado o usuario en lista negra",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "503": {
+                        "description": "Servicio PLD no disponible",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Indica si el usuario autenticado tiene MFA activado, cuántos códigos de recuperación le quedan y si su rol lo exige",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Consultar el estado de MFA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activa MFA con un código de la aplicación autenticadora. Retorna los códigos de recuperación, que no se pueden volver a consultar, y tokens de una sesión iniciada con MFA. Los tokens de acceso y refresh tokens anteriores se revocan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Activar MFA",
+                "parameters": [
+                    {
+                        "description": "Código TOTP",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAConfirmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "No autenticado o código incorrecto",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "No hay una inscripción pendiente",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Desactiva MFA con un código de la aplicación autenticadora o un código de recuperación. No es posible si el rol del usuario exige MFA",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Desactivar MFA",
+                "parameters": [
+                    {
+                        "description": "Código TOTP o de recuperación",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFADisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "No autenticado o código incorrecto",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "El rol del usuario exige MFA",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA no está activado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Genera un secreto TOTP y el URI otpauth:// para mostrarlo como código QR. MFA se activa al confirmar un código en /users/me/mfa/confirm; iniciar de nuevo reemplaza el secreto pendiente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Iniciar la inscripción en MFA",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAEnrollmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA ya está activado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reemplaza los códigos de recuperación con un código de la aplicación autenticadora. Los códigos anteriores dejan de ser válidos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Regenerar los códigos de recuperación",
+                "parameters": [
+                    {
+                        "description": "Código TOTP",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "No autenticado o código incorrecto",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA no está activado",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cambia la contraseña del usuario autenticado; requiere la contraseña actual. Cierra todas las sesiones del usuario, incluida la actual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Cambiar la contraseña",
+                "parameters": [
+                    {
+                        "description": "Contraseña actual y nueva",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "No autenticado o contraseña actual incorrecta",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información de un usuario por su ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Obtener usuario por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un usuario por su ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Eliminar usuario",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza parcialmente el perfil de un usuario por su ID (solo administradores), con las mismas reglas que PATCH /users/me",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Actualizar el perfil de un usuario",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos a actualizar",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "409": {
+                        "description": "Email registrado o usuario en lista negra",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "503": {
+                        "description": "Servicio PLD no disponible",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/screenings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene el historial de validaciones PLD de un usuario. Solo lo puede consultar el propio usuario o un administrador",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Obtener historial de screenings PLD",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ScreeningListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ProblemDetails"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "crabi-test_internal_infrastructure_http_dto.BatchScreeningJobResponse": {
+            "description": "Estado de un lote de screening",
+            "type": "object",
+            "properties": {
+                "blacklisted": {
+                    "description": "@Description Filas en lista negra\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "clean": {
+                    "description": "@Description Filas sin coincidencias\n@Example \"75\"",
+                    "type": "integer",
+                    "example": 75
+                },
+                "created_at": {
+                    "description": "@Description Fecha de creación del lote\n@Example \"2024-01-15T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "failed": {
+                    "description": "@Description Filas inválidas o con error del servicio PLD\n@Example \"2\"",
+                    "type": "integer",
+                    "example": 2
+                },
+                "finished_at": {
+                    "description": "@Description Fecha de término (vacía si sigue en curso)\n@Example \"2024-01-15T10:32:00Z\"",
+                    "type": "string",
+                    "example": "2024-01-15T10:32:00Z"
+                },
+                "id": {
+                    "description": "@Description ID del lote\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "processed": {
+                    "description": "@Description Filas procesadas\n@Example \"80\"",
+                    "type": "integer",
+                    "example": 80
+                },
+                "requested_by": {
+                    "description": "@Description ID del administrador que envió el lote\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "review": {
+                    "description": "@Description Filas con coincidencia no concluyente\n@Example \"2\"",
+                    "type": "integer",
+                    "example": 2
+                },
+                "source": {
+                    "description": "@Description Formato recibido (json, csv)\n@Example \"csv\"",
+                    "type": "string",
+                    "example": "csv"
+                },
+                "status": {
+                    "description": "@Description Estado del lote (running, completed)\n@Example \"running\"",
+                    "type": "string",
+                    "example": "running"
+                },
+                "total": {
+                    "description": "@Description Filas del lote\n@Example \"120\"",
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated_at": {
+                    "description": "@Description Fecha de la última actualización\n@Example \"2024-01-15T10:31:00Z\"",
+                    "type": "string",
+                    "example": "2024-01-15T10:31:00Z"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.BatchScreeningRequest": {
+            "description": "Lote de prospectos a validar",
+            "type": "object",
+            "required": [
+                "rows"
+            ],
+            "properties": {
+                "rows": {
+                    "description": "@Description Prospectos a validar",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.BatchScreeningRowRequest"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.BatchScreeningResultResponse": {
+            "description": "Lote de screening y resultados por fila",
+            "type": "object",
+            "properties": {
+                "job": {
+                    "description": "@Description Estado del lote",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.BatchScreeningJobResponse"
+                        }
+                    ]
+                },
+                "rows": {
+                    "description": "@Description Resultados en el orden recibido",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.BatchScreeningRowResponse"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.BatchScreeningRowRequest": {
+            "description": "Prospecto a validar contra el servicio PLD",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "@Description Email del prospecto\n@Example \"juan.perez@email.com\"",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                },
+                "id_number": {
+                    "description": "@Description Número de identificación del prospecto\n@Example \"12345678\"",
+                    "type": "string",
+                    "example": "12345678"
+                },
+                "name": {
+                    "description": "@Description Nombre completo del prospecto\n@Example \"Juan Pérez\"",
+                    "type": "string",
+                    "example": "Juan Pérez"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.BatchScreeningRowResponse": {
+            "description": "Resultado de un prospecto del lote",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "@Description Email del prospecto\n@Example \"juan.perez@email.com\"",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                },
+                "error": {
+                    "description": "@Description Error de la fila, si lo hubo\n@Example \"error validando usuario con servicio PLD\"",
+                    "type": "string",
+                    "example": "error validando usuario con servicio PLD"
+                },
+                "id_number": {
+                    "description": "@Description Número de identificación del prospecto\n@Example \"12345678\"",
+                    "type": "string",
+                    "example": "12345678"
+                },
+                "line": {
+                    "description": "@Description Posición de la fila en el archivo recibido (desde 1)\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "match_score": {
+                    "description": "@Description Similitud (0..1) de la mejor coincidencia\n@Example \"0\"",
+                    "type": "number",
+                    "example": 0
+                },
+                "name": {
+                    "description": "@Description Nombre completo del prospecto\n@Example \"Juan Pérez\"",
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "provider": {
+                    "description": "@Description Proveedor que respondió\n@Example \"pld-http\"",
+                    "type": "string",
+                    "example": "pld-http"
+                },
+                "reason": {
+                    "description": "@Description Motivo reportado por el proveedor\n@Example \"Usuario en lista negra\"",
+                    "type": "string",
+                    "example": "Usuario en lista negra"
+                },
+                "screening_id": {
+                    "description": "@Description ID del screening registrado en el historial\n@Example \"15\"",
+                    "type": "integer",
+                    "example": 15
+                },
+                "status": {
+                    "description": "@Description Resultado (pending, clean, blacklisted, review, error)\n@Example \"clean\"",
+                    "type": "string",
+                    "example": "clean"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ChangePasswordRequest": {
+            "description": "Contraseña actual y nueva contraseña",
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "@Description Contraseña actual\n@Example \"password123\"\n@Required",
+                    "type": "string",
+                    "example": "password123"
+                },
+                "new_password": {
+                    "description": "@Description Nueva contraseña (mínimo 8 caracteres, distinta de la actual)\n@Example \"nueva-password456\"\n@Required",
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "nueva-password456"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.CreateUserRequest": {
+            "description": "Solicitud para crear un nuevo usuario",
+            "type": "object",
+            "required": [
+                "email",
+                "id_number",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "date_of_birth": {
+                    "description": "@Description Fecha de nacimiento en formato AAAA-MM-DD\n@Example \"1985-04-12\"",
+                    "type": "string",
+                    "example": "1985-04-12"
+                },
+                "email": {
+                    "description": "@Description Email del usuario (debe ser único)\n@Example \"juan.perez@email.com\"\n@Required",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                },
+                "given_names": {
+                    "description": "@Description Nombre(s) de pila; si se indica, el apellido paterno es obligatorio\n@Example \"María de la Luz\"",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "María de la Luz"
+                },
+                "id_number": {
+                    "description": "@Description Número de identificación personal\n@Example \"12345678\"\n@Required",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 8,
+                    "example": "12345678"
+                },
+                "maternal_surname": {
+                    "description": "@Description Apellido materno (opcional)\n@Example \"López\"",
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "López"
+                },
+                "name": {
+                    "description": "@Description Nombre completo del usuario\n@Example \"Juan Pérez\"\n@Required",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Juan Pérez"
+                },
+                "nationality": {
+                    "description": "@Description Nacionalidad (código ISO 3166-1 alfa-2 en mayúsculas)\n@Example \"MX\"",
+                    "type": "string",
+                    "example": "MX"
+                },
+                "password": {
+                    "description": "@Description Contraseña del usuario (mínimo 8 caracteres)\n@Example \"password123\"\n@Required",
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "password123"
+                },
+                "paternal_surname": {
+                    "description": "@Description Apellido paterno\n@Example \"García\"",
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "García"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.FieldError": {
+            "description": "Error de validación de un campo",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "@Description Regla de validación que no se cumple\n@Example \"required\"",
+                    "type": "string",
+                    "example": "required"
+                },
+                "field": {
+                    "description": "@Description Nombre del campo en el JSON de la solicitud\n@Example \"email\"",
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "description": "@Description Descripción del error\n@Example \"es obligatorio\"",
+                    "type": "string",
+                    "example": "es obligatorio"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ForgotPasswordRequest": {
+            "description": "Email de la cuenta a restablecer",
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "@Description Email del usuario\n@Example \"juan.perez@email.com\"\n@Required",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.LoginRequest": {
+            "description": "Solicitud para autenticarse en el sistema",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
                     "description": "@Description Email del usuario\n@Example \"juan.perez@email.com\"\n@Required",
                     "type": "string",
                     "example": "juan.perez@email.com"
                 },
-                "password": {
-                    "description": "@Description Contraseña del usuario\n@Example \"password123\"\n@Required",
+                "password": {
+                    "description": "@Description Contraseña del usuario\n@Example \"password123\"\n@Required",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.LoginResponse": {
+            "description": "Respuesta de autenticación exitosa",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "@Description Segundos de vigencia del token JWT\n@Example \"900\"",
+                    "type": "integer",
+                    "example": 900
+                },
+                "mfa_enrollment_required": {
+                    "description": "@Description Indica que el rol del usuario exige MFA y aún no lo activó; hasta hacerlo solo puede usar los endpoints de /users/me/mfa\n@Example \"false\"",
+                    "type": "boolean",
+                    "example": false
+                },
+                "refresh_token": {
+                    "description": "@Description Refresh token para obtener un nuevo token JWT en /auth/refresh\n@Example \"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN\"",
+                    "type": "string",
+                    "example": "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+                },
+                "refresh_token_expires_at": {
+                    "description": "@Description Fecha de vencimiento del refresh token\n@Example \"2026-11-16T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2026-11-16T10:30:00Z"
+                },
+                "token": {
+                    "description": "@Description Token JWT para autenticación\n@Example \"eyJhbGciOiJFUzI1NiIsImtpZCI6...\"",
+                    "type": "string",
+                    "example": "eyJhbGciOiJFUzI1NiIsImtpZCI6..."
+                },
+                "user": {
+                    "description": "@Description Información del usuario autenticado",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.LogoutRequest": {
+            "description": "Refresh token de la sesión y alcance del cierre",
+            "type": "object",
+            "properties": {
+                "all_sessions": {
+                    "description": "@Description Cierra todas las sesiones del usuario en lugar de solo la actual\n@Example \"false\"",
+                    "type": "boolean",
+                    "example": false
+                },
+                "refresh_token": {
+                    "description": "@Description Refresh token de la sesión, que se revoca junto con el token de acceso\n@Example \"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN\"",
+                    "type": "string",
+                    "example": "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAChallengeResponse": {
+            "description": "Desafío MFA que se completa en /auth/mfa/verify",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "@Description Segundos de vigencia del desafío\n@Example \"300\"",
+                    "type": "integer",
+                    "example": 300
+                },
+                "mfa_required": {
+                    "description": "@Description Siempre true: el login requiere un segundo factor\n@Example \"true\"",
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfa_token": {
+                    "description": "@Description Token del desafío MFA (un solo uso, con vencimiento)\n@Example \"Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE\"",
+                    "type": "string",
+                    "example": "Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFACodeRequest": {
+            "description": "Código de la aplicación autenticadora",
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "@Description Código de 6 dígitos de la aplicación autenticadora\n@Example \"492039\"\n@Required",
+                    "type": "string",
+                    "example": "492039"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAConfirmResponse": {
+            "description": "Códigos de recuperación y tokens de una sesión iniciada con MFA",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "@Description Segundos de vigencia del token JWT\n@Example \"900\"",
+                    "type": "integer",
+                    "example": 900
+                },
+                "recovery_codes": {
+                    "description": "@Description Códigos de recuperación de un solo uso; no se pueden volver a consultar\n@Example \"k3v9q-x2m7p,a7d2w-p4n8c\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3v9q-x2m7p",
+                        "a7d2w-p4n8c"
+                    ]
+                },
+                "refresh_token": {
+                    "description": "@Description Refresh token de la sesión con MFA; los refresh tokens anteriores se revocan\n@Example \"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN\"",
+                    "type": "string",
+                    "example": "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+                },
+                "refresh_token_expires_at": {
+                    "description": "@Description Fecha de vencimiento del refresh token\n@Example \"2026-11-16T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2026-11-16T10:30:00Z"
+                },
+                "token": {
+                    "description": "@Description Token JWT de la sesión con MFA\n@Example \"eyJhbGciOiJFUzI1NiIsImtpZCI6...\"",
+                    "type": "string",
+                    "example": "eyJhbGciOiJFUzI1NiIsImtpZCI6..."
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFADisableRequest": {
+            "description": "Código TOTP o código de recuperación",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "@Description Código de 6 dígitos de la aplicación autenticadora\n@Example \"492039\"",
+                    "type": "string",
+                    "example": "492039"
+                },
+                "recovery_code": {
+                    "description": "@Description Código de recuperación, si no se envía code\n@Example \"k3v9q-x2m7p\"",
+                    "type": "string",
+                    "example": "k3v9q-x2m7p"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAEnrollmentResponse": {
+            "description": "Secreto TOTP para configurar la aplicación autenticadora",
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "description": "@Description URI otpauth:// para mostrar como código QR\n@Example \"otpauth://totp/Crabi:juan.perez@email.com?algorithm=SHA1\u0026digits=6\u0026issuer=Crabi\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP\"",
+                    "type": "string",
+                    "example": "otpauth://totp/Crabi:juan.perez@email.com?algorithm=SHA1\u0026digits=6\u0026issuer=Crabi\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "description": "@Description Secreto en base32, para ingresarlo manualmente en la aplicación\n@Example \"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP\"",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAPolicyListResponse": {
+            "description": "Política MFA de cada rol",
+            "type": "object",
+            "properties": {
+                "policies": {
+                    "description": "@Description Políticas por rol; los roles sin política configurada no exigen MFA",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.MFAPolicyResponse"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAPolicyRequest": {
+            "description": "Indica si el rol exige MFA",
+            "type": "object",
+            "required": [
+                "required"
+            ],
+            "properties": {
+                "required": {
+                    "description": "@Description Exigir MFA a los usuarios del rol\n@Example \"true\"\n@Required",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAPolicyResponse": {
+            "description": "Política MFA de un rol",
+            "type": "object",
+            "properties": {
+                "required": {
+                    "description": "@Description Indica si el rol exige MFA\n@Example \"true\"",
+                    "type": "boolean",
+                    "example": true
+                },
+                "role": {
+                    "description": "@Description Rol\n@Example \"admin\"",
+                    "type": "string",
+                    "example": "admin"
+                },
+                "updated_at": {
+                    "description": "@Description Fecha de la última modificación\n@Example \"2026-10-17T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2026-10-17T10:30:00Z"
+                },
+                "updated_by": {
+                    "description": "@Description ID del administrador que configuró la política\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFARecoveryCodesResponse": {
+            "description": "Nuevos códigos de recuperación; los anteriores dejan de ser válidos",
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "@Description Códigos de recuperación de un solo uso; no se pueden volver a consultar\n@Example \"k3v9q-x2m7p,a7d2w-p4n8c\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3v9q-x2m7p",
+                        "a7d2w-p4n8c"
+                    ]
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAStatusResponse": {
+            "description": "Estado de la autenticación multifactor del usuario autenticado",
+            "type": "object",
+            "properties": {
+                "confirmed_at": {
+                    "description": "@Description Fecha en que se activó MFA\n@Example \"2026-10-17T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2026-10-17T10:30:00Z"
+                },
+                "enabled": {
+                    "description": "@Description Indica si MFA está activado\n@Example \"true\"",
+                    "type": "boolean",
+                    "example": true
+                },
+                "pending": {
+                    "description": "@Description Indica si hay una inscripción iniciada sin confirmar\n@Example \"false\"",
+                    "type": "boolean",
+                    "example": false
+                },
+                "recovery_codes_remaining": {
+                    "description": "@Description Códigos de recuperación sin usar\n@Example \"10\"",
+                    "type": "integer",
+                    "example": 10
+                },
+                "required": {
+                    "description": "@Description Indica si el rol del usuario exige MFA\n@Example \"false\"",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.MFAVerifyRequest": {
+            "description": "Token del desafío MFA y un código TOTP o un código de recuperación",
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "@Description Código de 6 dígitos de la aplicación autenticadora\n@Example \"492039\"",
+                    "type": "string",
+                    "example": "492039"
+                },
+                "mfa_token": {
+                    "description": "@Description Token del desafío recibido en /auth/login\n@Example \"Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE\"\n@Required",
+                    "type": "string",
+                    "example": "Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE"
+                },
+                "recovery_code": {
+                    "description": "@Description Código de recuperación, si no se envía code (cada uno se puede usar una sola vez)\n@Example \"k3v9q-x2m7p\"",
+                    "type": "string",
+                    "example": "k3v9q-x2m7p"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ProblemDetails": {
+            "description": "Respuesta de error (application/problem+json, RFC 7807)",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "@Description Código estable del error, pensado para ser interpretado por los clientes\n@Example \"invalid_input\"",
+                    "type": "string",
+                    "example": "invalid_input"
+                },
+                "detail": {
+                    "description": "@Description Explicación de esta ocurrencia del problema\n@Example \"La solicitud tiene campos inválidos\"",
+                    "type": "string",
+                    "example": "La solicitud tiene campos inválidos"
+                },
+                "errors": {
+                    "description": "@Description Errores de validación por campo",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.FieldError"
+                    }
+                },
+                "instance": {
+                    "description": "@Description Ruta de la solicitud que originó el problema\n@Example \"/api/v1/users\"",
+                    "type": "string",
+                    "example": "/api/v1/users"
+                },
+                "status": {
+                    "description": "@Description Código HTTP de la respuesta\n@Example 400",
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "description": "@Description Resumen del tipo de problema; no cambia entre ocurrencias\n@Example \"Solicitud inválida\"",
+                    "type": "string",
+                    "example": "Solicitud inválida"
+                },
+                "type": {
+                    "description": "@Description URI que identifica el tipo de problema\n@Example \"/api/v1/problems/invalid_input\"",
+                    "type": "string",
+                    "example": "/api/v1/problems/invalid_input"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ProblemTypeResponse": {
+            "description": "Tipo de problema referenciado por el campo type de las respuestas de error",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "@Description Código estable del error\n@Example \"blacklisted\"",
+                    "type": "string",
+                    "example": "blacklisted"
+                },
+                "status": {
+                    "description": "@Description Código HTTP con el que se reporta\n@Example 409",
+                    "type": "integer",
+                    "example": 409
+                },
+                "title": {
+                    "description": "@Description Resumen del tipo de problema\n@Example \"Usuario en lista negra\"",
+                    "type": "string",
+                    "example": "Usuario en lista negra"
+                },
+                "type": {
+                    "description": "@Description URI que identifica el tipo de problema\n@Example \"/api/v1/problems/blacklisted\"",
+                    "type": "string",
+                    "example": "/api/v1/problems/blacklisted"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.RefreshTokenRequest": {
+            "description": "Refresh token emitido en el login o en la renovación anterior",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "@Description Refresh token vigente (cada uno se puede usar una sola vez)\n@Example \"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN\"\n@Required",
+                    "type": "string",
+                    "example": "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.RejectedApplicationListResponse": {
+            "description": "Listado de solicitudes de alta rechazadas",
+            "type": "object",
+            "properties": {
+                "rejected_applications": {
+                    "description": "@Description Solicitudes rechazadas de la más reciente a la más antigua",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.RejectedApplicationResponse"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.RejectedApplicationResponse": {
+            "description": "Solicitud de alta rechazada por el servicio PLD",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description Fecha del rechazo\n@Example \"2024-01-15T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "email": {
+                    "description": "@Description Email del solicitante\n@Example \"juan.perez@email.com\"",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                },
+                "id": {
+                    "description": "@Description ID del registro\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id_number": {
+                    "description": "@Description Número de identificación del solicitante\n@Example \"12345678\"",
                     "type": "string",
-                    "example": "password123"
+                    "example": "12345678"
+                },
+                "name": {
+                    "description": "@Description Nombre del solicitante\n@Example \"Juan Pérez\"",
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "provider": {
+                    "description": "@Description Proveedor que reportó la coincidencia\n@Example \"pld-http\"",
+                    "type": "string",
+                    "example": "pld-http"
+                },
+                "reason": {
+                    "description": "@Description Motivo del rechazo\n@Example \"Usuario en lista negra\"",
+                    "type": "string",
+                    "example": "Usuario en lista negra"
+                },
+                "screening_id": {
+                    "description": "@Description ID del screening asociado\n@Example \"10\"",
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "crabi-test_internal_infrastructure_http_dto.LoginResponse": {
-            "description": "Respuesta de autenticación exitosa",
+        "crabi-test_internal_infrastructure_http_dto.RescreeningRunResponse": {
+            "description": "Ejecución del re-screening periódico de la base de usuarios",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "@Description Error que detuvo la ejecución\n@Example \"error leyendo usuarios\"",
+                    "type": "string",
+                    "example": "error leyendo usuarios"
+                },
+                "failed": {
+                    "description": "@Description Usuarios cuya validación falló\n@Example \"0\"",
+                    "type": "integer",
+                    "example": 0
+                },
+                "finished_at": {
+                    "description": "@Description Fecha de finalización\n@Example \"2024-03-01T03:10:00Z\"",
+                    "type": "string",
+                    "example": "2024-03-01T03:10:00Z"
+                },
+                "flagged": {
+                    "description": "@Description Usuarios marcados en lista negra durante la ejecución\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "@Description ID de la ejecución\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_user_id": {
+                    "description": "@Description ID del último usuario procesado\n@Example \"250\"",
+                    "type": "integer",
+                    "example": 250
+                },
+                "processed": {
+                    "description": "@Description Usuarios procesados\n@Example \"250\"",
+                    "type": "integer",
+                    "example": 250
+                },
+                "started_at": {
+                    "description": "@Description Fecha de inicio\n@Example \"2024-03-01T03:00:00Z\"",
+                    "type": "string",
+                    "example": "2024-03-01T03:00:00Z"
+                },
+                "status": {
+                    "description": "@Description Estado de la ejecución (running, completed, failed)\n@Example \"running\"",
+                    "type": "string",
+                    "example": "running"
+                },
+                "trigger": {
+                    "description": "@Description Origen de la ejecución (scheduled, manual)\n@Example \"manual\"",
+                    "type": "string",
+                    "example": "manual"
+                },
+                "updated_at": {
+                    "description": "@Description Fecha del último avance registrado\n@Example \"2024-03-01T03:05:00Z\"",
+                    "type": "string",
+                    "example": "2024-03-01T03:05:00Z"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ResetPasswordRequest": {
+            "description": "Token de restablecimiento recibido y nueva contraseña",
             "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
             "properties": {
+                "new_password": {
+                    "description": "@Description Nueva contraseña (mínimo 8 caracteres)\n@Example \"nueva-password456\"\n@Required",
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "nueva-password456"
+                },
                 "token": {
-                    "description": "@Description Token JWT para autenticación\n@Example \"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...\"",
+                    "description": "@Description Token de restablecimiento (un solo uso, con vencimiento)\n@Example \"q3Jx9v0C2m8pY7tR1bW4nK6eH5sD0aLzF8uGiQ2oVcE\"\n@Required",
+                    "type": "string",
+                    "example": "q3Jx9v0C2m8pY7tR1bW4nK6eH5sD0aLzF8uGiQ2oVcE"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ReviewDecisionListResponse": {
+            "description": "Decisiones de revisión de un usuario",
+            "type": "object",
+            "properties": {
+                "decisions": {
+                    "description": "@Description Decisiones de la más reciente a la más antigua",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewDecisionResponse"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ReviewDecisionRequest": {
+            "description": "Justificación de la decisión de revisión",
+            "type": "object",
+            "required": [
+                "justification"
+            ],
+            "properties": {
+                "justification": {
+                    "description": "@Description Justificación de la decisión (obligatoria, queda en el log de auditoría)\n@Example \"Fecha de nacimiento y CURP no coinciden con la entrada de la lista\"",
+                    "type": "string",
+                    "example": "Fecha de nacimiento y CURP no coinciden con la entrada de la lista"
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ReviewDecisionResponse": {
+            "description": "Decisión de revisión de cumplimiento",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description Fecha de la decisión\n@Example \"2024-01-15T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "decision": {
+                    "description": "@Description Decisión (approved, rejected)\n@Example \"approved\"",
+                    "type": "string",
+                    "example": "approved"
+                },
+                "id": {
+                    "description": "@Description ID de la decisión\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "justification": {
+                    "description": "@Description Justificación de la decisión\n@Example \"Fecha de nacimiento y CURP no coinciden con la entrada de la lista\"",
+                    "type": "string",
+                    "example": "Fecha de nacimiento y CURP no coinciden con la entrada de la lista"
+                },
+                "reviewer_id": {
+                    "description": "@Description ID del oficial de cumplimiento que tomó la decisión\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "description": "@Description ID del usuario revisado\n@Example \"12\"",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ReviewItemResponse": {
+            "description": "Alta pendiente de revisión de cumplimiento",
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "@Description Motivo por el que el alta requiere revisión\n@Example \"Posible coincidencia en lista uif_lpb: María López (Lavado de dinero), similitud 0.81\"",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "Posible coincidencia en lista uif_lpb: María López (Lavado de dinero), similitud 0.81"
+                },
+                "screening": {
+                    "description": "@Description Screening más reciente del usuario",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ScreeningResponse"
+                        }
+                    ]
                 },
                 "user": {
-                    "description": "@Description Información del usuario autenticado",
+                    "description": "@Description Usuario pendiente de revisión",
                     "allOf": [
                         {
                             "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.UserResponse"
@@ -376,6 +2837,98 @@ const docTemplate = `{
                 }
             }
         },
+        "crabi-test_internal_infrastructure_http_dto.ReviewQueueResponse": {
+            "description": "Cola de altas pendientes de revisión",
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "@Description Altas pendientes de la más antigua a la más reciente",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ReviewItemResponse"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ScreeningListResponse": {
+            "description": "Historial de screenings PLD",
+            "type": "object",
+            "properties": {
+                "screenings": {
+                    "description": "@Description Screenings del más reciente al más antiguo",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crabi-test_internal_infrastructure_http_dto.ScreeningResponse"
+                    }
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.ScreeningResponse": {
+            "description": "Registro de auditoría de una validación PLD",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description Fecha de la validación\n@Example \"2024-01-15T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "email": {
+                    "description": "@Description Email validado\n@Example \"juan.perez@email.com\"",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                },
+                "error": {
+                    "description": "@Description Error reportado por el proveedor, si lo hubo\n@Example \"servicio PLD retornó código 503\"",
+                    "type": "string",
+                    "example": "servicio PLD retornó código 503"
+                },
+                "id": {
+                    "description": "@Description ID del screening\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id_number": {
+                    "description": "@Description Número de identificación validado\n@Example \"12345678\"",
+                    "type": "string",
+                    "example": "12345678"
+                },
+                "latency_ms": {
+                    "description": "@Description Latencia del proveedor en milisegundos\n@Example \"120\"",
+                    "type": "integer",
+                    "example": 120
+                },
+                "match_score": {
+                    "description": "@Description Similitud (0..1) de la mejor coincidencia en listas de sanciones\n@Example \"0.93\"",
+                    "type": "number",
+                    "example": 0.93
+                },
+                "provider": {
+                    "description": "@Description Proveedor que realizó la validación\n@Example \"pld-http\"",
+                    "type": "string",
+                    "example": "pld-http"
+                },
+                "raw_response": {
+                    "description": "@Description Respuesta cruda del proveedor\n@Example \"{\\\"is_in_blacklist\\\":false}\"",
+                    "type": "string",
+                    "example": "{\"is_in_blacklist\":false}"
+                },
+                "request_payload": {
+                    "description": "@Description Payload enviado al proveedor\n@Example \"{\\\"email\\\":\\\"juan.perez@email.com\\\",\\\"first_name\\\":\\\"Juan\\\",\\\"id_number\\\":\\\"12345678\\\",\\\"last_name\\\":\\\"Pérez\\\"}\"",
+                    "type": "string",
+                    "example": "{\"email\":\"juan.perez@email.com\",\"first_name\":\"Juan\",\"id_number\":\"12345678\",\"last_name\":\"Pérez\"}"
+                },
+                "status": {
+                    "description": "@Description Estado normalizado (clean, blacklisted, error)\n@Example \"clean\"",
+                    "type": "string",
+                    "example": "clean"
+                },
+                "user_id": {
+                    "description": "@Description ID del usuario vinculado (vacío si el alta no se completó)\n@Example \"1\"",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "crabi-test_internal_infrastructure_http_dto.SuccessResponse": {
             "description": "Respuesta de operación exitosa",
             "type": "object",
@@ -387,6 +2940,85 @@ const docTemplate = `{
                 }
             }
         },
+        "crabi-test_internal_infrastructure_http_dto.TokenResponse": {
+            "description": "Nuevo token JWT y nuevo refresh token",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "@Description Segundos de vigencia del token JWT\n@Example \"900\"",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "description": "@Description Nuevo refresh token; el anterior deja de ser válido\n@Example \"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN\"",
+                    "type": "string",
+                    "example": "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+                },
+                "refresh_token_expires_at": {
+                    "description": "@Description Fecha de vencimiento del nuevo refresh token\n@Example \"2026-11-16T10:30:00Z\"",
+                    "type": "string",
+                    "example": "2026-11-16T10:30:00Z"
+                },
+                "token": {
+                    "description": "@Description Token JWT para autenticación\n@Example \"eyJhbGciOiJFUzI1NiIsImtpZCI6...\"",
+                    "type": "string",
+                    "example": "eyJhbGciOiJFUzI1NiIsImtpZCI6..."
+                }
+            }
+        },
+        "crabi-test_internal_infrastructure_http_dto.UpdateProfileRequest": {
+            "description": "Actualización parcial del perfil: solo cambian los campos incluidos. Cambiar la identidad (nombre, email, identificación o datos estructurados) valida de nuevo al usuario contra el servicio PLD",
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "description": "@Description Fecha de nacimiento en formato AAAA-MM-DD\n@Example \"1985-04-12\"",
+                    "type": "string",
+                    "example": "1985-04-12"
+                },
+                "email": {
+                    "description": "@Description Email del usuario (debe ser único)\n@Example \"juan.perez@email.com\"",
+                    "type": "string",
+                    "example": "juan.perez@email.com"
+                },
+                "given_names": {
+                    "description": "@Description Nombre(s) de pila; requiere apellido paterno\n@Example \"María de la Luz\"",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "María de la Luz"
+                },
+                "id_number": {
+                    "description": "@Description Número de identificación personal\n@Example \"12345678\"",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 8,
+                    "example": "12345678"
+                },
+                "maternal_surname": {
+                    "description": "@Description Apellido materno; una cadena vacía lo elimina\n@Example \"López\"",
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "López"
+                },
+                "name": {
+                    "description": "@Description Nombre completo del usuario\n@Example \"Juan Pérez\"",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Juan Pérez"
+                },
+                "nationality": {
+                    "description": "@Description Nacionalidad (código ISO 3166-1 alfa-2 en mayúsculas)\n@Example \"MX\"",
+                    "type": "string",
+                    "example": "MX"
+                },
+                "paternal_surname": {
+                    "description": "@Description Apellido paterno\n@Example \"García\"",
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "García"
+                }
+            }
+        },
         "crabi-test_internal_infrastructure_http_dto.UserResponse": {
             "description": "Información del usuario",
             "type": "object",
@@ -396,11 +3028,31 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2024-01-15T10:30:00Z"
                 },
+                "date_of_birth": {
+                    "description": "@Description Fecha de nacimiento en formato AAAA-MM-DD\n@Example \"1985-04-12\"",
+                    "type": "string",
+                    "example": "1985-04-12"
+                },
                 "email": {
                     "description": "@Description Email del usuario\n@Example \"juan.perez@email.com\"",
                     "type": "string",
                     "example": "juan.perez@email.com"
                 },
+                "flag_reason": {
+                    "description": "@Description Motivo reportado por el servicio PLD al marcar al usuario\n@Example \"Usuario en lista negra\"",
+                    "type": "string",
+                    "example": "Usuario en lista negra"
+                },
+                "flagged_at": {
+                    "description": "@Description Fecha en que el re-screening detectó al usuario en lista negra\n@Example \"2024-03-01T03:00:00Z\"",
+                    "type": "string",
+                    "example": "2024-03-01T03:00:00Z"
+                },
+                "given_names": {
+                    "description": "@Description Nombre(s) de pila\n@Example \"María de la Luz\"",
+                    "type": "string",
+                    "example": "María de la Luz"
+                },
                 "id": {
                     "description": "@Description ID único del usuario\n@Example \"1\"",
                     "type": "integer",
@@ -411,11 +3063,36 @@ const docTemplate = `{
                     "type": "string",
                     "example": "12345678"
                 },
+                "maternal_surname": {
+                    "description": "@Description Apellido materno\n@Example \"López\"",
+                    "type": "string",
+                    "example": "López"
+                },
                 "name": {
                     "description": "@Description Nombre completo del usuario\n@Example \"Juan Pérez\"",
                     "type": "string",
                     "example": "Juan Pérez"
                 },
+                "nationality": {
+                    "description": "@Description Nacionalidad (código ISO 3166-1 alfa-2 en mayúsculas)\n@Example \"MX\"",
+                    "type": "string",
+                    "example": "MX"
+                },
+                "paternal_surname": {
+                    "description": "@Description Apellido paterno\n@Example \"García\"",
+                    "type": "string",
+                    "example": "García"
+                },
+                "role": {
+                    "description": "@Description Rol del usuario (user, admin)\n@Example \"user\"",
+                    "type": "string",
+                    "example": "user"
+                },
+                "status": {
+                    "description": "@Description Estado del usuario (active, pending_review, rejected)\n@Example \"active\"",
+                    "type": "string",
+                    "example": "active"
+                },
                 "updated_at": {
                     "description": "@Description Fecha de última actualización del usuario\n@Example \"2024-01-15T10:30:00Z\"",
                     "type": "string",
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+)
+
+// batchScreeningJobColumns lista las columnas leídas en las consultas de lotes
+const batchScreeningJobColumns = `id, status, source, requested_by, total, processed, clean, blacklisted, review, failed, created_at, updated_at, finished_at`
+
+// BatchScreeningRepository implementa el repositorio de lotes de screening con SQLite
+type BatchScreeningRepository struct {
+	db *sql.DB
+}
+
+// NewBatchScreeningRepository crea una nueva instancia del repositorio de lotes de screening
+func NewBatchScreeningRepository(db *sql.DB) *BatchScreeningRepository {
+	return &BatchScreeningRepository{db: db}
+}
+
+// CreateJob registra un lote y sus filas en una sola transacción
+func (r *BatchScreeningRepository) CreateJob(ctx context.Context, job *domain.BatchScreeningJob, rows []*domain.BatchScreeningRow) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO batch_screening_jobs (status, source, requested_by, total, processed, clean, blacklisted, review, failed, created_at, updated_at, finished_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, job.Status, job.Source, job.RequestedBy, job.Total, job.Processed, job.Clean, job.Blacklisted, job.Review, job.Failed, job.CreatedAt, job.UpdatedAt, job.FinishedAt)
+	if err != nil {
+		return err
+	}
+
+	jobID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO batch_screening_rows (job_id, line, id_number, name, email, status, reason, match_score, provider, screening_id, error, processed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		result, err := stmt.ExecContext(ctx, jobID, row.Line, row.IDNumber, row.Name, row.Email, row.Status, row.Reason, row.MatchScore, row.Provider, row.ScreeningID, row.Error, row.ProcessedAt)
+		if err != nil {
+			return err
+		}
+		rowID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		row.ID = uint(rowID)
+		row.JobID = uint(jobID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	job.ID = uint(jobID)
+	return nil
+}
+
+// UpdateJob guarda el estado y los contadores de un lote
+func (r *BatchScreeningRepository) UpdateJob(ctx context.Context, job *domain.BatchScreeningJob) error {
+	query := `
+		UPDATE batch_screening_jobs
+		SET status = ?, processed = ?, clean = ?, blacklisted = ?, review = ?, failed = ?, updated_at = ?, finished_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, job.Status, job.Processed, job.Clean, job.Blacklisted, job.Review, job.Failed, job.UpdatedAt, job.FinishedAt, job.ID)
+	return err
+}
+
+// GetJob obtiene un lote por su ID
+func (r *BatchScreeningRepository) GetJob(ctx context.Context, id uint) (*domain.BatchScreeningJob, error) {
+	query := `SELECT ` + batchScreeningJobColumns + ` FROM batch_screening_jobs WHERE id = ?`
+
+	job, err := scanBatchScreeningJob(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ListUnfinishedJobs obtiene los lotes en curso, del más antiguo al más reciente
+func (r *BatchScreeningRepository) ListUnfinishedJobs(ctx context.Context) ([]*domain.BatchScreeningJob, error) {
+	query := `SELECT ` + batchScreeningJobColumns + ` FROM batch_screening_jobs WHERE status = ? ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.BatchScreeningStatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []*domain.BatchScreeningJob{}
+	for rows.Next() {
+		job, err := scanBatchScreeningJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// UpdateRow guarda el resultado de una fila
+func (r *BatchScreeningRepository) UpdateRow(ctx context.Context, row *domain.BatchScreeningRow) error {
+	query := `
+		UPDATE batch_screening_rows
+		SET status = ?, reason = ?, match_score = ?, provider = ?, screening_id = ?, error = ?, processed_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, row.Status, row.Reason, row.MatchScore, row.Provider, row.ScreeningID, row.Error, row.ProcessedAt, row.ID)
+	return err
+}
+
+// ListRows obtiene las filas de un lote en el orden recibido
+func (r *BatchScreeningRepository) ListRows(ctx context.Context, jobID uint) ([]*domain.BatchScreeningRow, error) {
+	query := `
+		SELECT id, job_id, line, id_number, name, email, status, reason, match_score, provider, screening_id, error, processed_at
+		FROM batch_screening_rows WHERE job_id = ?
+		ORDER BY line
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*domain.BatchScreeningRow{}
+	for rows.Next() {
+		row := &domain.BatchScreeningRow{}
+		var screeningID sql.NullInt64
+		var processedAt sql.NullTime
+
+		if err := rows.Scan(&row.ID, &row.JobID, &row.Line, &row.IDNumber, &row.Name, &row.Email, &row.Status, &row.Reason, &row.MatchScore, &row.Provider, &screeningID, &row.Error, &processedAt); err != nil {
+			return nil, err
+		}
+		if screeningID.Valid {
+			id := uint(screeningID.Int64)
+			row.ScreeningID = &id
+		}
+		if processedAt.Valid {
+			row.ProcessedAt = &processedAt.Time
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// scanBatchScreeningJob lee un lote desde una fila de resultados
+func scanBatchScreeningJob(row rowScanner) (*domain.BatchScreeningJob, error) {
+	job := &domain.BatchScreeningJob{}
+	var finishedAt sql.NullTime
+
+	err := row.Scan(&job.ID, &job.Status, &job.Source, &job.RequestedBy, &job.Total, &job.Processed, &job.Clean, &job.Blacklisted, &job.Review, &job.Failed, &job.CreatedAt, &job.UpdatedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return job, nil
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestBatchScreeningRepository_Lifecycle(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewBatchScreeningRepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	job := &domain.BatchScreeningJob{
+		Status:      domain.BatchScreeningStatusRunning,
+		Source:      domain.BatchScreeningSourceCSV,
+		RequestedBy: 1,
+		Total:       2,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	rows := []*domain.BatchScreeningRow{
+		{Line: 1, IDNumber: "111", Name: "Juan Pérez", Email: "juan@email.com", Status: domain.BatchRowStatusPending},
+		{Line: 2, IDNumber: "222", Name: "María López", Status: domain.BatchRowStatusPending},
+	}
+	if err := repo.CreateJob(ctx, job, rows); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if job.ID == 0 || rows[1].ID == 0 || rows[1].JobID != job.ID {
+		t.Fatalf("Expected generated IDs, got job %+v and row %+v", job, rows[1])
+	}
+
+	screeningID := uint(15)
+	rows[1].Status = domain.ScreeningStatusBlacklisted
+	rows[1].Reason = "Coincidencia en lista ofac_sdn"
+	rows[1].MatchScore = 0.95
+	rows[1].Provider = "local-watchlist"
+	rows[1].ScreeningID = &screeningID
+	rows[1].ProcessedAt = &now
+	if err := repo.UpdateRow(ctx, rows[1]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	unfinished, err := repo.ListUnfinishedJobs(ctx)
+	if err != nil || len(unfinished) != 1 || unfinished[0].ID != job.ID {
+		t.Fatalf("Expected the job to be unfinished, got %+v (%v)", unfinished, err)
+	}
+
+	job.Status = domain.BatchScreeningStatusCompleted
+	job.Processed = 1
+	job.Blacklisted = 1
+	job.FinishedAt = &now
+	if err := repo.UpdateJob(ctx, job); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found, err := repo.GetJob(ctx, job.ID)
+	if err != nil || found == nil || found.Status != domain.BatchScreeningStatusCompleted || found.Blacklisted != 1 || found.FinishedAt == nil {
+		t.Fatalf("Expected completed job, got %+v (%v)", found, err)
+	}
+	if missing, err := repo.GetJob(ctx, 99); err != nil || missing != nil {
+		t.Errorf("Expected no job, got %+v (%v)", missing, err)
+	}
+
+	stored, err := repo.ListRows(ctx, job.ID)
+	if err != nil || len(stored) != 2 {
+		t.Fatalf("Expected 2 rows, got %+v (%v)", stored, err)
+	}
+	if stored[0].Status != domain.BatchRowStatusPending || stored[0].ScreeningID != nil || stored[0].ProcessedAt != nil {
+		t.Errorf("Unexpected pending row %+v", stored[0])
+	}
+	if stored[1].ScreeningID == nil || *stored[1].ScreeningID != 15 || stored[1].MatchScore != 0.95 || stored[1].Reason == "" {
+		t.Errorf("Unexpected processed row %+v", stored[1])
+	}
+}
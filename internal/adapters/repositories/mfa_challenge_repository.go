@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"time"
+)
+
+// MFAChallengeRepository implementa el almacenamiento de desafíos MFA con SQLite
+type MFAChallengeRepository struct {
+	db *sql.DB
+}
+
+// NewMFAChallengeRepository crea una nueva instancia del repositorio de desafíos MFA
+func NewMFAChallengeRepository(db *sql.DB) *MFAChallengeRepository {
+	return &MFAChallengeRepository{db: db}
+}
+
+// CreateWithinLimit registra un desafío MFA si el usuario tiene menos de maxChallenges desafíos
+// creados desde since. El conteo y la inserción son una sola sentencia, por lo que inicios de
+// sesión simultáneos no superan el límite
+func (r *MFAChallengeRepository) CreateWithinLimit(ctx context.Context, challenge *domain.MFAChallenge, since time.Time, maxChallenges int) (bool, error) {
+	query := `
+		INSERT INTO mfa_challenges (user_id, token_hash, attempts, expires_at, completed_at, created_at)
+		SELECT ?, ?, ?, ?, ?, ?
+		WHERE (SELECT COUNT(*) FROM mfa_challenges WHERE user_id = ? AND created_at > ?) < ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, challenge.UserID, challenge.TokenHash, challenge.Attempts, challenge.ExpiresAt, challenge.CompletedAt, challenge.CreatedAt, challenge.UserID, since, maxChallenges)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+
+	challenge.ID = uint(id)
+	return true, nil
+}
+
+// GetByTokenHash obtiene un desafío por el hash de su token. Retorna nil si no existe
+func (r *MFAChallengeRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	query := `
+		SELECT id, user_id, token_hash, attempts, expires_at, completed_at, created_at
+		FROM mfa_challenges WHERE token_hash = ?
+	`
+
+	challenge := &domain.MFAChallenge{}
+	var completedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&challenge.ID, &challenge.UserID, &challenge.TokenHash, &challenge.Attempts, &challenge.ExpiresAt, &completedAt, &challenge.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		challenge.CompletedAt = &completedAt.Time
+	}
+	return challenge, nil
+}
+
+// ReserveAttempt consume un intento de un desafío pendiente y vigente antes de verificar el
+// código. La condición en la consulta garantiza que solicitudes simultáneas no superen
+// maxAttempts. Retorna false si el desafío ya no admite intentos
+func (r *MFAChallengeRepository) ReserveAttempt(ctx context.Context, id uint, maxAttempts int, now time.Time) (bool, error) {
+	query := `
+		UPDATE mfa_challenges SET attempts = attempts + 1
+		WHERE id = ? AND attempts < ? AND completed_at IS NULL AND expires_at > ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, maxAttempts, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// MarkCompleted marca como completado un desafío pendiente. La condición en la consulta
+// garantiza que un desafío se complete una sola vez
+func (r *MFAChallengeRepository) MarkCompleted(ctx context.Context, id uint, completedAt time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE mfa_challenges SET completed_at = ? WHERE id = ? AND completed_at IS NULL`, completedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestMFAChallengeRepository_AttemptsAndCompletion(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewMFAChallengeRepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	challenge := &domain.MFAChallenge{UserID: 1, TokenHash: "hash", ExpiresAt: now.Add(5 * time.Minute), CreatedAt: now}
+	if created, err := repo.CreateWithinLimit(ctx, challenge, now.Add(-time.Minute), 5); err != nil || !created {
+		t.Fatalf("Expected challenge to be created, got %t (%v)", created, err)
+	}
+	if challenge.ID == 0 {
+		t.Fatal("Expected challenge ID to be set")
+	}
+
+	for i := 0; i < 2; i++ {
+		if reserved, err := repo.ReserveAttempt(ctx, challenge.ID, 2, now); err != nil || !reserved {
+			t.Fatalf("Expected attempt %d to be reserved, got %t (%v)", i+1, reserved, err)
+		}
+	}
+	if reserved, _ := repo.ReserveAttempt(ctx, challenge.ID, 2, now); reserved {
+		t.Error("Expected no attempts left")
+	}
+	if reserved, _ := repo.ReserveAttempt(ctx, challenge.ID, 5, now.Add(5*time.Minute)); reserved {
+		t.Error("Expected expired challenge not to admit attempts")
+	}
+	if completed, err := repo.MarkCompleted(ctx, challenge.ID, now); err != nil || !completed {
+		t.Fatalf("Expected challenge to be completed, got %t (%v)", completed, err)
+	}
+	if completed, _ := repo.MarkCompleted(ctx, challenge.ID, now); completed {
+		t.Error("Expected challenge not to be completed twice")
+	}
+	if reserved, _ := repo.ReserveAttempt(ctx, challenge.ID, 5, now); reserved {
+		t.Error("Expected completed challenge not to admit attempts")
+	}
+
+	// Con un desafío en la ventana, el límite de uno rechaza el siguiente; fuera de la ventana no cuenta
+	next := &domain.MFAChallenge{UserID: 1, TokenHash: "otro", ExpiresAt: now.Add(5 * time.Minute), CreatedAt: now}
+	if created, err := repo.CreateWithinLimit(ctx, next, now.Add(-time.Minute), 1); err != nil || created {
+		t.Errorf("Expected the limit to reject the challenge, got %t (%v)", created, err)
+	}
+	if created, err := repo.CreateWithinLimit(ctx, next, now, 1); err != nil || !created {
+		t.Errorf("Expected challenge outside the window to be created, got %t (%v)", created, err)
+	}
+	other := &domain.MFAChallenge{UserID: 2, TokenHash: "otro-usuario", ExpiresAt: now.Add(5 * time.Minute), CreatedAt: now}
+	if created, _ := repo.CreateWithinLimit(ctx, other, now.Add(-time.Minute), 1); !created {
+		t.Error("Expected the limit to apply per user")
+	}
+
+	found, err := repo.GetByTokenHash(ctx, "hash")
+	if err != nil || found == nil || found.Attempts != 2 || found.CompletedAt == nil || found.UserID != 1 {
+		t.Errorf("Expected completed challenge with two attempts, got %+v (%v)", found, err)
+	}
+	if missing, err := repo.GetByTokenHash(ctx, "desconocido"); err != nil || missing != nil {
+		t.Errorf("Expected no challenge, got %+v (%v)", missing, err)
+	}
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+)
+
+// MFAPolicyRepository implementa el almacenamiento de políticas MFA por rol con SQLite
+type MFAPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewMFAPolicyRepository crea una nueva instancia del repositorio de políticas MFA
+func NewMFAPolicyRepository(db *sql.DB) *MFAPolicyRepository {
+	return &MFAPolicyRepository{db: db}
+}
+
+// List obtiene las políticas configuradas, ordenadas por rol
+func (r *MFAPolicyRepository) List(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT role, required, updated_by, updated_at FROM mfa_role_policies ORDER BY role`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []domain.MFARolePolicy
+	for rows.Next() {
+		var policy domain.MFARolePolicy
+		if err := rows.Scan(&policy.Role, &policy.Required, &policy.UpdatedBy, &policy.UpdatedAt); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+// Save crea o reemplaza la política de un rol
+func (r *MFAPolicyRepository) Save(ctx context.Context, policy *domain.MFARolePolicy) error {
+	query := `
+		INSERT INTO mfa_role_policies (role, required, updated_by, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(role) DO UPDATE SET required = excluded.required, updated_by = excluded.updated_by, updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, policy.Role, policy.Required, policy.UpdatedBy, policy.UpdatedAt)
+	return err
+}
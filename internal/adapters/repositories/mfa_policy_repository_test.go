@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestMFAPolicyRepository_SaveAndList(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewMFAPolicyRepository(userRepo.db)
+	ctx := context.Background()
+
+	if policies, err := repo.List(ctx); err != nil || len(policies) != 0 {
+		t.Fatalf("Expected no policies, got %+v (%v)", policies, err)
+	}
+
+	now := time.Now()
+	for _, policy := range []*domain.MFARolePolicy{
+		{Role: domain.RoleUser, Required: true, UpdatedBy: 1, UpdatedAt: now},
+		{Role: domain.RoleAdmin, Required: true, UpdatedBy: 1, UpdatedAt: now},
+		{Role: domain.RoleUser, Required: false, UpdatedBy: 2, UpdatedAt: now},
+	} {
+		if err := repo.Save(ctx, policy); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	policies, err := repo.List(ctx)
+	if err != nil || len(policies) != 2 {
+		t.Fatalf("Expected 2 policies, got %+v (%v)", policies, err)
+	}
+	if policies[0].Role != domain.RoleAdmin || !policies[0].Required {
+		t.Errorf("Expected required admin policy, got %+v", policies[0])
+	}
+	if policies[1].Role != domain.RoleUser || policies[1].Required || policies[1].UpdatedBy != 2 {
+		t.Errorf("Expected replaced user policy, got %+v", policies[1])
+	}
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"time"
+)
+
+// MFARepository implementa el almacenamiento de inscripciones TOTP y códigos de recuperación
+// con SQLite
+type MFARepository struct {
+	db *sql.DB
+}
+
+// NewMFARepository crea una nueva instancia del repositorio de MFA
+func NewMFARepository(db *sql.DB) *MFARepository {
+	return &MFARepository{db: db}
+}
+
+// GetEnrollment obtiene la inscripción TOTP de un usuario. Retorna nil si no existe
+func (r *MFARepository) GetEnrollment(ctx context.Context, userID uint) (*domain.MFAEnrollment, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at
+		FROM mfa_enrollments WHERE user_id = ?
+	`
+
+	enrollment := &domain.MFAEnrollment{}
+	var confirmedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&enrollment.UserID, &enrollment.Secret, &confirmedAt, &enrollment.LastUsedStep, &enrollment.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if confirmedAt.Valid {
+		enrollment.ConfirmedAt = &confirmedAt.Time
+	}
+	return enrollment, nil
+}
+
+// SaveEnrollment crea la inscripción pendiente o reemplaza el secreto de la que estaba
+// pendiente. La condición del upsert impide reemplazar una inscripción confirmada
+func (r *MFARepository) SaveEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) (bool, error) {
+	query := `
+		INSERT INTO mfa_enrollments (user_id, secret, confirmed_at, last_used_step, created_at)
+		VALUES (?, ?, NULL, 0, ?)
+		ON CONFLICT(user_id) DO UPDATE SET secret = excluded.secret, last_used_step = 0, created_at = excluded.created_at
+		WHERE mfa_enrollments.confirmed_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, enrollment.UserID, enrollment.Secret, enrollment.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ConfirmEnrollment confirma la inscripción pendiente y reemplaza los códigos de recuperación
+// en una sola transacción
+func (r *MFARepository) ConfirmEnrollment(ctx context.Context, userID uint, step int64, confirmedAt time.Time, codeHashes []string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE mfa_enrollments SET confirmed_at = ?, last_used_step = ?
+		WHERE user_id = ? AND confirmed_at IS NULL AND last_used_step < ?
+	`, confirmedAt, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes, confirmedAt); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// DeleteEnrollment elimina la inscripción y los códigos de recuperación del usuario
+func (r *MFARepository) DeleteEnrollment(ctx context.Context, userID uint) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_enrollments WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MarkStepUsed registra el paso de un código aceptado. La condición en la consulta garantiza
+// que dos solicitudes concurrentes no puedan usar el mismo código
+func (r *MFARepository) MarkStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE mfa_enrollments SET last_used_step = ? WHERE user_id = ? AND last_used_step < ?`, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ReplaceRecoveryCodes reemplaza los códigos de recuperación del usuario en una sola transacción
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string, createdAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes, createdAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UseRecoveryCode marca como usado un código de recuperación vigente. La condición en la
+// consulta garantiza que cada código se use una sola vez
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = ?
+		WHERE id = (SELECT id FROM mfa_recovery_codes WHERE user_id = ? AND code_hash = ? AND used_at IS NULL LIMIT 1)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, usedAt, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// CountRecoveryCodes retorna la cantidad de códigos de recuperación sin usar del usuario
+func (r *MFARepository) CountRecoveryCodes(ctx context.Context, userID uint) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = ? AND used_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
+
+// replaceRecoveryCodes elimina los códigos de recuperación del usuario e inserta los nuevos
+// dentro de la transacción indicada
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID uint, codeHashes []string, createdAt time.Time) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO mfa_recovery_codes (user_id, code_hash, used_at, created_at) VALUES (?, ?, NULL, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, codeHash := range codeHashes {
+		if _, err := stmt.ExecContext(ctx, userID, codeHash, createdAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
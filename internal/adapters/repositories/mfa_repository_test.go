@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestMFARepository_EnrollmentLifecycle(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewMFARepository(userRepo.db)
+	ctx := context.Background()
+
+	if enrollment, err := repo.GetEnrollment(ctx, 1); err != nil || enrollment != nil {
+		t.Fatalf("Expected no enrollment, got %+v (%v)", enrollment, err)
+	}
+
+	now := time.Now()
+	if saved, err := repo.SaveEnrollment(ctx, &domain.MFAEnrollment{UserID: 1, Secret: "PRIMERO", CreatedAt: now}); err != nil || !saved {
+		t.Fatalf("Expected enrollment to be saved, got %t (%v)", saved, err)
+	}
+	// Una inscripción pendiente se puede reemplazar
+	if saved, err := repo.SaveEnrollment(ctx, &domain.MFAEnrollment{UserID: 1, Secret: "SEGUNDO", CreatedAt: now}); err != nil || !saved {
+		t.Fatalf("Expected pending enrollment to be replaced, got %t (%v)", saved, err)
+	}
+
+	confirmed, err := repo.ConfirmEnrollment(ctx, 1, 100, now, []string{"h1", "h2"})
+	if err != nil || !confirmed {
+		t.Fatalf("Expected enrollment to be confirmed, got %t (%v)", confirmed, err)
+	}
+	if confirmed, _ := repo.ConfirmEnrollment(ctx, 1, 101, now, nil); confirmed {
+		t.Error("Expected enrollment not to be confirmed twice")
+	}
+
+	enrollment, err := repo.GetEnrollment(ctx, 1)
+	if err != nil || enrollment == nil || enrollment.Secret != "SEGUNDO" || !enrollment.Confirmed() || enrollment.LastUsedStep != 100 {
+		t.Fatalf("Expected confirmed enrollment, got %+v (%v)", enrollment, err)
+	}
+	// Una inscripción confirmada no se reemplaza
+	if saved, err := repo.SaveEnrollment(ctx, &domain.MFAEnrollment{UserID: 1, Secret: "TERCERO", CreatedAt: now}); err != nil || saved {
+		t.Errorf("Expected confirmed enrollment not to be replaced, got %t (%v)", saved, err)
+	}
+
+	// Cada paso se acepta una sola vez y nunca uno anterior al último usado
+	if used, err := repo.MarkStepUsed(ctx, 1, 100); err != nil || used {
+		t.Errorf("Expected used step to be rejected, got %t (%v)", used, err)
+	}
+	if used, err := repo.MarkStepUsed(ctx, 1, 101); err != nil || !used {
+		t.Errorf("Expected new step to be accepted, got %t (%v)", used, err)
+	}
+
+	if err := repo.DeleteEnrollment(ctx, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if enrollment, _ := repo.GetEnrollment(ctx, 1); enrollment != nil {
+		t.Errorf("Expected enrollment to be deleted, got %+v", enrollment)
+	}
+	if count, _ := repo.CountRecoveryCodes(ctx, 1); count != 0 {
+		t.Errorf("Expected recovery codes to be deleted, got %d", count)
+	}
+}
+
+func TestMFARepository_RecoveryCodes(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewMFARepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	if err := repo.ReplaceRecoveryCodes(ctx, 1, []string{"h1", "h2", "h3"}, now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.ReplaceRecoveryCodes(ctx, 2, []string{"h1"}, now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if used, err := repo.UseRecoveryCode(ctx, 1, "h1", now); err != nil || !used {
+		t.Fatalf("Expected code to be used, got %t (%v)", used, err)
+	}
+	if used, _ := repo.UseRecoveryCode(ctx, 1, "h1", now); used {
+		t.Error("Expected code not to be used twice")
+	}
+	if used, _ := repo.UseRecoveryCode(ctx, 1, "desconocido", now); used {
+		t.Error("Expected unknown code not to be used")
+	}
+	if count, err := repo.CountRecoveryCodes(ctx, 1); err != nil || count != 2 {
+		t.Errorf("Expected 2 remaining codes, got %d (%v)", count, err)
+	}
+	// Los códigos de otro usuario no se ven afectados
+	if count, _ := repo.CountRecoveryCodes(ctx, 2); count != 1 {
+		t.Errorf("Expected 1 remaining code for the other user, got %d", count)
+	}
+
+	if err := repo.ReplaceRecoveryCodes(ctx, 1, []string{"h4"}, now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if used, _ := repo.UseRecoveryCode(ctx, 1, "h2", now); used {
+		t.Error("Expected replaced code not to be usable")
+	}
+	if count, _ := repo.CountRecoveryCodes(ctx, 1); count != 1 {
+		t.Errorf("Expected 1 code after replacing, got %d", count)
+	}
+}
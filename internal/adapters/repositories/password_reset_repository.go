@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"time"
+)
+
+// PasswordResetRepository implementa el almacenamiento de tokens de restablecimiento de
+// contraseña con SQLite
+type PasswordResetRepository struct {
+	db *sql.DB
+}
+
+// NewPasswordResetRepository crea una nueva instancia del repositorio de tokens de restablecimiento
+func NewPasswordResetRepository(db *sql.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// Create registra un token de restablecimiento
+func (r *PasswordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, used_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, token.UserID, token.TokenHash, token.ExpiresAt, token.UsedAt, token.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	token.ID = uint(id)
+	return nil
+}
+
+// GetByTokenHash obtiene un token por su hash. Retorna nil si no existe
+func (r *PasswordResetRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens WHERE token_hash = ?
+	`
+
+	token := &domain.PasswordResetToken{}
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &usedAt, &token.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return token, nil
+}
+
+// MarkUsed marca como usado un token que aún no se había usado. La condición en la consulta
+// garantiza que dos solicitudes concurrentes no puedan usar el mismo token
+func (r *PasswordResetRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`, usedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// InvalidateByUserID marca como usados los tokens pendientes de un usuario
+func (r *PasswordResetRepository) InvalidateByUserID(ctx context.Context, userID uint, usedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL`, usedAt, userID)
+	return err
+}
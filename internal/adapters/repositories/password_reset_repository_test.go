@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestPasswordResetRepository_SingleUse(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewPasswordResetRepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	token := &domain.PasswordResetToken{UserID: 1, TokenHash: "hash-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	if err := repo.Create(ctx, token); err != nil || token.ID == 0 {
+		t.Fatalf("Expected token to be created, got %+v (%v)", token, err)
+	}
+
+	found, err := repo.GetByTokenHash(ctx, "hash-1")
+	if err != nil || found == nil || found.UserID != 1 || !found.Usable(now) {
+		t.Fatalf("Expected usable token, got %+v (%v)", found, err)
+	}
+	if missing, err := repo.GetByTokenHash(ctx, "otro"); err != nil || missing != nil {
+		t.Errorf("Expected no token, got %+v (%v)", missing, err)
+	}
+
+	if used, err := repo.MarkUsed(ctx, token.ID, now); err != nil || !used {
+		t.Fatalf("Expected token to be marked as used, got %t (%v)", used, err)
+	}
+	if used, err := repo.MarkUsed(ctx, token.ID, now); err != nil || used {
+		t.Errorf("Expected token not to be used twice, got %t (%v)", used, err)
+	}
+
+	found, _ = repo.GetByTokenHash(ctx, "hash-1")
+	if found.UsedAt == nil || found.Usable(now) {
+		t.Errorf("Expected used token, got %+v", found)
+	}
+}
+
+func TestPasswordResetRepository_InvalidateByUserID(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewPasswordResetRepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	for _, token := range []*domain.PasswordResetToken{
+		{UserID: 1, TokenHash: "a", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		{UserID: 1, TokenHash: "b", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		{UserID: 2, TokenHash: "c", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+	} {
+		if err := repo.Create(ctx, token); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if err := repo.InvalidateByUserID(ctx, 1, now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for hash, usable := range map[string]bool{"a": false, "b": false, "c": true} {
+		token, _ := repo.GetByTokenHash(ctx, hash)
+		if token.Usable(now) != usable {
+			t.Errorf("Expected token %s usable=%t, got %+v", hash, usable, token)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"time"
+)
+
+// PLDCacheRepository implementa la caché persistente de resultados PLD con SQLite
+type PLDCacheRepository struct {
+	db *sql.DB
+}
+
+// NewPLDCacheRepository crea una nueva instancia del repositorio de caché PLD
+func NewPLDCacheRepository(db *sql.DB) *PLDCacheRepository {
+	return &PLDCacheRepository{db: db}
+}
+
+// Get obtiene una entrada por su clave
+func (r *PLDCacheRepository) Get(ctx context.Context, key string) (*domain.PLDCacheEntry, error) {
+	query := `SELECT key, response, list_version, expires_at, created_at FROM pld_cache WHERE key = ?`
+
+	entry := &domain.PLDCacheEntry{}
+	err := r.db.QueryRowContext(ctx, query, key).Scan(&entry.Key, &entry.Response, &entry.ListVersion, &entry.ExpiresAt, &entry.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Put guarda o reemplaza una entrada
+func (r *PLDCacheRepository) Put(ctx context.Context, entry *domain.PLDCacheEntry) error {
+	query := `
+		INSERT INTO pld_cache (key, response, list_version, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			response = excluded.response,
+			list_version = excluded.list_version,
+			expires_at = excluded.expires_at,
+			created_at = excluded.created_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, entry.Key, entry.Response, entry.ListVersion, entry.ExpiresAt, entry.CreatedAt)
+	return err
+}
+
+// Delete elimina una entrada
+func (r *PLDCacheRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM pld_cache WHERE key = ?`, key)
+	return err
+}
+
+// DeleteExpired elimina las entradas vencidas
+func (r *PLDCacheRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM pld_cache WHERE expires_at <= ?`, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
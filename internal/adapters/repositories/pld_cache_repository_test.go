@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestPLDCacheRepository_PutGetAndExpire(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewPLDCacheRepository(userRepo.db)
+	ctx := context.Background()
+
+	if entry, err := repo.Get(ctx, "missing"); err != nil || entry != nil {
+		t.Fatalf("Expected no entry, got %+v (%v)", entry, err)
+	}
+
+	now := time.Now()
+	entries := []*domain.PLDCacheEntry{
+		{Key: "clean", Response: `{"status":"clean"}`, ListVersion: "1", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		{Key: "expired", Response: `{"status":"clean"}`, ExpiresAt: now.Add(-time.Minute), CreatedAt: now.Add(-time.Hour)},
+	}
+	for _, entry := range entries {
+		if err := repo.Put(ctx, entry); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Put reemplaza la entrada existente
+	entries[0].Response = `{"status":"blacklisted"}`
+	entries[0].ListVersion = "1,2"
+	if err := repo.Put(ctx, entries[0]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	found, err := repo.Get(ctx, "clean")
+	if err != nil || found == nil || found.Response != `{"status":"blacklisted"}` || found.ListVersion != "1,2" {
+		t.Fatalf("Expected replaced entry, got %+v (%v)", found, err)
+	}
+	if !found.ExpiresAt.Equal(entries[0].ExpiresAt) {
+		t.Errorf("Expected expiration %s, got %s", entries[0].ExpiresAt, found.ExpiresAt)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, now)
+	if err != nil || deleted != 1 {
+		t.Fatalf("Expected 1 expired entry deleted, got %d (%v)", deleted, err)
+	}
+
+	if err := repo.Delete(ctx, "clean"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entry, _ := repo.Get(ctx, "clean"); entry != nil {
+		t.Errorf("Expected entry to be deleted, got %+v", entry)
+	}
+}
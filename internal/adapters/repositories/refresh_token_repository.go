@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"time"
+)
+
+// RefreshTokenRepository implementa el almacenamiento de refresh tokens con SQLite
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenRepository crea una nueva instancia del repositorio de refresh tokens
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create registra un refresh token
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, mfa, expires_at, rotated_at, revoked_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, token.UserID, token.FamilyID, token.TokenHash, token.MFA, token.ExpiresAt, token.RotatedAt, token.RevokedAt, token.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	token.ID = uint(id)
+	return nil
+}
+
+// GetByTokenHash obtiene un refresh token por su hash. Retorna nil si no existe
+func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, token_hash, mfa, expires_at, rotated_at, revoked_at, created_at
+		FROM refresh_tokens WHERE token_hash = ?
+	`
+
+	token := &domain.RefreshToken{}
+	var rotatedAt, revokedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.MFA, &token.ExpiresAt, &rotatedAt, &revokedAt, &token.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if rotatedAt.Valid {
+		token.RotatedAt = &rotatedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return token, nil
+}
+
+// MarkRotated marca como rotado un token que no se había rotado ni revocado. La condición
+// en la consulta garantiza que dos renovaciones concurrentes no puedan usar el mismo token
+func (r *RefreshTokenRepository) MarkRotated(ctx context.Context, id uint, rotatedAt time.Time) (bool, error) {
+	query := `UPDATE refresh_tokens SET rotated_at = ? WHERE id = ? AND rotated_at IS NULL AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, rotatedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// RevokeFamily revoca los tokens de una familia que aún no estaban revocados
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`, revokedAt, familyID)
+	return err
+}
+
+// RevokeByUserID revoca los tokens de un usuario que aún no estaban revocados
+func (r *RefreshTokenRepository) RevokeByUserID(ctx context.Context, userID uint, revokedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`, revokedAt, userID)
+	return err
+}
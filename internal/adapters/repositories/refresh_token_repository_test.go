@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenRepository_RotateAndRevokeFamily(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewRefreshTokenRepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	tokens := []*domain.RefreshToken{
+		{UserID: 1, FamilyID: "familia-a", TokenHash: "a1", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		{UserID: 1, FamilyID: "familia-a", TokenHash: "a2", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		{UserID: 1, FamilyID: "familia-b", TokenHash: "b1", MFA: true, ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+	}
+	for _, token := range tokens {
+		if err := repo.Create(ctx, token); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if rotated, err := repo.MarkRotated(ctx, tokens[0].ID, now); err != nil || !rotated {
+		t.Fatalf("Expected token to be rotated, got %t (%v)", rotated, err)
+	}
+	if rotated, err := repo.MarkRotated(ctx, tokens[0].ID, now); err != nil || rotated {
+		t.Errorf("Expected token not to be rotated twice, got %t (%v)", rotated, err)
+	}
+
+	if err := repo.RevokeFamily(ctx, "familia-a", now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rotated, _ := repo.MarkRotated(ctx, tokens[1].ID, now); rotated {
+		t.Error("Expected revoked token not to be rotated")
+	}
+
+	for hash, revoked := range map[string]bool{"a1": true, "a2": true, "b1": false} {
+		token, err := repo.GetByTokenHash(ctx, hash)
+		if err != nil || token == nil || (token.RevokedAt != nil) != revoked {
+			t.Errorf("Expected token %s revoked=%t, got %+v (%v)", hash, revoked, token, err)
+		}
+	}
+	if found, _ := repo.GetByTokenHash(ctx, "a1"); found.RotatedAt == nil || found.FamilyID != "familia-a" || found.MFA {
+		t.Errorf("Expected rotated token of familia-a, got %+v", found)
+	}
+	if found, _ := repo.GetByTokenHash(ctx, "b1"); !found.MFA {
+		t.Errorf("Expected MFA token of familia-b, got %+v", found)
+	}
+	if missing, err := repo.GetByTokenHash(ctx, "desconocido"); err != nil || missing != nil {
+		t.Errorf("Expected no token, got %+v (%v)", missing, err)
+	}
+
+	if err := repo.RevokeByUserID(ctx, 1, now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := repo.GetByTokenHash(ctx, "b1"); found.RevokedAt == nil {
+		t.Error("Expected every token of the user to be revoked")
+	}
+}
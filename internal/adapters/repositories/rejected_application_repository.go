@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+)
+
+// RejectedApplicationRepository implementa el repositorio de solicitudes rechazadas con SQLite
+type RejectedApplicationRepository struct {
+	db *sql.DB
+}
+
+// NewRejectedApplicationRepository crea una nueva instancia del repositorio de solicitudes rechazadas
+func NewRejectedApplicationRepository(db *sql.DB) *RejectedApplicationRepository {
+	return &RejectedApplicationRepository{db: db}
+}
+
+// Create registra una solicitud rechazada en la base de datos
+func (r *RejectedApplicationRepository) Create(ctx context.Context, application *domain.RejectedApplication) error {
+	query := `
+		INSERT INTO rejected_applications (name, email, id_number, reason, provider, screening_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		application.Name,
+		application.Email,
+		application.IDNumber,
+		application.Reason,
+		application.Provider,
+		application.ScreeningID,
+		application.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	application.ID = uint(id)
+	return nil
+}
+
+// List obtiene las solicitudes rechazadas dentro del rango de fechas, de la más reciente a la más antigua
+func (r *RejectedApplicationRepository) List(ctx context.Context, filter domain.RejectedApplicationFilter) ([]*domain.RejectedApplication, error) {
+	// Las fechas se almacenan en UTC para que la comparación por rango sea consistente
+	query := `
+		SELECT id, name, email, id_number, reason, provider, screening_id, created_at
+		FROM rejected_applications WHERE 1 = 1
+	`
+	var args []interface{}
+
+	if !filter.From.IsZero() {
+		query += " AND created_at >= ?"
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		query += " AND created_at <= ?"
+		args = append(args, filter.To.UTC())
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applications := []*domain.RejectedApplication{}
+	for rows.Next() {
+		application := &domain.RejectedApplication{}
+		var screeningID sql.NullInt64
+
+		err := rows.Scan(
+			&application.ID,
+			&application.Name,
+			&application.Email,
+			&application.IDNumber,
+			&application.Reason,
+			&application.Provider,
+			&screeningID,
+			&application.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if screeningID.Valid {
+			id := uint(screeningID.Int64)
+			application.ScreeningID = &id
+		}
+		applications = append(applications, application)
+	}
+
+	return applications, rows.Err()
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRejectedApplicationRepository_ListByDateRange(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewRejectedApplicationRepository(userRepo.db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		err := repo.Create(ctx, &domain.RejectedApplication{
+			Name:      "Juan Pérez",
+			Email:     "juan.perez@email.com",
+			IDNumber:  "12345678",
+			Reason:    "Usuario en lista negra",
+			Provider:  "pld-http",
+			CreatedAt: base.AddDate(0, 0, i),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	all, err := repo.List(ctx, domain.RejectedApplicationFilter{})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("Expected 3 applications, got %d (%v)", len(all), err)
+	}
+
+	// Rango expresado en otra zona horaria
+	mexico := time.FixedZone("CST", -6*60*60)
+	filtered, err := repo.List(ctx, domain.RejectedApplicationFilter{
+		From: base.AddDate(0, 0, 1).In(mexico),
+		To:   base.AddDate(0, 0, 1).In(mexico),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(filtered) != 1 || !filtered[0].CreatedAt.Equal(base.AddDate(0, 0, 1)) {
+		t.Errorf("Expected only the application from Jan 16, got %+v", filtered)
+	}
+}
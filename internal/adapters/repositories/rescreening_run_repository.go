@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+)
+
+// rescreeningRunColumns lista las columnas leídas en las consultas de ejecuciones
+const rescreeningRunColumns = `id, trigger, status, last_user_id, processed, flagged, failed, error, started_at, updated_at, finished_at`
+
+// RescreeningRunRepository implementa el repositorio de ejecuciones de re-screening con SQLite
+type RescreeningRunRepository struct {
+	db *sql.DB
+}
+
+// NewRescreeningRunRepository crea una nueva instancia del repositorio de ejecuciones de re-screening
+func NewRescreeningRunRepository(db *sql.DB) *RescreeningRunRepository {
+	return &RescreeningRunRepository{db: db}
+}
+
+// Create registra una nueva ejecución
+func (r *RescreeningRunRepository) Create(ctx context.Context, run *domain.RescreeningRun) error {
+	query := `
+		INSERT INTO rescreening_runs (trigger, status, last_user_id, processed, flagged, failed, error, started_at, updated_at, finished_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, run.Trigger, run.Status, run.LastUserID, run.Processed, run.Flagged, run.Failed, run.Error, run.StartedAt, run.UpdatedAt, run.FinishedAt)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	run.ID = uint(id)
+	return nil
+}
+
+// Update guarda el progreso de una ejecución
+func (r *RescreeningRunRepository) Update(ctx context.Context, run *domain.RescreeningRun) error {
+	query := `
+		UPDATE rescreening_runs
+		SET status = ?, last_user_id = ?, processed = ?, flagged = ?, failed = ?, error = ?, updated_at = ?, finished_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, run.Status, run.LastUserID, run.Processed, run.Flagged, run.Failed, run.Error, run.UpdatedAt, run.FinishedAt, run.ID)
+	return err
+}
+
+// GetLatest obtiene la ejecución más reciente
+func (r *RescreeningRunRepository) GetLatest(ctx context.Context) (*domain.RescreeningRun, error) {
+	query := `SELECT ` + rescreeningRunColumns + ` FROM rescreening_runs ORDER BY id DESC LIMIT 1`
+	return r.getOne(ctx, query)
+}
+
+// GetUnfinished obtiene la ejecución más reciente que quedó en curso (por ejemplo, por un reinicio)
+func (r *RescreeningRunRepository) GetUnfinished(ctx context.Context) (*domain.RescreeningRun, error) {
+	query := `SELECT ` + rescreeningRunColumns + ` FROM rescreening_runs WHERE status = ? ORDER BY id DESC LIMIT 1`
+	return r.getOne(ctx, query, domain.RescreeningStatusRunning)
+}
+
+// getOne ejecuta una consulta que retorna a lo sumo una ejecución
+func (r *RescreeningRunRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.RescreeningRun, error) {
+	run := &domain.RescreeningRun{}
+	var finishedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&run.ID,
+		&run.Trigger,
+		&run.Status,
+		&run.LastUserID,
+		&run.Processed,
+		&run.Flagged,
+		&run.Failed,
+		&run.Error,
+		&run.StartedAt,
+		&run.UpdatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+
+	return run, nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRescreeningRunRepository_Lifecycle(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewRescreeningRunRepository(userRepo.db)
+	ctx := context.Background()
+
+	if run, err := repo.GetLatest(ctx); err != nil || run != nil {
+		t.Fatalf("Expected no runs, got %+v (%v)", run, err)
+	}
+
+	now := time.Now()
+	run := &domain.RescreeningRun{
+		Trigger:   domain.RescreeningTriggerManual,
+		Status:    domain.RescreeningStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.Create(ctx, run); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	run.LastUserID = 42
+	run.Processed = 42
+	run.Flagged = 1
+	if err := repo.Update(ctx, run); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	unfinished, err := repo.GetUnfinished(ctx)
+	if err != nil || unfinished == nil || unfinished.LastUserID != 42 || unfinished.Flagged != 1 {
+		t.Fatalf("Expected unfinished run at user 42, got %+v (%v)", unfinished, err)
+	}
+
+	finishedAt := time.Now()
+	run.Status = domain.RescreeningStatusCompleted
+	run.FinishedAt = &finishedAt
+	if err := repo.Update(ctx, run); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if unfinished, err := repo.GetUnfinished(ctx); err != nil || unfinished != nil {
+		t.Errorf("Expected no unfinished runs, got %+v (%v)", unfinished, err)
+	}
+	latest, err := repo.GetLatest(ctx)
+	if err != nil || latest == nil || latest.Status != domain.RescreeningStatusCompleted || latest.FinishedAt == nil {
+		t.Errorf("Expected completed latest run, got %+v (%v)", latest, err)
+	}
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+)
+
+// ReviewDecisionRepository implementa el registro de decisiones de revisión con SQLite
+type ReviewDecisionRepository struct {
+	db *sql.DB
+}
+
+// NewReviewDecisionRepository crea una nueva instancia del repositorio de decisiones de revisión
+func NewReviewDecisionRepository(db *sql.DB) *ReviewDecisionRepository {
+	return &ReviewDecisionRepository{db: db}
+}
+
+// Create registra una decisión de revisión
+func (r *ReviewDecisionRepository) Create(ctx context.Context, decision *domain.ReviewDecision) error {
+	query := `
+		INSERT INTO review_decisions (user_id, reviewer_id, decision, justification, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, decision.UserID, decision.ReviewerID, decision.Decision, decision.Justification, decision.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	decision.ID = uint(id)
+	return nil
+}
+
+// RecordDecision registra una decisión de revisión y cambia el estado del usuario en una
+// transacción. La condición sobre el estado garantiza que dos revisores no decidan la misma
+// alta: el segundo no encuentra al usuario pendiente y no registra nada
+func (r *ReviewDecisionRepository) RecordDecision(ctx context.Context, decision *domain.ReviewDecision, status string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users SET status = ?, updated_at = ?
+		WHERE id = ? AND status = ?
+	`, status, decision.CreatedAt, decision.UserID, domain.UserStatusPendingReview)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO review_decisions (user_id, reviewer_id, decision, justification, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	result, err = tx.ExecContext(ctx, query, decision.UserID, decision.ReviewerID, decision.Decision, decision.Justification, decision.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	decision.ID = uint(id)
+	return true, nil
+}
+
+// ListByUserID obtiene las decisiones tomadas sobre un usuario, de la más reciente a la más antigua
+func (r *ReviewDecisionRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.ReviewDecision, error) {
+	query := `
+		SELECT id, user_id, reviewer_id, decision, justification, created_at
+		FROM review_decisions WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := []*domain.ReviewDecision{}
+	for rows.Next() {
+		decision := &domain.ReviewDecision{}
+		if err := rows.Scan(&decision.ID, &decision.UserID, &decision.ReviewerID, &decision.Decision, &decision.Justification, &decision.CreatedAt); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, rows.Err()
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestReviewDecisionRepository_CreateAndList(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewReviewDecisionRepository(userRepo.db)
+	ctx := context.Background()
+
+	base := time.Now()
+	decisions := []*domain.ReviewDecision{
+		{UserID: 1, ReviewerID: 9, Decision: domain.ReviewDecisionRejected, Justification: "Coincide fecha de nacimiento", CreatedAt: base},
+		{UserID: 2, ReviewerID: 9, Decision: domain.ReviewDecisionApproved, Justification: "Homonimia", CreatedAt: base.Add(time.Minute)},
+		{UserID: 1, ReviewerID: 8, Decision: domain.ReviewDecisionApproved, Justification: "Apelación aceptada", CreatedAt: base.Add(2 * time.Minute)},
+	}
+	for _, decision := range decisions {
+		if err := repo.Create(ctx, decision); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	found, err := repo.ListByUserID(ctx, 1)
+	if err != nil || len(found) != 2 {
+		t.Fatalf("Expected 2 decisions, got %+v (%v)", found, err)
+	}
+	if found[0].ID != decisions[2].ID || found[0].ReviewerID != 8 || found[0].Justification != "Apelación aceptada" {
+		t.Errorf("Expected most recent decision first, got %+v", found[0])
+	}
+
+	if none, err := repo.ListByUserID(ctx, 3); err != nil || len(none) != 0 {
+		t.Errorf("Expected no decisions, got %+v (%v)", none, err)
+	}
+}
+
+func TestReviewDecisionRepository_RecordDecision(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewReviewDecisionRepository(userRepo.db)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "María López", Email: "maria.lopez@email.com", Password: "hash", IDNumber: "87654321", Status: domain.UserStatusPendingReview, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	approval := &domain.ReviewDecision{UserID: user.ID, ReviewerID: 9, Decision: domain.ReviewDecisionApproved, Justification: "Homonimia", CreatedAt: time.Now()}
+	if recorded, err := repo.RecordDecision(ctx, approval, domain.UserStatusActive); err != nil || !recorded || approval.ID == 0 {
+		t.Fatalf("Expected decision to be recorded, got %t (%v)", recorded, err)
+	}
+
+	// Una segunda decisión sobre la misma alta no cambia el estado ni queda registrada
+	rejection := &domain.ReviewDecision{UserID: user.ID, ReviewerID: 8, Decision: domain.ReviewDecisionRejected, Justification: "Coincide fecha de nacimiento", CreatedAt: time.Now()}
+	if recorded, err := repo.RecordDecision(ctx, rejection, domain.UserStatusRejected); err != nil || recorded {
+		t.Fatalf("Expected second decision not to be recorded, got %t (%v)", recorded, err)
+	}
+
+	found, _ := userRepo.GetByID(ctx, user.ID)
+	if found.Status != domain.UserStatusActive {
+		t.Errorf("Expected active user, got %q", found.Status)
+	}
+	if decisions, err := repo.ListByUserID(ctx, user.ID); err != nil || len(decisions) != 1 || decisions[0].Decision != domain.ReviewDecisionApproved {
+		t.Errorf("Expected only the approval, got %+v (%v)", decisions, err)
+	}
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+)
+
+// ScreeningRepository implementa el repositorio de screenings PLD con SQLite
+type ScreeningRepository struct {
+	db *sql.DB
+}
+
+// NewScreeningRepository crea una nueva instancia del repositorio de screenings
+func NewScreeningRepository(db *sql.DB) *ScreeningRepository {
+	return &ScreeningRepository{db: db}
+}
+
+// Create registra un screening en la base de datos
+func (r *ScreeningRepository) Create(ctx context.Context, screening *domain.Screening) error {
+	query := `
+		INSERT INTO screenings (user_id, id_number, email, request_payload, raw_response, status, provider, match_score, latency_ms, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		screening.UserID,
+		screening.IDNumber,
+		screening.Email,
+		screening.RequestPayload,
+		screening.RawResponse,
+		screening.Status,
+		screening.Provider,
+		screening.MatchScore,
+		screening.LatencyMs,
+		screening.Error,
+		screening.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el ID generado
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	screening.ID = uint(id)
+	return nil
+}
+
+// LinkUser asocia un screening con el usuario creado a partir de él
+func (r *ScreeningRepository) LinkUser(ctx context.Context, screeningID, userID uint) error {
+	query := `UPDATE screenings SET user_id = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, userID, screeningID)
+	return err
+}
+
+// ListByUserID obtiene el historial de screenings de un usuario, del más reciente al más antiguo
+func (r *ScreeningRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.Screening, error) {
+	query := `
+		SELECT id, user_id, id_number, email, request_payload, raw_response, status, provider, match_score, latency_ms, error, created_at
+		FROM screenings WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	screenings := []*domain.Screening{}
+	for rows.Next() {
+		screening, err := scanScreening(rows)
+		if err != nil {
+			return nil, err
+		}
+		screenings = append(screenings, screening)
+	}
+
+	return screenings, rows.Err()
+}
+
+// scanScreening convierte una fila en un screening
+func scanScreening(rows *sql.Rows) (*domain.Screening, error) {
+	screening := &domain.Screening{}
+	var userID sql.NullInt64
+	var rawResponse, errMsg sql.NullString
+
+	err := rows.Scan(
+		&screening.ID,
+		&userID,
+		&screening.IDNumber,
+		&screening.Email,
+		&screening.RequestPayload,
+		&rawResponse,
+		&screening.Status,
+		&screening.Provider,
+		&screening.MatchScore,
+		&screening.LatencyMs,
+		&errMsg,
+		&screening.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		id := uint(userID.Int64)
+		screening.UserID = &id
+	}
+	screening.RawResponse = rawResponse.String
+	screening.Error = errMsg.String
+
+	return screening, nil
+}
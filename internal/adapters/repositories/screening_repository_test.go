@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestScreeningRepository_CreateLinkAndList(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewScreeningRepository(userRepo.db)
+	ctx := context.Background()
+
+	first := &domain.Screening{
+		IDNumber:       "12345678",
+		Email:          "juan.perez@email.com",
+		RequestPayload: `{"first_name":"Juan"}`,
+		RawResponse:    `{"is_in_blacklist":false}`,
+		Status:         domain.ScreeningStatusClean,
+		Provider:       "pld-http",
+		MatchScore:     0.93,
+		LatencyMs:      42,
+		CreatedAt:      time.Now().Add(-time.Hour),
+	}
+	second := &domain.Screening{
+		IDNumber:       "12345678",
+		Email:          "juan.perez@email.com",
+		RequestPayload: `{"first_name":"Juan"}`,
+		Status:         domain.ScreeningStatusError,
+		Provider:       "pld-http",
+		Error:          "servicio PLD retornó código 503",
+		CreatedAt:      time.Now(),
+	}
+	for _, s := range []*domain.Screening{first, second} {
+		if err := repo.Create(ctx, s); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := repo.LinkUser(ctx, s.ID, 7); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	screenings, err := repo.ListByUserID(ctx, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(screenings) != 2 {
+		t.Fatalf("Expected 2 screenings, got %d", len(screenings))
+	}
+	if screenings[0].ID != second.ID || screenings[0].Error == "" {
+		t.Errorf("Expected most recent screening first, got %+v", screenings[0])
+	}
+	if screenings[1].RawResponse != first.RawResponse || screenings[1].LatencyMs != 42 || screenings[1].MatchScore != 0.93 {
+		t.Errorf("Expected raw response, latency and match score to round-trip, got %+v", screenings[1])
+	}
+	if screenings[1].UserID == nil || *screenings[1].UserID != 7 {
+		t.Errorf("Expected screening linked to user 7, got %v", screenings[1].UserID)
+	}
+
+	empty, err := repo.ListByUserID(ctx, 99)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected no screenings for unknown user, got %v (%v)", empty, err)
+	}
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"time"
+)
+
+// TokenRevocationRepository implementa el almacenamiento de revocaciones de tokens con SQLite
+type TokenRevocationRepository struct {
+	db *sql.DB
+}
+
+// NewTokenRevocationRepository crea una nueva instancia del repositorio de revocaciones
+func NewTokenRevocationRepository(db *sql.DB) *TokenRevocationRepository {
+	return &TokenRevocationRepository{db: db}
+}
+
+// RevokeToken registra la revocación de un token. Revocar dos veces el mismo token no es un error
+func (r *TokenRevocationRepository) RevokeToken(ctx context.Context, revocation *domain.TokenRevocation) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(jti) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, revocation.JTI, revocation.UserID, revocation.ExpiresAt, revocation.RevokedAt)
+	return err
+}
+
+// IsTokenRevoked indica si el token con el jti indicado fue revocado
+func (r *TokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var exists int
+	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM revoked_tokens WHERE jti = ?`, jti).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ListRevokedTokenIDs retorna los jti de las revocaciones vigentes
+func (r *TokenRevocationRepository) ListRevokedTokenIDs(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT jti FROM revoked_tokens WHERE expires_at > ?`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var jti string
+		if err := rows.Scan(&jti); err != nil {
+			return nil, err
+		}
+		ids = append(ids, jti)
+	}
+	return ids, rows.Err()
+}
+
+// RevokeUserTokens registra la revocación de todos los tokens de un usuario, reemplazando la anterior
+func (r *TokenRevocationRepository) RevokeUserTokens(ctx context.Context, revocation *domain.UserTokenRevocation) error {
+	query := `
+		INSERT INTO user_token_revocations (user_id, revoked_before, expires_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET revoked_before = excluded.revoked_before, expires_at = excluded.expires_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, revocation.UserID, revocation.RevokedBefore, revocation.ExpiresAt)
+	return err
+}
+
+// GetUserTokenRevocation obtiene la revocación de tokens de un usuario. Retorna nil si no existe
+func (r *TokenRevocationRepository) GetUserTokenRevocation(ctx context.Context, userID uint) (*domain.UserTokenRevocation, error) {
+	query := `SELECT user_id, revoked_before, expires_at FROM user_token_revocations WHERE user_id = ?`
+
+	revocation := &domain.UserTokenRevocation{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&revocation.UserID, &revocation.RevokedBefore, &revocation.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return revocation, nil
+}
+
+// DeleteExpired elimina las revocaciones de tokens y de usuarios que ya vencieron
+func (r *TokenRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	for _, query := range []string{
+		`DELETE FROM revoked_tokens WHERE expires_at <= ?`,
+		`DELETE FROM user_token_revocations WHERE expires_at <= ?`,
+	} {
+		result, err := r.db.ExecContext(ctx, query, now)
+		if err != nil {
+			return deleted, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += affected
+	}
+	return deleted, nil
+}
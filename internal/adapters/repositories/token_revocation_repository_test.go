@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestTokenRevocationRepository_RevokeAndPrune(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewTokenRevocationRepository(userRepo.db)
+	ctx := context.Background()
+
+	now := time.Now()
+	revocations := []*domain.TokenRevocation{
+		{JTI: "vigente", UserID: 1, ExpiresAt: now.Add(time.Hour), RevokedAt: now},
+		{JTI: "vencido", UserID: 1, ExpiresAt: now.Add(-time.Minute), RevokedAt: now.Add(-time.Hour)},
+	}
+	for _, revocation := range revocations {
+		if err := repo.RevokeToken(ctx, revocation); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	// Revocar dos veces el mismo token no falla
+	if err := repo.RevokeToken(ctx, revocations[0]); err != nil {
+		t.Fatalf("Expected no error revoking twice, got %v", err)
+	}
+
+	if revoked, err := repo.IsTokenRevoked(ctx, "vigente"); err != nil || !revoked {
+		t.Errorf("Expected token to be revoked, got %t (%v)", revoked, err)
+	}
+	if revoked, err := repo.IsTokenRevoked(ctx, "desconocido"); err != nil || revoked {
+		t.Errorf("Expected unknown token not to be revoked, got %t (%v)", revoked, err)
+	}
+	if ids, err := repo.ListRevokedTokenIDs(ctx, now); err != nil || len(ids) != 1 || ids[0] != "vigente" {
+		t.Errorf("Expected only the active revocation, got %v (%v)", ids, err)
+	}
+
+	if err := repo.RevokeUserTokens(ctx, &domain.UserTokenRevocation{UserID: 1, RevokedBefore: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.RevokeUserTokens(ctx, &domain.UserTokenRevocation{UserID: 2, RevokedBefore: now, ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	revocation, err := repo.GetUserTokenRevocation(ctx, 2)
+	if err != nil || revocation == nil || !revocation.RevokedBefore.Equal(now) {
+		t.Fatalf("Expected the user revocation, got %+v (%v)", revocation, err)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, now)
+	if err != nil || deleted != 2 {
+		t.Fatalf("Expected 2 expired revocations deleted, got %d (%v)", deleted, err)
+	}
+	if revoked, _ := repo.IsTokenRevoked(ctx, "vencido"); revoked {
+		t.Error("Expected the expired revocation to be pruned")
+	}
+	if revocation, _ := repo.GetUserTokenRevocation(ctx, 1); revocation != nil {
+		t.Error("Expected the expired user revocation to be pruned")
+	}
+	if revocation, _ := repo.GetUserTokenRevocation(ctx, 2); revocation == nil {
+		t.Error("Expected the active user revocation to be kept")
+	}
+}
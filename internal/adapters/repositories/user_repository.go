@@ -1,10 +1,24 @@
 package repositories
 
 import (
+	"context"
 	"crabi-test/internal/domain"
 	"database/sql"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
+// userColumns lista las columnas leídas en las consultas de usuarios, en el orden de scanUser
+const userColumns = `id, name, email, password, id_number, role, status, created_at, updated_at, flagged_at, flag_reason, review_reason, given_names, paternal_surname, maternal_surname, date_of_birth, nationality`
+
+// rowScanner abstrae *sql.Row y *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // UserRepository implementa el repositorio de usuarios con SQLite
 type UserRepository struct {
 	db *sql.DB
@@ -16,15 +30,15 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 }
 
 // Create crea un nuevo usuario en la base de datos
-func (r *UserRepository) Create(user *domain.User) error {
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (name, email, password, id_number, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO users (name, email, password, id_number, role, status, created_at, updated_at, flagged_at, flag_reason, review_reason, given_names, paternal_surname, maternal_surname, date_of_birth, nationality)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.Exec(query, user.Name, user.Email, user.Password, user.IDNumber, user.CreatedAt, user.UpdatedAt)
+	result, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.Password, user.IDNumber, user.Role, userStatus(user), user.CreatedAt, user.UpdatedAt, user.FlaggedAt, user.FlagReason, user.ReviewReason, user.GivenNames, user.PaternalSurname, user.MaternalSurname, user.DateOfBirth, user.Nationality)
 	if err != nil {
-		return err
+		return userWriteError(err)
 	}
 
 	// Obtener el ID generado
@@ -38,23 +52,10 @@ func (r *UserRepository) Create(user *domain.User) error {
 }
 
 // GetByID obtiene un usuario por su ID
-func (r *UserRepository) GetByID(id uint) (*domain.User, error) {
-	query := `
-		SELECT id, name, email, password, id_number, created_at, updated_at
-		FROM users WHERE id = ?
-	`
-
-	user := &domain.User{}
-	err := r.db.QueryRow(query, id).Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-		&user.IDNumber,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+func (r *UserRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
 
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -66,23 +67,10 @@ func (r *UserRepository) GetByID(id uint) (*domain.User, error) {
 }
 
 // GetByEmail obtiene un usuario por su email
-func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
-	query := `
-		SELECT id, name, email, password, id_number, created_at, updated_at
-		FROM users WHERE email = ?
-	`
-
-	user := &domain.User{}
-	err := r.db.QueryRow(query, email).Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-		&user.IDNumber,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = ?`
 
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -93,21 +81,230 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 	return user, nil
 }
 
-// Update actualiza un usuario existente
-func (r *UserRepository) Update(user *domain.User) error {
+// ListAfterID obtiene hasta limit usuarios con ID mayor a afterID, ordenados por ID
+func (r *UserRepository) ListAfterID(ctx context.Context, afterID uint, limit int) ([]*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id > ? ORDER BY id LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// ListByStatus obtiene los usuarios con el estado indicado, del más antiguo al más reciente
+func (r *UserRepository) ListByStatus(ctx context.Context, status string) ([]*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE status = ? ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// Update actualiza un usuario existente. La contraseña no se modifica (ver UpdatePassword)
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users 
-		SET name = ?, email = ?, password = ?, id_number = ?, updated_at = ?
+		SET name = ?, email = ?, id_number = ?, role = ?, status = ?, updated_at = ?, flagged_at = ?, flag_reason = ?, review_reason = ?, given_names = ?, paternal_surname = ?, maternal_surname = ?, date_of_birth = ?, nationality = ?
 		WHERE id = ?
 	`
 
-	_, err := r.db.Exec(query, user.Name, user.Email, user.Password, user.IDNumber, user.UpdatedAt, user.ID)
-	return err
+	_, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.IDNumber, user.Role, userStatus(user), user.UpdatedAt, user.FlaggedAt, user.FlagReason, user.ReviewReason, user.GivenNames, user.PaternalSurname, user.MaternalSurname, user.DateOfBirth, user.Nationality, user.ID)
+	return userWriteError(err)
+}
+
+// UpdateProfile guarda solo los datos de perfil e identidad de un usuario, sin tocar su rol,
+// estado ni marca de PLD. Con markForReview, en la misma transacción deja pendiente de revisión
+// al usuario con user.ReviewReason solo si sigue activo, para no deshacer una decisión tomada
+// mientras tanto. Retorna domain.ErrNotFound si el usuario no existe
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *domain.User, markForReview bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET name = ?, email = ?, id_number = ?, given_names = ?, paternal_surname = ?, maternal_surname = ?, date_of_birth = ?, nationality = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := tx.ExecContext(ctx, query, user.Name, user.Email, user.IDNumber, user.GivenNames, user.PaternalSurname, user.MaternalSurname, user.DateOfBirth, user.Nationality, user.UpdatedAt, user.ID)
+	if err != nil {
+		return userWriteError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+
+	if markForReview {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE users SET status = ?, review_reason = ?
+			WHERE id = ? AND status = ?
+		`, domain.UserStatusPendingReview, user.ReviewReason, user.ID, domain.UserStatusActive)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePassword guarda el hash de la nueva contraseña de un usuario. Retorna
+// domain.ErrNotFound si el usuario no existe
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string, updatedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET password = ?, updated_at = ? WHERE id = ?`, passwordHash, updatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+	return nil
+}
+
+// Flag establece la marca de PLD de un usuario que aún no la tiene. Solo escribe las columnas
+// de la marca para no pisar cambios hechos mientras corre el re-screening
+func (r *UserRepository) Flag(ctx context.Context, id uint, reason string, flaggedAt time.Time) (bool, error) {
+	query := `
+		UPDATE users SET flagged_at = ?, flag_reason = ?, updated_at = ?
+		WHERE id = ? AND flagged_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, flaggedAt, reason, flaggedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
 }
 
-// Delete elimina un usuario por su ID
-func (r *UserRepository) Delete(id uint) error {
+// SetRole cambia el rol de un usuario. Solo un usuario activo y sin marca de PLD puede
+// recibir el rol de administrador; la condición se verifica en la misma sentencia
+func (r *UserRepository) SetRole(ctx context.Context, id uint, role string, updatedAt time.Time) (bool, error) {
+	query := `UPDATE users SET role = ?, updated_at = ? WHERE id = ?`
+	args := []any{role, updatedAt, id}
+	if role == domain.RoleAdmin {
+		query += ` AND status = ? AND flagged_at IS NULL`
+		args = append(args, domain.UserStatusActive)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// Delete elimina un usuario por su ID. Retorna domain.ErrNotFound si el usuario no existe
+func (r *UserRepository) Delete(ctx context.Context, id uint) error {
 	query := `DELETE FROM users WHERE id = ?`
-	_, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+	return nil
+}
+
+// userWriteError traduce la violación del índice único de email a domain.ErrEmailTaken
+func userWriteError(err error) error {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return domain.ErrEmailTaken
+	}
 	return err
 }
+
+// scanUser convierte una fila en un usuario
+func scanUser(row rowScanner) (*domain.User, error) {
+	user := &domain.User{}
+	var flaggedAt sql.NullTime
+
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.IDNumber,
+		&user.Role,
+		&user.Status,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&flaggedAt,
+		&user.FlagReason,
+		&user.ReviewReason,
+		&user.GivenNames,
+		&user.PaternalSurname,
+		&user.MaternalSurname,
+		&user.DateOfBirth,
+		&user.Nationality,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if flaggedAt.Valid {
+		user.FlaggedAt = &flaggedAt.Time
+	}
+
+	return user, nil
+}
+
+// userStatus retorna el estado a persistir; un usuario sin estado se considera activo
+func userStatus(user *domain.User) string {
+	if user.Status == "" {
+		return domain.UserStatusActive
+	}
+	return user.Status
+}
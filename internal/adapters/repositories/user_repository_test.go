@@ -0,0 +1,374 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/database/sqlite"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestUserRepository(t *testing.T) *UserRepository {
+	t.Setenv("DB_PATH", filepath.Join(t.TempDir(), "test.db"))
+
+	db, err := sqlite.InitDB()
+	if err != nil {
+		t.Fatalf("Error inicializando base de datos: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewUserRepository(db)
+}
+
+func TestUserRepository_CreateAndGet(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	user := &domain.User{
+		Name:      "Juan Pérez",
+		Email:     "juan.perez@email.com",
+		Password:  "hash",
+		IDNumber:  "12345678",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found, err := repo.GetByEmail(ctx, "juan.perez@email.com")
+	if err != nil || found == nil || found.ID != user.ID {
+		t.Fatalf("Expected user %d, got %+v (%v)", user.ID, found, err)
+	}
+}
+
+func TestUserRepository_CancelledContext(t *testing.T) {
+	repo := newTestUserRepository(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.GetByID(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from GetByID, got %v", err)
+	}
+
+	user := &domain.User{Name: "Juan", Email: "juan@email.com", Password: "hash", IDNumber: "12345678"}
+	if err := repo.Create(ctx, user); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from Create, got %v", err)
+	}
+}
+
+func TestUserRepository_PersistsRole(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	user := &domain.User{
+		Name:      "Oficial",
+		Email:     "compliance@email.com",
+		Password:  "hash",
+		IDNumber:  "12345678",
+		Role:      domain.RoleAdmin,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found, err := repo.GetByID(ctx, user.ID)
+	if err != nil || found == nil || found.Role != domain.RoleAdmin {
+		t.Fatalf("Expected admin role, got %+v (%v)", found, err)
+	}
+}
+
+func TestUserRepository_PersistsIdentity(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	user := &domain.User{
+		Name:            "María de la Luz García López",
+		Email:           "maria@email.com",
+		Password:        "hash",
+		IDNumber:        "GALM850412MDFRPR05",
+		GivenNames:      "María de la Luz",
+		PaternalSurname: "García",
+		MaternalSurname: "López",
+		DateOfBirth:     "1985-04-12",
+		Nationality:     "MX",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found, err := repo.GetByID(ctx, user.ID)
+	if err != nil || found == nil {
+		t.Fatalf("Expected user, got %+v (%v)", found, err)
+	}
+	if found.PLDRequest() != user.PLDRequest() {
+		t.Errorf("Expected identity %+v, got %+v", user.PLDRequest(), found.PLDRequest())
+	}
+
+	found.MaternalSurname = ""
+	if err := repo.Update(ctx, found); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	updated, _ := repo.GetByID(ctx, user.ID)
+	if updated.MaternalSurname != "" || updated.PaternalSurname != "García" {
+		t.Errorf("Expected updated surnames, got %+v", updated)
+	}
+}
+
+func TestUserRepository_ListAfterIDAndFlag(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	for _, email := range []string{"a@email.com", "b@email.com", "c@email.com"} {
+		user := &domain.User{Name: "Usuario", Email: email, Password: "hash", IDNumber: "12345678", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	batch, err := repo.ListAfterID(ctx, 1, 10)
+	if err != nil || len(batch) != 2 || batch[0].ID != 2 || batch[1].ID != 3 {
+		t.Fatalf("Expected users 2 and 3, got %+v (%v)", batch, err)
+	}
+
+	flaggedAt := time.Now()
+	batch[0].FlaggedAt = &flaggedAt
+	batch[0].FlagReason = "Sanción reciente"
+	if err := repo.Update(ctx, batch[0]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found, err := repo.GetByID(ctx, 2)
+	if err != nil || found.FlaggedAt == nil || found.FlagReason != "Sanción reciente" {
+		t.Errorf("Expected flagged user, got %+v (%v)", found, err)
+	}
+
+	batch, err = repo.ListAfterID(ctx, 0, 1)
+	if err != nil || len(batch) != 1 || batch[0].ID != 1 || batch[0].FlaggedAt != nil {
+		t.Errorf("Expected unflagged user 1 only, got %+v (%v)", batch, err)
+	}
+}
+
+func TestUserRepository_Flag(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", Password: "hash", IDNumber: "12345678", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Un cambio posterior a la lectura del re-screening se conserva al marcar
+	user.Name = "Juan Pérez García"
+	user.Status = domain.UserStatusPendingReview
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if flagged, err := repo.Flag(ctx, user.ID, "Sanción reciente", time.Now()); err != nil || !flagged {
+		t.Fatalf("Expected user to be flagged, got %t (%v)", flagged, err)
+	}
+	if flagged, _ := repo.Flag(ctx, user.ID, "Otra sanción", time.Now()); flagged {
+		t.Error("Expected an already flagged user not to be flagged again")
+	}
+	if flagged, _ := repo.Flag(ctx, 99, "Sanción reciente", time.Now()); flagged {
+		t.Error("Expected an unknown user not to be flagged")
+	}
+
+	found, err := repo.GetByID(ctx, user.ID)
+	if err != nil || found.FlaggedAt == nil || found.FlagReason != "Sanción reciente" {
+		t.Fatalf("Expected flagged user, got %+v (%v)", found, err)
+	}
+	if found.Name != "Juan Pérez García" || found.Status != domain.UserStatusPendingReview {
+		t.Errorf("Expected Flag to keep the other fields, got %+v", found)
+	}
+}
+
+func TestUserRepository_UpdateProfile(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", Password: "hash", IDNumber: "12345678", Role: domain.RoleUser, Status: domain.UserStatusActive, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	other := &domain.User{Name: "Ana López", Email: "ana.lopez@email.com", Password: "hash", IDNumber: "87654321", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	for _, u := range []*domain.User{user, other} {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// La marca hecha después de leer el usuario se conserva al guardar el perfil
+	stale := *user
+	repo.Flag(ctx, user.ID, "Sanción reciente", time.Now())
+	stale.Name = "Juan Pérez García"
+	stale.Role = domain.RoleAdmin
+	if err := repo.UpdateProfile(ctx, &stale, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	found, _ := repo.GetByID(ctx, user.ID)
+	if found.Name != "Juan Pérez García" || found.FlaggedAt == nil || found.Role != domain.RoleUser {
+		t.Errorf("Expected only the profile to change, got %+v", found)
+	}
+
+	// Solo un usuario activo pasa a revisión
+	stale.ReviewReason = "Posible coincidencia"
+	if err := repo.UpdateProfile(ctx, &stale, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := repo.GetByID(ctx, user.ID); found.Status != domain.UserStatusPendingReview || found.ReviewReason != "Posible coincidencia" {
+		t.Errorf("Expected the user to be pending review, got %+v", found)
+	}
+	repo.db.ExecContext(ctx, `UPDATE users SET status = ? WHERE id = ?`, domain.UserStatusRejected, user.ID)
+	if err := repo.UpdateProfile(ctx, &stale, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := repo.GetByID(ctx, user.ID); found.Status != domain.UserStatusRejected {
+		t.Errorf("Expected the rejection to be kept, got %q", found.Status)
+	}
+
+	stale.Email = other.Email
+	if err := repo.UpdateProfile(ctx, &stale, false); !errors.Is(err, domain.ErrEmailTaken) {
+		t.Errorf("Expected ErrEmailTaken, got %v", err)
+	}
+	stale.ID = 99
+	stale.Email = "nuevo@email.com"
+	if err := repo.UpdateProfile(ctx, &stale, false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUserRepository_SetRole(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	active := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", Password: "hash", IDNumber: "12345678", Status: domain.UserStatusActive, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	pending := &domain.User{Name: "Ana López", Email: "ana.lopez@email.com", Password: "hash", IDNumber: "87654321", Status: domain.UserStatusPendingReview, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	for _, user := range []*domain.User{active, pending} {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if updated, err := repo.SetRole(ctx, active.ID, domain.RoleAdmin, time.Now()); err != nil || !updated {
+		t.Fatalf("Expected active user to be promoted, got %t (%v)", updated, err)
+	}
+	if found, _ := repo.GetByID(ctx, active.ID); found.Role != domain.RoleAdmin {
+		t.Errorf("Expected admin role, got %q", found.Role)
+	}
+
+	// Un usuario pendiente de revisión o marcado no puede ser administrador
+	if updated, _ := repo.SetRole(ctx, pending.ID, domain.RoleAdmin, time.Now()); updated {
+		t.Error("Expected a pending user not to be promoted")
+	}
+	repo.Flag(ctx, active.ID, "Sanción reciente", time.Now())
+	if updated, err := repo.SetRole(ctx, active.ID, domain.RoleUser, time.Now()); err != nil || !updated {
+		t.Fatalf("Expected flagged admin to be demoted, got %t (%v)", updated, err)
+	}
+	if updated, _ := repo.SetRole(ctx, active.ID, domain.RoleAdmin, time.Now()); updated {
+		t.Error("Expected a flagged user not to be promoted")
+	}
+	if updated, _ := repo.SetRole(ctx, 99, domain.RoleUser, time.Now()); updated {
+		t.Error("Expected an unknown user not to be updated")
+	}
+}
+
+func TestUserRepository_ListByStatus(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	statuses := []string{"", domain.UserStatusPendingReview, domain.UserStatusActive, domain.UserStatusPendingReview}
+	for i, status := range statuses {
+		user := &domain.User{
+			Name:         "Usuario",
+			Email:        string(rune('a'+i)) + "@email.com",
+			Password:     "hash",
+			IDNumber:     "12345678",
+			Status:       status,
+			ReviewReason: "Posible coincidencia",
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	pending, err := repo.ListByStatus(ctx, domain.UserStatusPendingReview)
+	if err != nil || len(pending) != 2 || pending[0].ID != 2 || pending[1].ID != 4 {
+		t.Fatalf("Expected users 2 and 4 pending review, got %+v (%v)", pending, err)
+	}
+	if pending[0].ReviewReason != "Posible coincidencia" {
+		t.Errorf("Expected review reason to be persisted, got %q", pending[0].ReviewReason)
+	}
+
+	active, err := repo.ListByStatus(ctx, domain.UserStatusActive)
+	if err != nil || len(active) != 2 {
+		t.Errorf("Expected users without status to default to active, got %+v (%v)", active, err)
+	}
+
+	pending[0].Status = domain.UserStatusRejected
+	if err := repo.Update(ctx, pending[0]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := repo.GetByID(ctx, 2); found.Status != domain.UserStatusRejected {
+		t.Errorf("Expected rejected status, got %q", found.Status)
+	}
+}
+
+func TestUserRepository_DomainErrors(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	newUser := func() *domain.User {
+		return &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", Password: "hash", IDNumber: "12345678", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	}
+	if err := repo.Create(ctx, newUser()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.Create(ctx, newUser()); !errors.Is(err, domain.ErrEmailTaken) {
+		t.Errorf("Expected ErrEmailTaken, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, 99); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	repo := newTestUserRepository(t)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", Password: "hash", IDNumber: "12345678", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Update nunca escribe la contraseña, aunque el usuario traiga otra
+	user.Password = "texto-plano"
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := repo.GetByID(ctx, user.ID); found.Password != "hash" {
+		t.Errorf("Expected Update to keep the password, got %q", found.Password)
+	}
+
+	if err := repo.UpdatePassword(ctx, user.ID, "nuevo-hash", time.Now()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found, _ := repo.GetByID(ctx, user.ID); found.Password != "nuevo-hash" {
+		t.Errorf("Expected new password hash, got %q", found.Password)
+	}
+
+	if err := repo.UpdatePassword(ctx, 99, "hash", time.Now()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
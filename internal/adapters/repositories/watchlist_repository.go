@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"database/sql"
+	"encoding/json"
+)
+
+// WatchlistRepository implementa el repositorio de listas de sanciones con SQLite
+type WatchlistRepository struct {
+	db *sql.DB
+}
+
+// NewWatchlistRepository crea una nueva instancia del repositorio de listas de sanciones
+func NewWatchlistRepository(db *sql.DB) *WatchlistRepository {
+	return &WatchlistRepository{db: db}
+}
+
+// ReplaceList registra una nueva versión de una lista y reemplaza sus entradas en una
+// sola transacción, de modo que el screening nunca observa una lista a medio importar
+func (r *WatchlistRepository) ReplaceList(ctx context.Context, version *domain.WatchlistVersion, entries []*domain.WatchlistEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO watchlist_versions (source, version, checksum, entry_count, imported_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, version.Source, version.Version, version.Checksum, version.EntryCount, version.ImportedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM watchlist_entries WHERE source = ?`, version.Source); err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO watchlist_entries (source, version_id, external_id, name, aliases, id_numbers, program)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, entry := range entries {
+		aliases, _ := json.Marshal(nonNil(entry.Aliases))
+		idNumbers, _ := json.Marshal(nonNil(entry.IDNumbers))
+
+		if _, err := stmt.ExecContext(ctx, version.Source, id, entry.ExternalID, entry.Name, string(aliases), string(idNumbers), entry.Program); err != nil {
+			return err
+		}
+		entry.Source = version.Source
+		entry.VersionID = uint(id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	version.ID = uint(id)
+	return nil
+}
+
+// ListActiveVersions obtiene la versión vigente (la más reciente) de cada lista importada
+func (r *WatchlistRepository) ListActiveVersions(ctx context.Context) ([]*domain.WatchlistVersion, error) {
+	query := `
+		SELECT id, source, version, checksum, entry_count, imported_at
+		FROM watchlist_versions v
+		WHERE id = (SELECT MAX(id) FROM watchlist_versions WHERE source = v.source)
+		ORDER BY source
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []*domain.WatchlistVersion{}
+	for rows.Next() {
+		version := &domain.WatchlistVersion{}
+		if err := rows.Scan(&version.ID, &version.Source, &version.Version, &version.Checksum, &version.EntryCount, &version.ImportedAt); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
+
+// ListEntries obtiene las entradas vigentes de todas las listas
+func (r *WatchlistRepository) ListEntries(ctx context.Context) ([]*domain.WatchlistEntry, error) {
+	query := `SELECT id, source, version_id, external_id, name, aliases, id_numbers, program FROM watchlist_entries ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*domain.WatchlistEntry{}
+	for rows.Next() {
+		entry := &domain.WatchlistEntry{}
+		var aliases, idNumbers string
+		if err := rows.Scan(&entry.ID, &entry.Source, &entry.VersionID, &entry.ExternalID, &entry.Name, &aliases, &idNumbers, &entry.Program); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(aliases), &entry.Aliases); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(idNumbers), &entry.IDNumbers); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// nonNil evita que un slice vacío se serialice como null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
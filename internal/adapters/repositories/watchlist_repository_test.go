@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestWatchlistRepository_ReplaceListKeepsLatestVersion(t *testing.T) {
+	userRepo := newTestUserRepository(t)
+	repo := NewWatchlistRepository(userRepo.db)
+	ctx := context.Background()
+
+	first := &domain.WatchlistVersion{Source: domain.WatchlistSourceUIF, Version: "2024-01", Checksum: "a", EntryCount: 2, ImportedAt: time.Now()}
+	err := repo.ReplaceList(ctx, first, []*domain.WatchlistEntry{
+		{ExternalID: "1", Name: "María López", Aliases: []string{"La Güera"}, IDNumbers: []string{"LONM800101ABC"}},
+		{ExternalID: "2", Name: "Pedro Gómez"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	other := &domain.WatchlistVersion{Source: domain.WatchlistSourceOFAC, Version: "2024-01-10", Checksum: "b", EntryCount: 1, ImportedAt: time.Now()}
+	if err := repo.ReplaceList(ctx, other, []*domain.WatchlistEntry{{ExternalID: "36", Name: "AEROCARIBBEAN AIRLINES"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	second := &domain.WatchlistVersion{Source: domain.WatchlistSourceUIF, Version: "2024-02", Checksum: "c", EntryCount: 1, ImportedAt: time.Now()}
+	if err := repo.ReplaceList(ctx, second, []*domain.WatchlistEntry{{ExternalID: "3", Name: "Ana Ruiz"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	versions, err := repo.ListActiveVersions(ctx)
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("Expected 2 active versions, got %+v (%v)", versions, err)
+	}
+	for _, version := range versions {
+		if version.Source == domain.WatchlistSourceUIF && version.Version != "2024-02" {
+			t.Errorf("Expected UIF version 2024-02 to be active, got %s", version.Version)
+		}
+	}
+
+	entries, err := repo.ListEntries(ctx)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected 2 current entries, got %+v (%v)", entries, err)
+	}
+	for _, entry := range entries {
+		if entry.Source == domain.WatchlistSourceUIF && (entry.Name != "Ana Ruiz" || entry.VersionID != second.ID) {
+			t.Errorf("Expected only the entries of the latest UIF version, got %+v", entry)
+		}
+		if entry.Aliases == nil || entry.IDNumbers == nil {
+			t.Errorf("Expected empty slices instead of nil, got %+v", entry)
+		}
+	}
+}
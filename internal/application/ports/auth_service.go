@@ -1,10 +1,13 @@
 package ports
 
-import "crabi-test/internal/domain"
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
 
 // AuthService define las operaciones de autenticación
 type AuthService interface {
-	Login(email, password string) (*domain.User, string, error)
-	ValidateToken(token string) (*domain.User, error)
+	Login(ctx context.Context, email, password string) (*domain.User, string, error)
+	ValidateToken(ctx context.Context, token string) (*domain.User, error)
 	GenerateToken(user *domain.User) (string, error)
 }
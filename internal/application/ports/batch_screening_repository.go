@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// BatchScreeningRepository define las operaciones de persistencia de los lotes de screening
+type BatchScreeningRepository interface {
+	// CreateJob registra un lote junto con todas sus filas
+	CreateJob(ctx context.Context, job *domain.BatchScreeningJob, rows []*domain.BatchScreeningRow) error
+	// UpdateJob guarda el estado y los contadores de un lote
+	UpdateJob(ctx context.Context, job *domain.BatchScreeningJob) error
+	// GetJob obtiene un lote por su ID, o nil si no existe
+	GetJob(ctx context.Context, id uint) (*domain.BatchScreeningJob, error)
+	// ListUnfinishedJobs obtiene los lotes que quedaron en curso (por ejemplo, por un reinicio)
+	ListUnfinishedJobs(ctx context.Context) ([]*domain.BatchScreeningJob, error)
+	// UpdateRow guarda el resultado de una fila
+	UpdateRow(ctx context.Context, row *domain.BatchScreeningRow) error
+	// ListRows obtiene las filas de un lote en el orden recibido
+	ListRows(ctx context.Context, jobID uint) ([]*domain.BatchScreeningRow, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
+
+// MFAChallengeRepository define las operaciones de persistencia para los desafíos MFA del
+// inicio de sesión
+type MFAChallengeRepository interface {
+	// CreateWithinLimit registra un desafío solo si el usuario tiene menos de maxChallenges
+	// desafíos creados desde since. Retorna false si alcanzó el límite
+	CreateWithinLimit(ctx context.Context, challenge *domain.MFAChallenge, since time.Time, maxChallenges int) (bool, error)
+	// GetByTokenHash retorna nil si no existe un desafío con el hash indicado
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error)
+	// ReserveAttempt consume un intento de un desafío pendiente y vigente con menos de
+	// maxAttempts intentos. Retorna false si el desafío ya no admite intentos
+	ReserveAttempt(ctx context.Context, id uint, maxAttempts int, now time.Time) (bool, error)
+	// MarkCompleted marca como completado un desafío pendiente. Retorna false si ya se había
+	// completado
+	MarkCompleted(ctx context.Context, id uint, completedAt time.Time) (bool, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// MFAPolicyRepository define las operaciones de persistencia para las políticas MFA por rol
+type MFAPolicyRepository interface {
+	List(ctx context.Context) ([]domain.MFARolePolicy, error)
+	// Save crea o reemplaza la política del rol
+	Save(ctx context.Context, policy *domain.MFARolePolicy) error
+}
@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
+
+// MFARepository define las operaciones de persistencia para las inscripciones TOTP y los
+// códigos de recuperación
+type MFARepository interface {
+	// GetEnrollment retorna nil si el usuario no tiene una inscripción
+	GetEnrollment(ctx context.Context, userID uint) (*domain.MFAEnrollment, error)
+	// SaveEnrollment crea la inscripción pendiente del usuario o reemplaza la que estaba
+	// pendiente. Retorna false si el usuario ya tiene una inscripción confirmada
+	SaveEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) (bool, error)
+	// ConfirmEnrollment confirma una inscripción pendiente y reemplaza los códigos de
+	// recuperación del usuario en una sola transacción. Retorna false si no había una
+	// inscripción pendiente
+	ConfirmEnrollment(ctx context.Context, userID uint, step int64, confirmedAt time.Time, codeHashes []string) (bool, error)
+	// DeleteEnrollment elimina la inscripción y los códigos de recuperación del usuario
+	DeleteEnrollment(ctx context.Context, userID uint) error
+	// MarkStepUsed registra el paso de tiempo de un código aceptado. Retorna false si ya se
+	// había usado ese paso o uno posterior
+	MarkStepUsed(ctx context.Context, userID uint, step int64) (bool, error)
+	// ReplaceRecoveryCodes reemplaza los códigos de recuperación del usuario
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string, createdAt time.Time) error
+	// UseRecoveryCode marca como usado un código de recuperación vigente del usuario. Retorna
+	// false si no existe o ya se había usado
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error)
+	// CountRecoveryCodes retorna la cantidad de códigos de recuperación sin usar del usuario
+	CountRecoveryCodes(ctx context.Context, userID uint) (int, error)
+}
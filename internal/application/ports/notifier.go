@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// Notifier define el envío de avisos a los usuarios (p. ej. por email)
+type Notifier interface {
+	NotifyPasswordReset(ctx context.Context, notice domain.PasswordResetNotice) error
+}
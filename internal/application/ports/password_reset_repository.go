@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
+
+// PasswordResetRepository define las operaciones de persistencia para los tokens de
+// restablecimiento de contraseña
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+	// GetByTokenHash retorna nil si no existe un token con el hash indicado
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkUsed marca como usado un token vigente. Retorna false si el token ya se había usado
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error)
+	// InvalidateByUserID marca como usados los tokens vigentes del usuario
+	InvalidateByUserID(ctx context.Context, userID uint, usedAt time.Time) error
+}
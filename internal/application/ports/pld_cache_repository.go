@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
+
+// PLDCacheRepository define las operaciones de persistencia de la caché de resultados PLD
+type PLDCacheRepository interface {
+	// Get obtiene una entrada por su clave, o nil si no existe
+	Get(ctx context.Context, key string) (*domain.PLDCacheEntry, error)
+	// Put guarda o reemplaza una entrada
+	Put(ctx context.Context, entry *domain.PLDCacheEntry) error
+	// Delete elimina una entrada
+	Delete(ctx context.Context, key string) error
+	// DeleteExpired elimina las entradas vencidas y retorna cuántas se eliminaron
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -1,8 +1,11 @@
 package ports
 
-import "crabi-test/internal/domain"
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
 
 // PLDService define las operaciones del servicio de PLD
 type PLDService interface {
-	ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error)
+	ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error)
 }
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
+
+// RefreshTokenRepository define las operaciones de persistencia para los refresh tokens
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	// GetByTokenHash retorna nil si no existe un token con el hash indicado
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// MarkRotated marca como rotado un token vigente. Retorna false si el token ya se había
+	// rotado o revocado
+	MarkRotated(ctx context.Context, id uint, rotatedAt time.Time) (bool, error)
+	// RevokeFamily revoca todos los tokens de una familia
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	// RevokeByUserID revoca todos los tokens de un usuario
+	RevokeByUserID(ctx context.Context, userID uint, revokedAt time.Time) error
+}
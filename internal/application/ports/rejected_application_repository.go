@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// RejectedApplicationRepository define las operaciones de persistencia para solicitudes rechazadas
+type RejectedApplicationRepository interface {
+	Create(ctx context.Context, application *domain.RejectedApplication) error
+	List(ctx context.Context, filter domain.RejectedApplicationFilter) ([]*domain.RejectedApplication, error)
+}
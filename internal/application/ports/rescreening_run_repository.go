@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// RescreeningRunRepository define las operaciones de persistencia para las ejecuciones de re-screening
+type RescreeningRunRepository interface {
+	Create(ctx context.Context, run *domain.RescreeningRun) error
+	Update(ctx context.Context, run *domain.RescreeningRun) error
+	GetLatest(ctx context.Context) (*domain.RescreeningRun, error)
+	GetUnfinished(ctx context.Context) (*domain.RescreeningRun, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// ReviewDecisionRepository define las operaciones de persistencia para el registro de decisiones de revisión
+type ReviewDecisionRepository interface {
+	Create(ctx context.Context, decision *domain.ReviewDecision) error
+	// RecordDecision registra la decisión y cambia el estado del usuario en una transacción,
+	// solo si el usuario sigue pendiente de revisión. Retorna false si ya no lo está
+	RecordDecision(ctx context.Context, decision *domain.ReviewDecision, status string) (bool, error)
+	ListByUserID(ctx context.Context, userID uint) ([]*domain.ReviewDecision, error)
+}
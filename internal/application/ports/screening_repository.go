@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// ScreeningRepository define las operaciones de persistencia para el historial de screenings PLD
+type ScreeningRepository interface {
+	Create(ctx context.Context, screening *domain.Screening) error
+	LinkUser(ctx context.Context, screeningID, userID uint) error
+	ListByUserID(ctx context.Context, userID uint) ([]*domain.Screening, error)
+}
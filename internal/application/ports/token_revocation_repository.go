@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
+
+// TokenRevocationRepository define las operaciones de persistencia para las revocaciones de
+// tokens de acceso
+type TokenRevocationRepository interface {
+	// RevokeToken registra la revocación de un token por su jti
+	RevokeToken(ctx context.Context, revocation *domain.TokenRevocation) error
+	// IsTokenRevoked indica si el token con el jti indicado fue revocado
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// ListRevokedTokenIDs retorna los jti de las revocaciones vigentes en now
+	ListRevokedTokenIDs(ctx context.Context, now time.Time) ([]string, error)
+	// RevokeUserTokens registra o reemplaza la revocación de todos los tokens de un usuario
+	RevokeUserTokens(ctx context.Context, revocation *domain.UserTokenRevocation) error
+	// GetUserTokenRevocation retorna nil si el usuario no tiene una revocación registrada
+	GetUserTokenRevocation(ctx context.Context, userID uint) (*domain.UserTokenRevocation, error)
+	// DeleteExpired elimina las revocaciones de tokens que ya vencieron y retorna cuántas eliminó
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
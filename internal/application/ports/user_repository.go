@@ -1,12 +1,51 @@
 package ports
 
-import "crabi-test/internal/domain"
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"time"
+)
 
 // UserRepository define las operaciones de persistencia para usuarios
 type UserRepository interface {
-	Create(user *domain.User) error
-	GetByID(id uint) (*domain.User, error)
-	GetByEmail(email string) (*domain.User, error)
-	Update(user *domain.User) error
-	Delete(id uint) error
+	Create(ctx context.Context, user *domain.User) error
+	GetByID(ctx context.Context, id uint) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// UserBatchReader define la lectura por lotes de usuarios para procesos en segundo plano
+type UserBatchReader interface {
+	// ListAfterID obtiene hasta limit usuarios con ID mayor a afterID, ordenados por ID
+	ListAfterID(ctx context.Context, afterID uint, limit int) ([]*domain.User, error)
+}
+
+// UserStatusReader define la consulta de usuarios por estado para la cola de revisión
+type UserStatusReader interface {
+	// ListByStatus obtiene los usuarios con el estado indicado, del más antiguo al más reciente
+	ListByStatus(ctx context.Context, status string) ([]*domain.User, error)
+}
+
+// UserFlagWriter define el marcado de un usuario detectado en lista negra por un re-screening
+type UserFlagWriter interface {
+	// Flag establece la marca de PLD solo si el usuario aún no la tiene, sin modificar el resto
+	// del usuario. Retorna false si el usuario no existe o ya estaba marcado
+	Flag(ctx context.Context, id uint, reason string, flaggedAt time.Time) (bool, error)
+}
+
+// UserProfileWriter define las escrituras de la actualización de perfil. Ninguna reescribe el
+// usuario completo, para no pisar cambios de estado o marcas hechos en paralelo
+type UserProfileWriter interface {
+	UserFlagWriter
+	// UpdateProfile guarda solo los datos de perfil e identidad. Con markForReview además deja
+	// pendiente de revisión al usuario con user.ReviewReason, solo si su estado actual es activo
+	UpdateProfile(ctx context.Context, user *domain.User, markForReview bool) error
+}
+
+// UserPasswordWriter define la actualización de la contraseña de un usuario. Es la única vía
+// para cambiarla: Update no modifica la contraseña
+type UserPasswordWriter interface {
+	// UpdatePassword guarda el hash de la nueva contraseña
+	UpdatePassword(ctx context.Context, id uint, passwordHash string, updatedAt time.Time) error
 }
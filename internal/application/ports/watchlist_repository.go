@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+)
+
+// WatchlistRepository define las operaciones de persistencia para las listas de sanciones
+type WatchlistRepository interface {
+	// ReplaceList registra una nueva versión de una lista y reemplaza sus entradas
+	ReplaceList(ctx context.Context, version *domain.WatchlistVersion, entries []*domain.WatchlistEntry) error
+	// ListActiveVersions obtiene la versión vigente de cada lista importada
+	ListActiveVersions(ctx context.Context) ([]*domain.WatchlistVersion, error)
+	// ListEntries obtiene las entradas vigentes de todas las listas
+	ListEntries(ctx context.Context) ([]*domain.WatchlistEntry, error)
+}
@@ -1,117 +1,428 @@
 package services
 
 import (
+	"context"
 	"crabi-test/internal/application/ports"
 	"crabi-test/internal/domain"
+	"crabi-test/pkg/jwtkeys"
 	"errors"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// serviceSubjectPrefix antecede al nombre del servicio en el sub de los tokens de servicio
+const serviceSubjectPrefix = "service:"
+
+// Métodos de autenticación del claim amr (RFC 8176)
+const (
+	amrPassword = "pwd"
+	amrOTP      = "otp"
+)
+
+// AuthConfig define la vigencia de los tokens de acceso y los valores de los claims
+// registrados que se emiten y se exigen al validar
+type AuthConfig struct {
+	AccessTokenTTL time.Duration
+	// Issuer es el iss de los tokens que emite la API
+	Issuer string
+	// UserAudience es el aud de los tokens de usuarios finales
+	UserAudience string
+	// ServiceAudience es el aud de los tokens de servicios internos, que no se aceptan como
+	// tokens de usuario
+	ServiceAudience string
+	// ClockSkew es la tolerancia al validar exp, nbf e iat de tokens emitidos en otro servidor
+	ClockSkew time.Duration
+}
+
+// DefaultAuthConfig retorna la configuración por defecto: tokens de acceso de corta
+// duración que el cliente renueva con un refresh token
+func DefaultAuthConfig() AuthConfig {
+	return AuthConfig{
+		AccessTokenTTL:  15 * time.Minute,
+		Issuer:          "crabi-api",
+		UserAudience:    "crabi-api",
+		ServiceAudience: "crabi-internal",
+		ClockSkew:       30 * time.Second,
+	}
+}
+
+// AuthConfigFromEnv obtiene la configuración de las variables de entorno, usando los valores
+// por defecto para las que no estén definidas
+func AuthConfigFromEnv() AuthConfig {
+	config := DefaultAuthConfig()
+
+	if v, err := time.ParseDuration(os.Getenv("JWT_ACCESS_TOKEN_TTL")); err == nil && v > 0 {
+		config.AccessTokenTTL = v
+	}
+	if v := os.Getenv("JWT_ISSUER"); v != "" {
+		config.Issuer = v
+	}
+	if v := os.Getenv("JWT_AUDIENCE"); v != "" {
+		config.UserAudience = v
+	}
+	if v := os.Getenv("JWT_SERVICE_AUDIENCE"); v != "" {
+		config.ServiceAudience = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("JWT_CLOCK_SKEW")); err == nil && v >= 0 {
+		config.ClockSkew = v
+	}
+
+	return config
+}
+
+// AccessTokenClaims son los claims de los tokens que emite la API. sub es el ID del usuario,
+// o el nombre del servicio con el prefijo "service:" en los tokens de servicio. amr lista los
+// métodos con los que el usuario se autenticó
+type AccessTokenClaims struct {
+	Email string   `json:"email,omitempty"`
+	AMR   []string `json:"amr,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// MFA indica si la sesión se inició con un segundo factor
+func (c *AccessTokenClaims) MFA() bool {
+	for _, method := range c.AMR {
+		if method == amrOTP {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// errRevokedToken se retorna para los tokens revocados al cerrar sesión
+	errRevokedToken = domain.NewError(domain.ErrInvalidToken, "token revocado")
+	// errExpiredToken se retorna para los tokens vencidos, para que el cliente sepa que debe renovarlo
+	errExpiredToken = domain.NewError(domain.ErrInvalidToken, "token vencido")
+)
+
 // AuthService implementa la lógica de autenticación
 type AuthService struct {
-	userRepo ports.UserRepository
+	userRepo       ports.UserRepository
+	revocationRepo ports.TokenRevocationRepository
+	keyRing        *jwtkeys.KeyRing
+	config         AuthConfig
+	timeouts       Timeouts
 }
 
-// NewAuthService crea una nueva instancia del servicio de autenticación
-func NewAuthService(userRepo ports.UserRepository) *AuthService {
+// NewAuthService crea una nueva instancia del servicio de autenticación. Los tokens se
+// firman con la clave activa de keyRing
+func NewAuthService(userRepo ports.UserRepository, revocationRepo ports.TokenRevocationRepository, keyRing *jwtkeys.KeyRing) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		revocationRepo: revocationRepo,
+		keyRing:        keyRing,
+		config:         AuthConfigFromEnv(),
+		timeouts:       TimeoutsFromEnv(),
 	}
 }
 
 // Login autentica un usuario y retorna un token JWT
-func (s *AuthService) Login(email, password string) (*domain.User, string, error) {
+func (s *AuthService) Login(ctx context.Context, email, password string) (*domain.User, string, error) {
+	user, err := s.Authenticate(ctx, email, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Generar token JWT
+	token, err := s.GenerateToken(user)
+	if err != nil {
+		return nil, "", errors.New("error generando token")
+	}
+
+	return user, token, nil
+}
+
+// Authenticate verifica el email y la contraseña de un usuario activo, sin emitir tokens.
+// Los usuarios con MFA activado deben completar el segundo paso antes de recibirlos
+func (s *AuthService) Authenticate(ctx context.Context, email, password string) (*domain.User, error) {
 	// Buscar usuario por email
-	user, err := s.userRepo.GetByEmail(email)
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	user, err := s.userRepo.GetByEmail(dbCtx, email)
+	cancel()
 	if err != nil || user == nil {
-		return nil, "", errors.New("credenciales inválidas")
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, domain.ErrInvalidCredentials
 	}
 
 	// Verificar contraseña
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return nil, "", errors.New("credenciales inválidas")
+		return nil, domain.ErrInvalidCredentials
 	}
 
-	// Generar token JWT
-	token, err := s.GenerateToken(user)
-	if err != nil {
-		return nil, "", errors.New("error generando token")
+	// Solo los usuarios activos pueden iniciar sesión
+	if err := inactiveUserError(user); err != nil {
+		return nil, err
 	}
 
-	return user, token, nil
+	return user, nil
 }
 
-// GenerateToken genera un token JWT para un usuario
+// GenerateToken genera un token JWT para un usuario autenticado solo con contraseña
 func (s *AuthService) GenerateToken(user *domain.User) (string, error) {
-	// Obtener secret key del environment
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "crabi-jwt-secret-key-for-development-only"
+	return s.generateUserToken(user, amrPassword)
+}
+
+// GenerateMFAToken genera un token JWT para un usuario que además completó el segundo factor
+func (s *AuthService) GenerateMFAToken(user *domain.User) (string, error) {
+	return s.generateUserToken(user, amrPassword, amrOTP)
+}
+
+// generateUserToken genera un token JWT de usuario con los métodos de autenticación indicados
+func (s *AuthService) generateUserToken(user *domain.User, amr ...string) (string, error) {
+	claims, err := s.newClaims(strconv.FormatUint(uint64(user.ID), 10), s.config.UserAudience)
+	if err != nil {
+		return "", err
 	}
+	claims.Email = user.Email
+	claims.AMR = amr
 
-	// Crear claims del token
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // 24 horas
-		"iat":     time.Now().Unix(),
+	// Firmar token con la clave activa
+	tokenString, err := s.keyRing.Sign(claims)
+	if err != nil {
+		return "", err
 	}
 
-	// Crear token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return tokenString, nil
+}
+
+// GenerateServiceToken genera un token para que un servicio interno llame a otros servicios.
+// Lleva la audiencia de servicios, por lo que no se acepta como token de usuario
+func (s *AuthService) GenerateServiceToken(service string) (string, error) {
+	if service == "" {
+		return "", domain.NewError(domain.ErrInvalidInput, "el nombre del servicio es requerido")
+	}
 
-	// Firmar token
-	tokenString, err := token.SignedString([]byte(secretKey))
+	claims, err := s.newClaims(serviceSubjectPrefix+service, s.config.ServiceAudience)
 	if err != nil {
 		return "", err
 	}
+	return s.keyRing.Sign(claims)
+}
 
-	return tokenString, nil
+// AccessTokenTTL retorna la vigencia de los tokens de acceso
+func (s *AuthService) AccessTokenTTL() time.Duration {
+	return s.config.AccessTokenTTL
 }
 
-// ValidateToken valida un token JWT y retorna el usuario
-func (s *AuthService) ValidateToken(tokenString string) (*domain.User, error) {
-	// Obtener secret key del environment
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "crabi-jwt-secret-key-for-development-only"
+// ValidateToken valida un token JWT de usuario y retorna el usuario
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*domain.User, error) {
+	user, _, err := s.ValidateTokenClaims(ctx, tokenString)
+	return user, err
+}
+
+// ValidateTokenClaims valida un token JWT de usuario y retorna el usuario y los claims del
+// token, por ejemplo para saber si la sesión se inició con MFA
+func (s *AuthService) ValidateTokenClaims(ctx context.Context, tokenString string) (*domain.User, *AccessTokenClaims, error) {
+	claims, err := s.parseToken(tokenString, s.config.UserAudience)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// El sub de un token de usuario es su ID
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil || userID == 0 {
+		return nil, nil, domain.ErrInvalidToken
+	}
+
+	// Rechazar los tokens revocados al cerrar sesión
+	if err := s.checkTokenRevoked(ctx, claims.ID); err != nil {
+		return nil, nil, err
+	}
+
+	// Buscar usuario en base de datos
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	user, err := s.userRepo.GetByID(dbCtx, uint(userID))
+	cancel()
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, errors.New("error obteniendo usuario")
+	}
+	if user == nil {
+		return nil, nil, domain.NewError(domain.ErrInvalidToken, "usuario no encontrado")
+	}
+
+	// Rechazar los tokens emitidos antes de cerrar todas las sesiones del usuario
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	revocation, err := s.revocationRepo.GetUserTokenRevocation(dbCtx, user.ID)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, errors.New("error verificando revocación del token")
+	}
+	if revocation != nil && !claims.IssuedAt.After(revocation.RevokedBefore) {
+		return nil, nil, errRevokedToken
+	}
+
+	// Un usuario que pasó a revisión o fue rechazado después de iniciar sesión pierde el
+	// acceso aunque su token siga vigente
+	if err := inactiveUserError(user); err != nil {
+		return nil, nil, err
+	}
+
+	return user, claims, nil
+}
+
+// ValidateServiceToken valida un token de servicio interno y retorna el nombre del servicio
+func (s *AuthService) ValidateServiceToken(ctx context.Context, tokenString string) (string, error) {
+	claims, err := s.parseToken(tokenString, s.config.ServiceAudience)
+	if err != nil {
+		return "", err
+	}
+
+	service, ok := strings.CutPrefix(claims.Subject, serviceSubjectPrefix)
+	if !ok || service == "" {
+		return "", domain.ErrInvalidToken
+	}
+
+	if err := s.checkTokenRevoked(ctx, claims.ID); err != nil {
+		return "", err
+	}
+	return service, nil
+}
+
+// RevokeToken revoca un token de acceso hasta su vencimiento, para cerrar la sesión en la
+// que se emitió
+func (s *AuthService) RevokeToken(ctx context.Context, tokenString string) error {
+	claims, err := s.parseToken(tokenString, s.config.UserAudience)
+	if err != nil {
+		return err
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return domain.ErrInvalidToken
 	}
 
-	// Parsear token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secretKey), nil
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.revocationRepo.RevokeToken(dbCtx, &domain.TokenRevocation{
+		JTI:       claims.ID,
+		UserID:    uint(userID),
+		ExpiresAt: claims.ExpiresAt.Time.Add(s.config.ClockSkew),
+		RevokedAt: time.Now(),
 	})
+}
+
+// RevokeAllTokens revoca todos los tokens de acceso emitidos hasta ahora para el usuario.
+// La revocación se conserva lo que dura un token de acceso, tras lo cual ya vencieron todos.
+// iat tiene la precisión de jwt.TimePrecision y, con fracciones de segundo, puede perder un
+// intervalo al leerse como número de punto flotante. Por eso antes de retornar espera a que
+// pasen dos intervalos: así un token emitido después, como la sesión nueva al activar MFA,
+// siempre queda con iat posterior a la revocación
+func (s *AuthService) RevokeAllTokens(ctx context.Context, userID uint) error {
+	now := time.Now()
 
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	err := s.revocationRepo.RevokeUserTokens(dbCtx, &domain.UserTokenRevocation{
+		UserID:        userID,
+		RevokedBefore: now,
+		ExpiresAt:     now.Add(s.config.AccessTokenTTL + s.config.ClockSkew),
+	})
+	cancel()
 	if err != nil {
-		return nil, errors.New("token inválido")
+		return err
 	}
 
-	// Verificar que el token sea válido
-	if !token.Valid {
-		return nil, errors.New("token inválido")
+	timer := time.NewTimer(time.Until(now.Truncate(jwt.TimePrecision).Add(2 * jwt.TimePrecision)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newClaims crea los claims registrados de un token nuevo. El jti identifica el token para
+// poder revocarlo
+func (s *AuthService) newClaims(subject, audience string) (*AccessTokenClaims, error) {
+	jti, err := newRandomID()
+	if err != nil {
+		return nil, err
 	}
 
-	// Extraer claims
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil, errors.New("token inválido")
+	now := time.Now()
+	return &AccessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    s.config.Issuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.AccessTokenTTL)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}, nil
+}
+
+// parseToken verifica la firma de un token y sus claims registrados: iss y aud deben
+// coincidir con la configuración, y exp, nbf e iat se validan con la tolerancia de reloj.
+// El anillo de claves solo acepta tokens con el kid y el algoritmo de una de sus claves.
+// Todos los claims registrados son obligatorios; sin jti el token no se podría revocar
+func (s *AuthService) parseToken(tokenString, audience string) (*AccessTokenClaims, error) {
+	claims := &AccessTokenClaims{}
+	token, err := s.keyRing.Parse(tokenString, claims,
+		jwt.WithIssuer(s.config.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithLeeway(s.config.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errExpiredToken
+		}
+		return nil, domain.ErrInvalidToken
+	}
+	if !token.Valid {
+		return nil, domain.ErrInvalidToken
 	}
 
-	// Obtener user_id del token
-	userID, ok := claims["user_id"].(float64)
-	if !ok {
-		return nil, errors.New("token inválido")
+	if claims.ID == "" || claims.Subject == "" || claims.IssuedAt == nil || claims.NotBefore == nil {
+		return nil, domain.ErrInvalidToken
 	}
 
-	// Buscar usuario en base de datos
-	user, err := s.userRepo.GetByID(uint(userID))
+	return claims, nil
+}
+
+// checkTokenRevoked retorna errRevokedToken si el token fue revocado al cerrar sesión
+func (s *AuthService) checkTokenRevoked(ctx context.Context, jti string) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	revoked, err := s.revocationRepo.IsTokenRevoked(dbCtx, jti)
 	if err != nil {
-		return nil, errors.New("usuario no encontrado")
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return errors.New("error verificando revocación del token")
+	}
+	if revoked {
+		return errRevokedToken
 	}
+	return nil
+}
 
-	return user, nil
+// inactiveUserError retorna el error de un usuario que no puede obtener tokens por su
+// estado de revisión de cumplimiento, o nil si está activo
+func inactiveUserError(user *domain.User) error {
+	switch user.Status {
+	case domain.UserStatusPendingReview:
+		return domain.ErrPendingReview
+	case domain.UserStatusRejected:
+		return domain.ErrRejected
+	}
+	return nil
 }
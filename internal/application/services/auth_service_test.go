@@ -1,17 +1,44 @@
 package services
 
 import (
+	"context"
 	"crabi-test/internal/domain"
+	"crabi-test/pkg/jwtkeys"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// TestMain usa para iat la misma precisión que cmd/server
+func TestMain(m *testing.M) {
+	jwt.TimePrecision = time.Millisecond
+	os.Exit(m.Run())
+}
+
+// testKeyRing firma los tokens de las pruebas
+var testKeyRing = newTestKeyRing()
+
+func newTestKeyRing() *jwtkeys.KeyRing {
+	key, err := jwtkeys.GenerateKey(jwtkeys.ES256)
+	if err != nil {
+		panic(err)
+	}
+	keyRing, err := jwtkeys.NewKeyRing(key)
+	if err != nil {
+		panic(err)
+	}
+	return keyRing
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario con contraseña encriptada
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -24,10 +51,10 @@ func TestAuthService_Login_Success(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test Login
-	resultUser, token, err := authService.Login("juan.perez@email.com", "password123")
+	resultUser, token, err := authService.Login(context.Background(), "juan.perez@email.com", "password123")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -43,10 +70,10 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 func TestAuthService_Login_InvalidCredentials(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login con credenciales inválidas
-	user, token, err := authService.Login("nonexistent@email.com", "wrongpassword")
+	user, token, err := authService.Login(context.Background(), "nonexistent@email.com", "wrongpassword")
 
 	if err == nil {
 		t.Error("Expected error for invalid credentials")
@@ -63,7 +90,7 @@ func TestAuthService_Login_InvalidCredentials(t *testing.T) {
 
 func TestAuthService_Login_WrongPassword(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario con contraseña encriptada
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -76,10 +103,10 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test Login con contraseña incorrecta
-	resultUser, token, err := authService.Login("juan.perez@email.com", "wrongpassword")
+	resultUser, token, err := authService.Login(context.Background(), "juan.perez@email.com", "wrongpassword")
 
 	if err == nil {
 		t.Error("Expected error for wrong password")
@@ -96,7 +123,7 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 
 func TestAuthService_GenerateToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	user := &domain.User{
 		ID:        1,
@@ -121,7 +148,7 @@ func TestAuthService_GenerateToken(t *testing.T) {
 
 func TestAuthService_ValidateToken_Success(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	user := &domain.User{
 		ID:        1,
@@ -132,13 +159,13 @@ func TestAuthService_ValidateToken_Success(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Generar token
 	token, _ := authService.GenerateToken(user)
 
 	// Test ValidateToken
-	validatedUser, err := authService.ValidateToken(token)
+	validatedUser, err := authService.ValidateToken(context.Background(), token)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -148,12 +175,47 @@ func TestAuthService_ValidateToken_Success(t *testing.T) {
 	}
 }
 
+func TestAuthService_GenerateMFAToken(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", IDNumber: "12345678"}
+	userRepo.Create(context.Background(), user)
+
+	tests := []struct {
+		name     string
+		generate func(*domain.User) (string, error)
+		amr      []string
+		mfa      bool
+	}{
+		{"password", authService.GenerateToken, []string{"pwd"}, false},
+		{"mfa", authService.GenerateMFAToken, []string{"pwd", "otp"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.generate(user)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			validatedUser, claims, err := authService.ValidateTokenClaims(context.Background(), token)
+			if err != nil || validatedUser.ID != user.ID {
+				t.Fatalf("Expected user %d, got %+v (%v)", user.ID, validatedUser, err)
+			}
+			if strings.Join(claims.AMR, ",") != strings.Join(tt.amr, ",") || claims.MFA() != tt.mfa {
+				t.Errorf("Expected amr %v, got %v", tt.amr, claims.AMR)
+			}
+		})
+	}
+}
+
 func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test ValidateToken con token inválido
-	user, err := authService.ValidateToken("invalid.token.here")
+	user, err := authService.ValidateToken(context.Background(), "invalid.token.here")
 
 	if err == nil {
 		t.Error("Expected error for invalid token")
@@ -166,10 +228,10 @@ func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 
 func TestAuthService_ValidateToken_EmptyToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test ValidateToken con token vacío
-	user, err := authService.ValidateToken("")
+	user, err := authService.ValidateToken(context.Background(), "")
 
 	if err == nil {
 		t.Error("Expected error for empty token")
@@ -182,10 +244,10 @@ func TestAuthService_ValidateToken_EmptyToken(t *testing.T) {
 
 func TestAuthService_ValidateToken_MalformedToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test ValidateToken con token malformado
-	user, err := authService.ValidateToken("not.a.valid.jwt.token")
+	user, err := authService.ValidateToken(context.Background(), "not.a.valid.jwt.token")
 
 	if err == nil {
 		t.Error("Expected error for malformed token")
@@ -198,10 +260,10 @@ func TestAuthService_ValidateToken_MalformedToken(t *testing.T) {
 
 func TestAuthService_Login_EmptyCredentials(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login con credenciales vacías
-	user, token, err := authService.Login("", "")
+	user, token, err := authService.Login(context.Background(), "", "")
 
 	if err == nil {
 		t.Error("Expected error for empty credentials")
@@ -218,10 +280,10 @@ func TestAuthService_Login_EmptyCredentials(t *testing.T) {
 
 func TestAuthService_Login_EmptyEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login con email vacío
-	user, token, err := authService.Login("", "password123")
+	user, token, err := authService.Login(context.Background(), "", "password123")
 
 	if err == nil {
 		t.Error("Expected error for empty email")
@@ -238,10 +300,10 @@ func TestAuthService_Login_EmptyEmail(t *testing.T) {
 
 func TestAuthService_Login_EmptyPassword(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login con contraseña vacía
-	user, token, err := authService.Login("test@email.com", "")
+	user, token, err := authService.Login(context.Background(), "test@email.com", "")
 
 	if err == nil {
 		t.Error("Expected error for empty password")
@@ -258,7 +320,7 @@ func TestAuthService_Login_EmptyPassword(t *testing.T) {
 
 func TestAuthService_Login_WithMultipleUsers(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear múltiples usuarios con contraseñas encriptadas
 	users := []struct {
@@ -283,12 +345,12 @@ func TestAuthService_Login_WithMultipleUsers(t *testing.T) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
-		userRepo.Create(user)
+		userRepo.Create(context.Background(), user)
 	}
 
 	// Test login para cada usuario
 	for _, u := range users {
-		resultUser, token, err := authService.Login(u.email, u.password)
+		resultUser, token, err := authService.Login(context.Background(), u.email, u.password)
 		if err != nil {
 			t.Errorf("Expected no error for %s, got %v", u.email, err)
 		}
@@ -309,7 +371,7 @@ func TestAuthService_Login_WithMultipleUsers(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithMultipleTokens(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario
 	user := &domain.User{
@@ -321,7 +383,7 @@ func TestAuthService_ValidateToken_WithMultipleTokens(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Generar múltiples tokens
 	tokens := make([]string, 5)
@@ -335,7 +397,7 @@ func TestAuthService_ValidateToken_WithMultipleTokens(t *testing.T) {
 
 	// Validar cada token
 	for i, token := range tokens {
-		validatedUser, err := authService.ValidateToken(token)
+		validatedUser, err := authService.ValidateToken(context.Background(), token)
 		if err != nil {
 			t.Errorf("Expected no error validating token %d, got %v", i+1, err)
 		}
@@ -352,7 +414,7 @@ func TestAuthService_ValidateToken_WithMultipleTokens(t *testing.T) {
 
 func TestAuthService_GenerateToken_WithMultipleUsers(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear múltiples usuarios
 	users := []*domain.User{
@@ -401,7 +463,7 @@ func TestAuthService_GenerateToken_WithMultipleUsers(t *testing.T) {
 
 func TestAuthService_Login_WithSpecialCharacters(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario con caracteres especiales
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -414,10 +476,10 @@ func TestAuthService_Login_WithSpecialCharacters(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test Login
-	resultUser, token, err := authService.Login("jose.maria@email.com", "password123")
+	resultUser, token, err := authService.Login(context.Background(), "jose.maria@email.com", "password123")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -437,7 +499,7 @@ func TestAuthService_Login_WithSpecialCharacters(t *testing.T) {
 
 func TestAuthService_Login_WithUnicodeCharacters(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario con caracteres Unicode
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -450,10 +512,10 @@ func TestAuthService_Login_WithUnicodeCharacters(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test Login
-	resultUser, token, err := authService.Login("jose.maria@email.com", "password123")
+	resultUser, token, err := authService.Login(context.Background(), "jose.maria@email.com", "password123")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -473,13 +535,13 @@ func TestAuthService_Login_WithUnicodeCharacters(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithExpiredToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con token que simula estar expirado
 	// En un entorno real, esto requeriría manipular el tiempo
 	// Por ahora, solo verificamos que el middleware maneja tokens inválidos
 
-	user, err := authService.ValidateToken("expired.token.here")
+	user, err := authService.ValidateToken(context.Background(), "expired.token.here")
 
 	if err == nil {
 		t.Error("Expected error for expired token")
@@ -492,7 +554,7 @@ func TestAuthService_ValidateToken_WithExpiredToken(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithMalformedToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con tokens malformados
 	malformedTokens := []string{
@@ -504,7 +566,7 @@ func TestAuthService_ValidateToken_WithMalformedToken(t *testing.T) {
 	}
 
 	for i, token := range malformedTokens {
-		user, err := authService.ValidateToken(token)
+		user, err := authService.ValidateToken(context.Background(), token)
 
 		if err == nil {
 			t.Errorf("Expected error for malformed token %d: %s", i+1, token)
@@ -518,7 +580,7 @@ func TestAuthService_ValidateToken_WithMalformedToken(t *testing.T) {
 
 func TestAuthService_Login_WithDifferentPasswordCosts(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con diferentes costos de bcrypt
 	costs := []int{bcrypt.MinCost, bcrypt.DefaultCost, bcrypt.DefaultCost + 1}
@@ -534,10 +596,10 @@ func TestAuthService_Login_WithDifferentPasswordCosts(t *testing.T) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
-		userRepo.Create(user)
+		userRepo.Create(context.Background(), user)
 
 		// Test Login
-		resultUser, token, err := authService.Login(user.Email, "password123")
+		resultUser, token, err := authService.Login(context.Background(), user.Email, "password123")
 		if err != nil {
 			t.Errorf("Expected no error for cost %d, got %v", cost, err)
 		}
@@ -554,10 +616,10 @@ func TestAuthService_Login_WithDifferentPasswordCosts(t *testing.T) {
 
 func TestAuthService_Login_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login con error del repositorio
-	user, token, err := authService.Login("test@email.com", "password123")
+	user, token, err := authService.Login(context.Background(), "test@email.com", "password123")
 
 	if err == nil {
 		t.Error("Expected error from repository")
@@ -574,10 +636,10 @@ func TestAuthService_Login_WithRepositoryError(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test ValidateToken con error del repositorio
-	user, err := authService.ValidateToken("valid.token.here")
+	user, err := authService.ValidateToken(context.Background(), "valid.token.here")
 
 	if err == nil {
 		t.Error("Expected error from repository")
@@ -590,7 +652,7 @@ func TestAuthService_ValidateToken_WithRepositoryError(t *testing.T) {
 
 func TestAuthService_Login_WithDifferentPasswordHashes(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con diferentes tipos de hash de contraseña
 	testCases := []struct {
@@ -615,10 +677,10 @@ func TestAuthService_Login_WithDifferentPasswordHashes(t *testing.T) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
-		userRepo.Create(user)
+		userRepo.Create(context.Background(), user)
 
 		// Test Login
-		resultUser, token, err := authService.Login(user.Email, tc.password)
+		resultUser, token, err := authService.Login(context.Background(), user.Email, tc.password)
 		if err != nil {
 			t.Errorf("Expected no error for password %s, got %v", tc.password, err)
 		}
@@ -635,7 +697,7 @@ func TestAuthService_Login_WithDifferentPasswordHashes(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithDifferentTokenFormats(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con diferentes formatos de token inválidos
 	invalidTokens := []string{
@@ -652,7 +714,7 @@ func TestAuthService_ValidateToken_WithDifferentTokenFormats(t *testing.T) {
 	}
 
 	for i, token := range invalidTokens {
-		user, err := authService.ValidateToken(token)
+		user, err := authService.ValidateToken(context.Background(), token)
 
 		if err == nil {
 			t.Errorf("Expected error for invalid token %d: %s", i+1, token)
@@ -666,7 +728,7 @@ func TestAuthService_ValidateToken_WithDifferentTokenFormats(t *testing.T) {
 
 func TestAuthService_Login_WithSpecialCharactersInPassword(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con contraseña que contiene caracteres especiales
 	specialPassword := "p@ssw0rd!@#$%^&*()_+-=[]{}|;':\",./<>?"
@@ -680,10 +742,10 @@ func TestAuthService_Login_WithSpecialCharactersInPassword(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test Login
-	resultUser, token, err := authService.Login("test@email.com", specialPassword)
+	resultUser, token, err := authService.Login(context.Background(), "test@email.com", specialPassword)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -699,7 +761,7 @@ func TestAuthService_Login_WithSpecialCharactersInPassword(t *testing.T) {
 
 func TestAuthService_GenerateToken_WithDifferentUserTypes(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test con diferentes tipos de usuarios
 	users := []*domain.User{
@@ -756,10 +818,10 @@ func TestAuthService_GenerateToken_WithDifferentUserTypes(t *testing.T) {
 // Tests adicionales para aumentar cobertura
 func TestAuthService_Login_WithEmptyUserRepository(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login con repositorio vacío
-	user, token, err := authService.Login("nonexistent@email.com", "password123")
+	user, token, err := authService.Login(context.Background(), "nonexistent@email.com", "password123")
 
 	if err == nil {
 		t.Error("Expected error for non-existent user")
@@ -776,10 +838,10 @@ func TestAuthService_Login_WithEmptyUserRepository(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithEmptyToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test ValidateToken con token vacío
-	user, err := authService.ValidateToken("")
+	user, err := authService.ValidateToken(context.Background(), "")
 
 	if err == nil {
 		t.Error("Expected error for empty token")
@@ -792,10 +854,10 @@ func TestAuthService_ValidateToken_WithEmptyToken(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithWhitespaceToken(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test ValidateToken con token que solo contiene espacios
-	user, err := authService.ValidateToken("   ")
+	user, err := authService.ValidateToken(context.Background(), "   ")
 
 	if err == nil {
 		t.Error("Expected error for whitespace token")
@@ -808,10 +870,10 @@ func TestAuthService_ValidateToken_WithWhitespaceToken(t *testing.T) {
 
 func TestAuthService_Login_WithNilUserFromRepository(t *testing.T) {
 	userRepo := &NilUserMockRepository{}
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Test Login cuando el repositorio retorna nil
-	user, token, err := authService.Login("test@email.com", "password123")
+	user, token, err := authService.Login(context.Background(), "test@email.com", "password123")
 
 	if err == nil {
 		t.Error("Expected error for nil user")
@@ -828,7 +890,7 @@ func TestAuthService_Login_WithNilUserFromRepository(t *testing.T) {
 
 func TestAuthService_ValidateToken_WithRepositoryErrorOnGetByID(t *testing.T) {
 	userRepo := NewErrorOnGetByIDMockRepository()
-	authService := NewAuthService(userRepo)
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario y generar token
 	user := &domain.User{
@@ -840,12 +902,12 @@ func TestAuthService_ValidateToken_WithRepositoryErrorOnGetByID(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	token, _ := authService.GenerateToken(user)
 
 	// Test ValidateToken con error en GetByID
-	validatedUser, err := authService.ValidateToken(token)
+	validatedUser, err := authService.ValidateToken(context.Background(), token)
 
 	if err == nil {
 		t.Error("Expected error from repository GetByID")
@@ -856,6 +918,202 @@ func TestAuthService_ValidateToken_WithRepositoryErrorOnGetByID(t *testing.T) {
 	}
 }
 
+func TestAuthService_RevokeToken(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", IDNumber: "12345678"}
+	userRepo.Create(ctx, user)
+
+	token, _ := authService.GenerateToken(user)
+	otherToken, _ := authService.GenerateToken(user)
+
+	if err := authService.RevokeToken(ctx, token); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := authService.ValidateToken(ctx, token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a revoked token, got %v", err)
+	}
+
+	// Los demás tokens del usuario siguen siendo válidos
+	if _, err := authService.ValidateToken(ctx, otherToken); err != nil {
+		t.Errorf("Expected no error for another session, got %v", err)
+	}
+}
+
+func TestAuthService_RevokeAllTokens(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	revocationRepo := NewMockTokenRevocationRepository()
+	authService := NewAuthService(userRepo, revocationRepo, testKeyRing)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", IDNumber: "12345678"}
+	userRepo.Create(ctx, user)
+	token, _ := authService.GenerateToken(user)
+
+	if err := authService.RevokeAllTokens(ctx, user.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := authService.ValidateToken(ctx, token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a token issued before the revocation, got %v", err)
+	}
+
+	// Los tokens emitidos después de la revocación son válidos, aunque sea en el mismo segundo
+	newToken, _ := authService.GenerateToken(user)
+	if _, err := authService.ValidateToken(ctx, newToken); err != nil {
+		t.Errorf("Expected no error for a token issued after the revocation, got %v", err)
+	}
+}
+
+// signTestClaims firma claims válidos de un token de usuario con los cambios de mutate
+func signTestClaims(t *testing.T, userID uint, mutate func(claims *AccessTokenClaims)) string {
+	t.Helper()
+	config := DefaultAuthConfig()
+	now := time.Now()
+	claims := &AccessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-prueba",
+			Issuer:    config.Issuer,
+			Subject:   fmt.Sprint(userID),
+			Audience:  jwt.ClaimStrings{config.UserAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	mutate(claims)
+
+	token, err := testKeyRing.Sign(claims)
+	if err != nil {
+		t.Fatalf("Expected no error signing, got %v", err)
+	}
+	return token
+}
+
+func TestAuthService_ValidateToken_RegisteredClaims(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", IDNumber: "12345678"}
+	userRepo.Create(context.Background(), user)
+
+	skew := DefaultAuthConfig().ClockSkew
+	tests := []struct {
+		name   string
+		mutate func(claims *AccessTokenClaims)
+		valid  bool
+	}{
+		{"valid", func(claims *AccessTokenClaims) {}, true},
+		{"wrong issuer", func(claims *AccessTokenClaims) { claims.Issuer = "otro-emisor" }, false},
+		{"missing issuer", func(claims *AccessTokenClaims) { claims.Issuer = "" }, false},
+		{"service audience", func(claims *AccessTokenClaims) {
+			claims.Audience = jwt.ClaimStrings{DefaultAuthConfig().ServiceAudience}
+		}, false},
+		{"missing audience", func(claims *AccessTokenClaims) { claims.Audience = nil }, false},
+		{"missing subject", func(claims *AccessTokenClaims) { claims.Subject = "" }, false},
+		{"non numeric subject", func(claims *AccessTokenClaims) { claims.Subject = "service:pld-worker" }, false},
+		{"zero subject", func(claims *AccessTokenClaims) { claims.Subject = "0" }, false},
+		{"missing jti", func(claims *AccessTokenClaims) { claims.ID = "" }, false},
+		{"missing exp", func(claims *AccessTokenClaims) { claims.ExpiresAt = nil }, false},
+		{"missing nbf", func(claims *AccessTokenClaims) { claims.NotBefore = nil }, false},
+		{"missing iat", func(claims *AccessTokenClaims) { claims.IssuedAt = nil }, false},
+		{"expired", func(claims *AccessTokenClaims) {
+			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-skew - time.Minute))
+		}, false},
+		{"expired within skew", func(claims *AccessTokenClaims) { claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-skew / 2)) }, true},
+		{"not yet valid", func(claims *AccessTokenClaims) {
+			claims.NotBefore = jwt.NewNumericDate(time.Now().Add(skew + time.Minute))
+		}, false},
+		{"not yet valid within skew", func(claims *AccessTokenClaims) { claims.NotBefore = jwt.NewNumericDate(time.Now().Add(skew / 2)) }, true},
+		{"issued in the future", func(claims *AccessTokenClaims) {
+			claims.IssuedAt = jwt.NewNumericDate(time.Now().Add(skew + time.Minute))
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := signTestClaims(t, user.ID, tt.mutate)
+			_, err := authService.ValidateToken(context.Background(), token)
+			if tt.valid && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("Expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ValidateToken_ExpiredMessage(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+
+	token := signTestClaims(t, 1, func(claims *AccessTokenClaims) {
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	})
+
+	_, err := authService.ValidateToken(context.Background(), token)
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || domainErr.Detail() != "token vencido" {
+		t.Errorf("Expected the expired token error, got %v", err)
+	}
+}
+
+func TestAuthService_ServiceTokens(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+	ctx := context.Background()
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", IDNumber: "12345678"}
+	userRepo.Create(ctx, user)
+
+	serviceToken, err := authService.GenerateServiceToken("pld-worker")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	service, err := authService.ValidateServiceToken(ctx, serviceToken)
+	if err != nil || service != "pld-worker" {
+		t.Errorf("Expected service pld-worker, got %q (%v)", service, err)
+	}
+
+	// Las audiencias no son intercambiables
+	if _, err := authService.ValidateToken(ctx, serviceToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a service token used as user token, got %v", err)
+	}
+	userToken, _ := authService.GenerateToken(user)
+	if _, err := authService.ValidateServiceToken(ctx, userToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a user token used as service token, got %v", err)
+	}
+
+	if _, err := authService.GenerateServiceToken(""); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for an empty service name, got %v", err)
+	}
+}
+
+func TestAuthService_ValidateToken_RejectsSymmetricToken(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+
+	user := &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", IDNumber: "12345678"}
+	userRepo.Create(context.Background(), user)
+
+	// Un token HS256 con el kid de la clave activa y un jti válido
+	claims := jwt.MapClaims{
+		"jti":     "token-hs256",
+		"user_id": user.ID,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = testKeyRing.ActiveKey().ID
+	signed, _ := token.SignedString([]byte("crabi-jwt-secret-key-for-development-only"))
+
+	if _, err := authService.ValidateToken(context.Background(), signed); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an HS256 token, got %v", err)
+	}
+}
+
 // Mock repositories adicionales para casos edge
 type NilUserMockRepository struct {
 	users  map[uint]*domain.User
@@ -869,25 +1127,25 @@ func NewNilUserMockRepository() *NilUserMockRepository {
 	}
 }
 
-func (m *NilUserMockRepository) Create(user *domain.User) error {
+func (m *NilUserMockRepository) Create(ctx context.Context, user *domain.User) error {
 	m.users[user.ID] = user
 	m.emails[user.Email] = user
 	return nil
 }
 
-func (m *NilUserMockRepository) GetByID(id uint) (*domain.User, error) {
+func (m *NilUserMockRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	return nil, nil // Siempre retorna nil
 }
 
-func (m *NilUserMockRepository) GetByEmail(email string) (*domain.User, error) {
+func (m *NilUserMockRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	return nil, nil // Siempre retorna nil
 }
 
-func (m *NilUserMockRepository) Update(user *domain.User) error {
+func (m *NilUserMockRepository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-func (m *NilUserMockRepository) Delete(id uint) error {
+func (m *NilUserMockRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
@@ -903,27 +1161,77 @@ func NewErrorOnGetByIDMockRepository() *ErrorOnGetByIDMockRepository {
 	}
 }
 
-func (m *ErrorOnGetByIDMockRepository) Create(user *domain.User) error {
+func (m *ErrorOnGetByIDMockRepository) Create(ctx context.Context, user *domain.User) error {
 	m.users[user.ID] = user
 	m.emails[user.Email] = user
 	return nil
 }
 
-func (m *ErrorOnGetByIDMockRepository) GetByID(id uint) (*domain.User, error) {
+func (m *ErrorOnGetByIDMockRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	return nil, fmt.Errorf("database error")
 }
 
-func (m *ErrorOnGetByIDMockRepository) GetByEmail(email string) (*domain.User, error) {
+func (m *ErrorOnGetByIDMockRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	if user, exists := m.emails[email]; exists {
 		return user, nil
 	}
 	return nil, nil
 }
 
-func (m *ErrorOnGetByIDMockRepository) Update(user *domain.User) error {
+func (m *ErrorOnGetByIDMockRepository) Update(ctx context.Context, user *domain.User) error {
+	return nil
+}
+
+func (m *ErrorOnGetByIDMockRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (m *ErrorOnGetByIDMockRepository) Delete(id uint) error {
+// MockTokenRevocationRepository para testing
+type MockTokenRevocationRepository struct {
+	tokens map[string]domain.TokenRevocation
+	users  map[uint]domain.UserTokenRevocation
+}
+
+func NewMockTokenRevocationRepository() *MockTokenRevocationRepository {
+	return &MockTokenRevocationRepository{
+		tokens: make(map[string]domain.TokenRevocation),
+		users:  make(map[uint]domain.UserTokenRevocation),
+	}
+}
+
+func (m *MockTokenRevocationRepository) RevokeToken(ctx context.Context, revocation *domain.TokenRevocation) error {
+	m.tokens[revocation.JTI] = *revocation
 	return nil
 }
+
+func (m *MockTokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	_, revoked := m.tokens[jti]
+	return revoked, nil
+}
+
+func (m *MockTokenRevocationRepository) ListRevokedTokenIDs(ctx context.Context, now time.Time) ([]string, error) {
+	var ids []string
+	for jti, revocation := range m.tokens {
+		if revocation.ExpiresAt.After(now) {
+			ids = append(ids, jti)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockTokenRevocationRepository) RevokeUserTokens(ctx context.Context, revocation *domain.UserTokenRevocation) error {
+	m.users[revocation.UserID] = *revocation
+	return nil
+}
+
+func (m *MockTokenRevocationRepository) GetUserTokenRevocation(ctx context.Context, userID uint) (*domain.UserTokenRevocation, error) {
+	revocation, exists := m.users[userID]
+	if !exists {
+		return nil, nil
+	}
+	return &revocation, nil
+}
+
+func (m *MockTokenRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BatchScreeningConfig define la ejecución de los lotes de screening
+type BatchScreeningConfig struct {
+	// Concurrency es el número máximo de validaciones PLD simultáneas por lote
+	Concurrency int
+	// MaxRows es el número máximo de filas aceptadas en un lote
+	MaxRows int
+}
+
+// DefaultBatchScreeningConfig retorna la configuración por defecto de los lotes de screening
+func DefaultBatchScreeningConfig() BatchScreeningConfig {
+	return BatchScreeningConfig{
+		Concurrency: 4,
+		MaxRows:     1000,
+	}
+}
+
+// BatchScreeningConfigFromEnv construye la configuración a partir de variables de entorno
+func BatchScreeningConfigFromEnv() BatchScreeningConfig {
+	config := DefaultBatchScreeningConfig()
+
+	if v, err := strconv.Atoi(os.Getenv("BATCH_SCREENING_CONCURRENCY")); err == nil && v > 0 {
+		config.Concurrency = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("BATCH_SCREENING_MAX_ROWS")); err == nil && v > 0 {
+		config.MaxRows = v
+	}
+
+	return config
+}
+
+// BatchScreeningService valida lotes de prospectos contra el servicio PLD en segundo plano
+type BatchScreeningService struct {
+	repo     ports.BatchScreeningRepository
+	screener *screener
+	config   BatchScreeningConfig
+	timeouts Timeouts
+}
+
+// NewBatchScreeningService crea una nueva instancia del servicio de lotes de screening
+func NewBatchScreeningService(pldService ports.PLDService, screeningRepo ports.ScreeningRepository, repo ports.BatchScreeningRepository, config BatchScreeningConfig) *BatchScreeningService {
+	defaults := DefaultBatchScreeningConfig()
+	if config.Concurrency < 1 {
+		config.Concurrency = defaults.Concurrency
+	}
+	if config.MaxRows < 1 {
+		config.MaxRows = defaults.MaxRows
+	}
+
+	timeouts := TimeoutsFromEnv()
+	return &BatchScreeningService{
+		repo:     repo,
+		screener: newScreener(pldService, screeningRepo, timeouts),
+		config:   config,
+		timeouts: timeouts,
+	}
+}
+
+// Submit registra un lote y lo valida en segundo plano. Las filas sin número de
+// identificación o nombre se registran como error sin consultar al servicio PLD
+func (s *BatchScreeningService) Submit(ctx context.Context, rows []*domain.BatchScreeningRow, source string, requestedBy uint) (*domain.BatchScreeningJob, error) {
+	if len(rows) == 0 {
+		return nil, domain.NewError(domain.ErrInvalidInput, "el lote no contiene filas")
+	}
+	if len(rows) > s.config.MaxRows {
+		return nil, domain.Errorf(domain.ErrInvalidInput, "el lote excede el máximo de %d filas", s.config.MaxRows)
+	}
+
+	now := time.Now()
+	job := &domain.BatchScreeningJob{
+		Status:      domain.BatchScreeningStatusRunning,
+		Source:      source,
+		RequestedBy: requestedBy,
+		Total:       len(rows),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	var pending []*domain.BatchScreeningRow
+	for i, row := range rows {
+		row.Line = i + 1
+		row.IDNumber = strings.TrimSpace(row.IDNumber)
+		row.Name = strings.TrimSpace(row.Name)
+		row.Email = strings.TrimSpace(row.Email)
+		row.Status = domain.BatchRowStatusPending
+
+		if row.IDNumber == "" || row.Name == "" {
+			row.Status = domain.ScreeningStatusError
+			row.Error = "fila inválida: id_number y name son obligatorios"
+			row.ProcessedAt = &now
+			job.Processed++
+			job.Failed++
+			continue
+		}
+		pending = append(pending, row)
+	}
+	if len(pending) == 0 {
+		job.Status = domain.BatchScreeningStatusCompleted
+		job.FinishedAt = &now
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	if err := s.repo.CreateJob(dbCtx, job, rows); err != nil {
+		return nil, errors.New("error registrando lote de screening")
+	}
+
+	snapshot := *job
+	if len(pending) > 0 {
+		// El lote continúa aunque termine la solicitud que lo inició
+		go s.execute(context.WithoutCancel(ctx), job, pending)
+	}
+
+	return &snapshot, nil
+}
+
+// Get obtiene un lote y el resultado de cada fila
+func (s *BatchScreeningService) Get(ctx context.Context, id uint) (*domain.BatchScreeningJob, []*domain.BatchScreeningRow, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	job, err := s.repo.GetJob(dbCtx, id)
+	if err != nil {
+		return nil, nil, errors.New("error obteniendo lote de screening")
+	}
+	if job == nil {
+		return nil, nil, domain.NewError(domain.ErrNotFound, "lote de screening no encontrado")
+	}
+
+	rows, err := s.repo.ListRows(dbCtx, id)
+	if err != nil {
+		return nil, nil, errors.New("error obteniendo lote de screening")
+	}
+
+	return job, rows, nil
+}
+
+// ResumeUnfinished reanuda en segundo plano los lotes que quedaron en curso
+func (s *BatchScreeningService) ResumeUnfinished(ctx context.Context) {
+	go func() {
+		dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+		jobs, err := s.repo.ListUnfinishedJobs(dbCtx)
+		cancel()
+		if err != nil {
+			log.Printf("lotes de screening: error buscando lotes pendientes: %v", err)
+			return
+		}
+
+		for _, job := range jobs {
+			dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+			rows, err := s.repo.ListRows(dbCtx, job.ID)
+			cancel()
+			if err != nil {
+				log.Printf("lotes de screening: error leyendo lote %d: %v", job.ID, err)
+				continue
+			}
+
+			var pending []*domain.BatchScreeningRow
+			for _, row := range rows {
+				if row.Status == domain.BatchRowStatusPending {
+					pending = append(pending, row)
+				}
+			}
+
+			log.Printf("lotes de screening: reanudando lote %d (%d filas pendientes)", job.ID, len(pending))
+			s.execute(ctx, job, pending)
+		}
+	}()
+}
+
+// execute valida las filas pendientes con concurrencia limitada. Un único recolector
+// persiste los resultados para no competir por escrituras en la base de datos. Si ctx
+// se cancela el lote queda en curso para reanudarse en el próximo arranque
+func (s *BatchScreeningService) execute(ctx context.Context, job *domain.BatchScreeningJob, rows []*domain.BatchScreeningRow) {
+	queue := make(chan *domain.BatchScreeningRow)
+	results := make(chan *domain.BatchScreeningRow)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(s.config.Concurrency, len(rows)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range queue {
+				if s.screenRow(ctx, row) {
+					results <- row
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		for _, row := range rows {
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- row:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for row := range results {
+		s.record(ctx, job, row)
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := time.Now()
+	job.Status = domain.BatchScreeningStatusCompleted
+	job.FinishedAt = &now
+	s.saveJob(ctx, job)
+	log.Printf("lote de screening %d completado: %d limpios, %d en lista negra, %d en revisión, %d fallidos", job.ID, job.Clean, job.Blacklisted, job.Review, job.Failed)
+}
+
+// screenRow valida una fila. Retorna false si ctx fue cancelado y la fila debe quedar pendiente
+func (s *BatchScreeningService) screenRow(ctx context.Context, row *domain.BatchScreeningRow) bool {
+	pldResponse, screening, err := s.screener.screen(ctx, domain.PLDRequest{IDNumber: row.IDNumber, Name: row.Name, Email: row.Email}, nil)
+	if ctx.Err() != nil {
+		return false
+	}
+
+	now := time.Now()
+	row.ProcessedAt = &now
+	if err != nil {
+		row.Status = domain.ScreeningStatusError
+		row.Error = err.Error()
+		return true
+	}
+
+	row.Status = screening.Status
+	row.Reason = pldResponse.Reason
+	row.MatchScore = screening.MatchScore
+	row.Provider = screening.Provider
+	row.ScreeningID = &screening.ID
+	return true
+}
+
+// record persiste el resultado de una fila y actualiza los contadores del lote
+func (s *BatchScreeningService) record(ctx context.Context, job *domain.BatchScreeningJob, row *domain.BatchScreeningRow) {
+	job.Processed++
+	switch row.Status {
+	case domain.ScreeningStatusClean:
+		job.Clean++
+	case domain.ScreeningStatusBlacklisted:
+		job.Blacklisted++
+	case domain.ScreeningStatusReview:
+		job.Review++
+	default:
+		job.Failed++
+	}
+
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+
+	if err := s.repo.UpdateRow(dbCtx, row); err != nil {
+		log.Printf("lote de screening %d: error guardando fila %d: %v", job.ID, row.Line, err)
+	}
+	s.saveJob(ctx, job)
+}
+
+// saveJob persiste el estado y los contadores del lote
+func (s *BatchScreeningService) saveJob(ctx context.Context, job *domain.BatchScreeningJob) {
+	job.UpdatedAt = time.Now()
+
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+
+	if err := s.repo.UpdateJob(dbCtx, job); err != nil {
+		log.Printf("lote de screening %d: error guardando progreso: %v", job.ID, err)
+	}
+}
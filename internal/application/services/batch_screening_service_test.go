@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockBatchScreeningRepository para testing
+type MockBatchScreeningRepository struct {
+	mu   sync.Mutex
+	jobs []*domain.BatchScreeningJob
+	rows []*domain.BatchScreeningRow
+}
+
+func (m *MockBatchScreeningRepository) CreateJob(ctx context.Context, job *domain.BatchScreeningJob, rows []*domain.BatchScreeningRow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job.ID = uint(len(m.jobs) + 1)
+	stored := *job
+	m.jobs = append(m.jobs, &stored)
+	for _, row := range rows {
+		row.ID = uint(len(m.rows) + 1)
+		row.JobID = job.ID
+		storedRow := *row
+		m.rows = append(m.rows, &storedRow)
+	}
+	return nil
+}
+
+func (m *MockBatchScreeningRepository) UpdateJob(ctx context.Context, job *domain.BatchScreeningJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := *job
+	m.jobs[job.ID-1] = &stored
+	return nil
+}
+
+func (m *MockBatchScreeningRepository) GetJob(ctx context.Context, id uint) (*domain.BatchScreeningJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id == 0 || int(id) > len(m.jobs) {
+		return nil, nil
+	}
+	job := *m.jobs[id-1]
+	return &job, nil
+}
+
+func (m *MockBatchScreeningRepository) ListUnfinishedJobs(ctx context.Context) ([]*domain.BatchScreeningJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []*domain.BatchScreeningJob
+	for _, job := range m.jobs {
+		if job.Status == domain.BatchScreeningStatusRunning {
+			copied := *job
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockBatchScreeningRepository) UpdateRow(ctx context.Context, row *domain.BatchScreeningRow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := *row
+	m.rows[row.ID-1] = &stored
+	return nil
+}
+
+func (m *MockBatchScreeningRepository) ListRows(ctx context.Context, jobID uint) ([]*domain.BatchScreeningRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []*domain.BatchScreeningRow
+	for _, row := range m.rows {
+		if row.JobID == jobID {
+			copied := *row
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+// ConcurrentPLDService registra la máxima concurrencia observada
+type ConcurrentPLDService struct {
+	ListPLDService
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (m *ConcurrentPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	m.mu.Lock()
+	m.inFlight++
+	m.peak = max(m.peak, m.inFlight)
+	response, err := m.ListPLDService.ValidateUser(ctx, request)
+	m.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+	return response, err
+}
+
+// waitForBatch espera a que el lote termine
+func waitForBatch(t *testing.T, service *BatchScreeningService, id uint) (*domain.BatchScreeningJob, []*domain.BatchScreeningRow) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, rows, err := service.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if job.Status == domain.BatchScreeningStatusCompleted {
+			return job, rows
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Timeout esperando el lote")
+	return nil, nil
+}
+
+func batchRows(idNumbers ...string) []*domain.BatchScreeningRow {
+	rows := make([]*domain.BatchScreeningRow, 0, len(idNumbers))
+	for _, idNumber := range idNumbers {
+		rows = append(rows, &domain.BatchScreeningRow{IDNumber: idNumber, Name: "Prospecto " + idNumber, Email: idNumber + "@email.com"})
+	}
+	return rows
+}
+
+func TestBatchScreeningService_Submit_ScreensAllRows(t *testing.T) {
+	pldService := &ConcurrentPLDService{ListPLDService: ListPLDService{
+		blacklisted: map[string]bool{"222": true},
+		failing:     map[string]bool{"444": true},
+	}}
+	screeningRepo := NewMockScreeningRepository()
+	repo := &MockBatchScreeningRepository{}
+	service := NewBatchScreeningService(pldService, screeningRepo, repo, BatchScreeningConfig{Concurrency: 2, MaxRows: 10})
+
+	rows := batchRows("111", "222", "333", "444", "555", "")
+	job, err := service.Submit(context.Background(), rows, domain.BatchScreeningSourceJSON, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if job.Status != domain.BatchScreeningStatusRunning || job.Total != 6 || job.RequestedBy != 7 {
+		t.Errorf("Unexpected job %+v", job)
+	}
+
+	job, results := waitForBatch(t, service, job.ID)
+	if job.Processed != 6 || job.Clean != 3 || job.Blacklisted != 1 || job.Failed != 2 || job.FinishedAt == nil {
+		t.Errorf("Unexpected counters %+v", job)
+	}
+
+	expected := []string{"clean", "blacklisted", "clean", "error", "clean", "error"}
+	for i, row := range results {
+		if row.Line != i+1 || row.Status != expected[i] {
+			t.Errorf("Expected line %d to be %s, got %+v", i+1, expected[i], row)
+		}
+	}
+	if results[1].Reason != "Sanción reciente" || results[1].ScreeningID == nil {
+		t.Errorf("Expected blacklisted row linked to its screening, got %+v", results[1])
+	}
+	if !strings.Contains(results[5].Error, "obligatorios") {
+		t.Errorf("Expected invalid row error, got %+v", results[5])
+	}
+	if len(screeningRepo.screenings) != 5 {
+		t.Errorf("Expected 5 screenings in the audit trail, got %d", len(screeningRepo.screenings))
+	}
+	if pldService.peak > 2 {
+		t.Errorf("Expected at most 2 concurrent validations, got %d", pldService.peak)
+	}
+}
+
+func TestBatchScreeningService_Submit_Validation(t *testing.T) {
+	service := NewBatchScreeningService(&ListPLDService{}, NewMockScreeningRepository(), &MockBatchScreeningRepository{}, BatchScreeningConfig{MaxRows: 2})
+
+	if _, err := service.Submit(context.Background(), nil, domain.BatchScreeningSourceCSV, 1); err == nil || err.Error() != "el lote no contiene filas" {
+		t.Errorf("Expected empty batch error, got %v", err)
+	}
+	if _, err := service.Submit(context.Background(), batchRows("1", "2", "3"), domain.BatchScreeningSourceCSV, 1); err == nil || !strings.HasPrefix(err.Error(), "el lote excede") {
+		t.Errorf("Expected max rows error, got %v", err)
+	}
+	if _, _, err := service.Get(context.Background(), 99); err == nil || err.Error() != "lote de screening no encontrado" {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestBatchScreeningService_ResumeUnfinished(t *testing.T) {
+	repo := &MockBatchScreeningRepository{}
+	rows := batchRows("111", "222")
+	rows[0].Status = domain.ScreeningStatusClean
+	rows[1].Status = domain.BatchRowStatusPending
+	repo.CreateJob(context.Background(), &domain.BatchScreeningJob{Status: domain.BatchScreeningStatusRunning, Total: 2, Processed: 1, Clean: 1}, rows)
+
+	pldService := &ListPLDService{}
+	service := NewBatchScreeningService(pldService, NewMockScreeningRepository(), repo, DefaultBatchScreeningConfig())
+	service.ResumeUnfinished(context.Background())
+
+	job, _ := waitForBatch(t, service, 1)
+	if job.Processed != 2 || job.Clean != 2 {
+		t.Errorf("Unexpected counters after resume %+v", job)
+	}
+	if len(pldService.calls) != 1 || pldService.calls[0] != "222" {
+		t.Errorf("Expected only the pending row to be screened, got %v", pldService.calls)
+	}
+}
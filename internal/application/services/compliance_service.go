@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+)
+
+// ComplianceService implementa las consultas del oficial de cumplimiento
+type ComplianceService struct {
+	rejectedRepo ports.RejectedApplicationRepository
+	timeouts     Timeouts
+}
+
+// NewComplianceService crea una nueva instancia del servicio de cumplimiento
+func NewComplianceService(rejectedRepo ports.RejectedApplicationRepository) *ComplianceService {
+	return &ComplianceService{
+		rejectedRepo: rejectedRepo,
+		timeouts:     TimeoutsFromEnv(),
+	}
+}
+
+// ListRejectedApplications obtiene las solicitudes de alta rechazadas en un rango de fechas
+func (s *ComplianceService) ListRejectedApplications(ctx context.Context, filter domain.RejectedApplicationFilter) ([]*domain.RejectedApplication, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, domain.NewError(domain.ErrInvalidInput, "rango de fechas inválido")
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.rejectedRepo.List(dbCtx, filter)
+}
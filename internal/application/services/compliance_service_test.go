@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestComplianceService_ListRejectedApplications_FiltersByDate(t *testing.T) {
+	rejectedRepo := NewMockRejectedApplicationRepository()
+	complianceService := NewComplianceService(rejectedRepo)
+
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		rejectedRepo.Create(context.Background(), &domain.RejectedApplication{
+			Email:     "juan@email.com",
+			CreatedAt: base.AddDate(0, 0, i),
+		})
+	}
+
+	applications, err := complianceService.ListRejectedApplications(context.Background(), domain.RejectedApplicationFilter{
+		From: base.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(applications) != 2 {
+		t.Errorf("Expected 2 applications, got %d", len(applications))
+	}
+}
+
+func TestComplianceService_ListRejectedApplications_InvalidRange(t *testing.T) {
+	complianceService := NewComplianceService(NewMockRejectedApplicationRepository())
+
+	now := time.Now()
+	_, err := complianceService.ListRejectedApplications(context.Background(), domain.RejectedApplicationFilter{
+		From: now,
+		To:   now.Add(-time.Hour),
+	})
+	if err == nil {
+		t.Error("Expected error for inverted date range")
+	}
+}
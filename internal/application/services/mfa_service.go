@@ -0,0 +1,578 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"crabi-test/pkg/totp"
+	"crypto/rand"
+	"encoding/base32"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// errInvalidMFAChallenge se retorna para cualquier desafío que no se pueda usar, sin
+	// distinguir si no existe, venció, ya se completó o agotó sus intentos
+	errInvalidMFAChallenge = domain.NewError(domain.ErrInvalidToken, "desafío MFA inválido o vencido")
+	// errInvalidMFACode se retorna para un código TOTP o de recuperación incorrecto o ya usado
+	errInvalidMFACode = domain.NewError(domain.ErrInvalidCredentials, "código MFA inválido")
+	// errMFANotEnabled se retorna al operar sobre MFA de un usuario que no lo tiene activado
+	errMFANotEnabled = domain.NewError(domain.ErrConflict, "MFA no está activado")
+	// errNoPendingEnrollment se retorna al confirmar sin una inscripción pendiente
+	errNoPendingEnrollment = domain.NewError(domain.ErrConflict, "no hay una inscripción MFA pendiente")
+	// errMissingMFACode se retorna cuando no se envía un código TOTP ni uno de recuperación
+	errMissingMFACode = domain.NewError(domain.ErrInvalidInput, "se requiere un código MFA o un código de recuperación")
+	// errTooManyChallenges se retorna al superar los desafíos permitidos por usuario en la ventana
+	errTooManyChallenges = domain.NewError(domain.ErrTooManyAttempts, "demasiados inicios de sesión con MFA pendientes, intente de nuevo más tarde")
+)
+
+// mfaPolicyRoles son los roles a los que se les puede exigir MFA
+var mfaPolicyRoles = []string{domain.RoleUser, domain.RoleAdmin}
+
+// recoveryCodeEncoding codifica los códigos de recuperación con caracteres fáciles de transcribir
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAConfig define los parámetros de la autenticación multifactor
+type MFAConfig struct {
+	// Issuer es el nombre con el que la cuenta aparece en la aplicación autenticadora
+	Issuer string
+	// ChallengeTTL es el tiempo que tiene el usuario para completar el segundo paso del login
+	ChallengeTTL time.Duration
+	// MaxAttempts es la cantidad de códigos que admite un desafío
+	MaxAttempts int
+	// MaxChallenges es la cantidad de desafíos que se crean por usuario en ChallengeWindow.
+	// Junto con MaxAttempts limita los códigos que se pueden probar contra una cuenta
+	MaxChallenges int
+	// ChallengeWindow es la ventana en la que se cuentan los desafíos de cada usuario
+	ChallengeWindow time.Duration
+	// RecoveryCodes es la cantidad de códigos de recuperación que se generan
+	RecoveryCodes int
+}
+
+// DefaultMFAConfig retorna la configuración por defecto
+func DefaultMFAConfig() MFAConfig {
+	return MFAConfig{
+		Issuer:          "Crabi",
+		ChallengeTTL:    5 * time.Minute,
+		MaxAttempts:     5,
+		MaxChallenges:   10,
+		ChallengeWindow: 15 * time.Minute,
+		RecoveryCodes:   10,
+	}
+}
+
+// MFAConfigFromEnv obtiene la configuración de las variables de entorno, usando los valores
+// por defecto para las que no estén definidas
+func MFAConfigFromEnv() MFAConfig {
+	config := DefaultMFAConfig()
+
+	if v := os.Getenv("MFA_ISSUER"); v != "" {
+		config.Issuer = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("MFA_CHALLENGE_TTL")); err == nil && v > 0 {
+		config.ChallengeTTL = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("MFA_MAX_ATTEMPTS")); err == nil && v > 0 {
+		config.MaxAttempts = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("MFA_MAX_CHALLENGES")); err == nil && v > 0 {
+		config.MaxChallenges = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("MFA_CHALLENGE_WINDOW")); err == nil && v > 0 {
+		config.ChallengeWindow = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("MFA_RECOVERY_CODES")); err == nil && v > 0 {
+		config.RecoveryCodes = v
+	}
+
+	return config
+}
+
+// MFAService implementa la autenticación multifactor con TOTP: la inscripción del usuario,
+// los códigos de recuperación, el segundo paso del inicio de sesión y las políticas que
+// exigen MFA por rol. Las políticas se mantienen en memoria, por lo que asume una sola
+// instancia de la API por base de datos
+type MFAService struct {
+	userRepo      ports.UserRepository
+	mfaRepo       ports.MFARepository
+	challengeRepo ports.MFAChallengeRepository
+	policyRepo    ports.MFAPolicyRepository
+	// authService y refreshTokenService cierran las sesiones abiertas solo con contraseña al
+	// activar MFA
+	authService         *AuthService
+	refreshTokenService *RefreshTokenService
+	config              MFAConfig
+	timeouts            Timeouts
+	now                 func() time.Time
+
+	mu       sync.RWMutex
+	policies map[string]domain.MFARolePolicy
+}
+
+// NewMFAService crea una nueva instancia del servicio de MFA
+func NewMFAService(userRepo ports.UserRepository, mfaRepo ports.MFARepository, challengeRepo ports.MFAChallengeRepository, policyRepo ports.MFAPolicyRepository, authService *AuthService, refreshTokenService *RefreshTokenService, config MFAConfig) *MFAService {
+	return &MFAService{
+		userRepo:            userRepo,
+		mfaRepo:             mfaRepo,
+		challengeRepo:       challengeRepo,
+		policyRepo:          policyRepo,
+		authService:         authService,
+		refreshTokenService: refreshTokenService,
+		config:              config,
+		timeouts:            TimeoutsFromEnv(),
+		now:                 time.Now,
+	}
+}
+
+// Status retorna el estado de MFA del usuario
+func (s *MFAService) Status(ctx context.Context, user *domain.User) (*domain.MFAStatus, error) {
+	enrollment, err := s.getEnrollment(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.IsRequired(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.MFAStatus{Required: required}
+	if enrollment == nil {
+		return status, nil
+	}
+	if !enrollment.Confirmed() {
+		status.Pending = true
+		return status, nil
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	remaining, err := s.mfaRepo.CountRecoveryCodes(dbCtx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status.Enabled = true
+	status.ConfirmedAt = enrollment.ConfirmedAt
+	status.RecoveryCodesRemaining = remaining
+	return status, nil
+}
+
+// IsEnabled indica si el usuario tiene MFA activado, es decir, si debe completar el segundo
+// paso al iniciar sesión
+func (s *MFAService) IsEnabled(ctx context.Context, userID uint) (bool, error) {
+	enrollment, err := s.getEnrollment(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return enrollment != nil && enrollment.Confirmed(), nil
+}
+
+// StartEnrollment genera un secreto TOTP para el usuario y retorna el URI otpauth:// para
+// mostrarlo como código QR. La inscripción queda pendiente hasta confirmarla con un código;
+// iniciarla de nuevo reemplaza el secreto pendiente
+func (s *MFAService) StartEnrollment(ctx context.Context, user *domain.User) (*domain.MFASetup, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	saved, err := s.mfaRepo.SaveEnrollment(dbCtx, &domain.MFAEnrollment{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, domain.NewError(domain.ErrConflict, "MFA ya está activado")
+	}
+
+	return &domain.MFASetup{
+		Secret: secret,
+		URI:    totp.URI(s.config.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment activa MFA con un código generado con el secreto pendiente y retorna los
+// códigos de recuperación en claro. Es la única vez que se pueden consultar
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID uint, code string) ([]string, error) {
+	enrollment, err := s.getEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil || enrollment.Confirmed() {
+		return nil, errNoPendingEnrollment
+	}
+
+	step, ok := totp.Verify(enrollment.Secret, code, s.now())
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	confirmed, err := s.mfaRepo.ConfirmEnrollment(dbCtx, userID, step, s.now(), hashes)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return nil, errNoPendingEnrollment
+	}
+
+	// Las sesiones vigentes se abrieron sin segundo factor: se cierran todas para que el
+	// usuario vuelva a iniciar sesión con MFA
+	if err := s.refreshTokenService.RevokeAll(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.authService.RevokeAllTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("MFA activado para el usuario %d", userID)
+	return codes, nil
+}
+
+// Disable desactiva MFA con un código TOTP o de recuperación, que permite desactivarlo tras
+// perder el dispositivo. No es posible si el rol del usuario exige MFA
+func (s *MFAService) Disable(ctx context.Context, user *domain.User, code, recoveryCode string) error {
+	required, err := s.IsRequired(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domain.NewError(domain.ErrForbidden, "MFA es obligatorio para el rol del usuario")
+	}
+
+	enrollment, err := s.confirmedEnrollment(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifySecondFactor(ctx, enrollment, code, recoveryCode); err != nil {
+		return err
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	if err := s.mfaRepo.DeleteEnrollment(dbCtx, user.ID); err != nil {
+		return err
+	}
+
+	log.Printf("MFA desactivado para el usuario %d", user.ID)
+	return nil
+}
+
+// RegenerateRecoveryCodes reemplaza los códigos de recuperación del usuario, verificando un
+// código TOTP, y retorna los nuevos en claro
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	enrollment, err := s.confirmedEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyTOTP(ctx, enrollment, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	if err := s.mfaRepo.ReplaceRecoveryCodes(dbCtx, userID, hashes, s.now()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// CreateChallenge abre el segundo paso del inicio de sesión de un usuario que ya verificó su
+// contraseña. Retorna el token del desafío, que el cliente envía junto con el código
+func (s *MFAService) CreateChallenge(ctx context.Context, userID uint) (string, time.Time, error) {
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := s.now()
+	challenge := &domain.MFAChallenge{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(s.config.ChallengeTTL),
+		CreatedAt: now,
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	created, err := s.challengeRepo.CreateWithinLimit(dbCtx, challenge, now.Add(-s.config.ChallengeWindow), s.config.MaxChallenges)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !created {
+		log.Printf("el usuario %d superó el límite de desafíos MFA", userID)
+		return "", time.Time{}, errTooManyChallenges
+	}
+	return token, challenge.ExpiresAt, nil
+}
+
+// VerifyChallenge completa el inicio de sesión con un código TOTP o de recuperación y retorna
+// el usuario. Cada código consume un intento del desafío antes de verificarse, de modo que
+// solicitudes simultáneas no superen MaxAttempts, y el desafío se puede completar una sola vez
+func (s *MFAService) VerifyChallenge(ctx context.Context, challengeToken, code, recoveryCode string) (*domain.User, error) {
+	if code == "" && recoveryCode == "" {
+		return nil, errMissingMFACode
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	challenge, err := s.challengeRepo.GetByTokenHash(dbCtx, hashOpaqueToken(challengeToken))
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || !challenge.Usable(s.now(), s.config.MaxAttempts) {
+		return nil, errInvalidMFAChallenge
+	}
+
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	reserved, err := s.challengeRepo.ReserveAttempt(dbCtx, challenge.ID, s.config.MaxAttempts, s.now())
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, errInvalidMFAChallenge
+	}
+
+	enrollment, err := s.getEnrollment(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil || !enrollment.Confirmed() {
+		return nil, errInvalidMFAChallenge
+	}
+
+	if err := s.verifySecondFactor(ctx, enrollment, code, recoveryCode); err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	completed, err := s.challengeRepo.MarkCompleted(dbCtx, challenge.ID, s.now())
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		return nil, errInvalidMFAChallenge
+	}
+
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	user, err := s.userRepo.GetByID(dbCtx, challenge.UserID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.NewError(domain.ErrInvalidToken, "usuario no encontrado")
+	}
+	if err := inactiveUserError(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// IsRequired indica si el rol exige MFA
+func (s *MFAService) IsRequired(ctx context.Context, role string) (bool, error) {
+	policies, err := s.loadPolicies(ctx)
+	if err != nil {
+		return false, err
+	}
+	return policies[role].Required, nil
+}
+
+// ListPolicies retorna la política de cada rol. Los roles sin política configurada no exigen MFA
+func (s *MFAService) ListPolicies(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	policies, err := s.loadPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.MFARolePolicy, 0, len(mfaPolicyRoles))
+	for _, role := range mfaPolicyRoles {
+		policy, ok := policies[role]
+		if !ok {
+			policy = domain.MFARolePolicy{Role: role}
+		}
+		result = append(result, policy)
+	}
+	return result, nil
+}
+
+// SetPolicy configura si el rol exige MFA. Los usuarios del rol sin MFA activado solo pueden
+// usar los endpoints para activarlo hasta que lo hagan
+func (s *MFAService) SetPolicy(ctx context.Context, role string, required bool, updatedBy uint) (*domain.MFARolePolicy, error) {
+	if !isMFAPolicyRole(role) {
+		return nil, domain.Errorf(domain.ErrInvalidInput, "rol inválido: %s", role)
+	}
+
+	policy := &domain.MFARolePolicy{
+		Role:      role,
+		Required:  required,
+		UpdatedBy: updatedBy,
+		UpdatedAt: s.now(),
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	if err := s.policyRepo.Save(dbCtx, policy); err != nil {
+		return nil, err
+	}
+
+	// Las políticas se vuelven a cargar en la próxima consulta
+	s.mu.Lock()
+	s.policies = nil
+	s.mu.Unlock()
+
+	log.Printf("política MFA del rol %s actualizada por el usuario %d: obligatorio=%t", role, updatedBy, required)
+	return policy, nil
+}
+
+// loadPolicies retorna las políticas por rol, cargándolas de la base de datos si no están en
+// memoria
+func (s *MFAService) loadPolicies(ctx context.Context) (map[string]domain.MFARolePolicy, error) {
+	s.mu.RLock()
+	policies := s.policies
+	s.mu.RUnlock()
+	if policies != nil {
+		return policies, nil
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	list, err := s.policyRepo.List(dbCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies = make(map[string]domain.MFARolePolicy, len(list))
+	for _, policy := range list {
+		policies[policy.Role] = policy
+	}
+
+	s.mu.Lock()
+	s.policies = policies
+	s.mu.Unlock()
+	return policies, nil
+}
+
+// getEnrollment obtiene la inscripción del usuario, o nil si no tiene
+func (s *MFAService) getEnrollment(ctx context.Context, userID uint) (*domain.MFAEnrollment, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.mfaRepo.GetEnrollment(dbCtx, userID)
+}
+
+// confirmedEnrollment obtiene la inscripción confirmada del usuario, o errMFANotEnabled
+func (s *MFAService) confirmedEnrollment(ctx context.Context, userID uint) (*domain.MFAEnrollment, error) {
+	enrollment, err := s.getEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil || !enrollment.Confirmed() {
+		return nil, errMFANotEnabled
+	}
+	return enrollment, nil
+}
+
+// verifySecondFactor verifica un código TOTP o, si no se envía, un código de recuperación
+func (s *MFAService) verifySecondFactor(ctx context.Context, enrollment *domain.MFAEnrollment, code, recoveryCode string) error {
+	switch {
+	case code != "":
+		return s.verifyTOTP(ctx, enrollment, code)
+	case recoveryCode != "":
+		return s.useRecoveryCode(ctx, enrollment.UserID, recoveryCode)
+	default:
+		return errMissingMFACode
+	}
+}
+
+// verifyTOTP verifica un código TOTP y registra su paso para que no se pueda reutilizar
+func (s *MFAService) verifyTOTP(ctx context.Context, enrollment *domain.MFAEnrollment, code string) error {
+	step, ok := totp.Verify(enrollment.Secret, code, s.now())
+	if !ok || step <= enrollment.LastUsedStep {
+		return errInvalidMFACode
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	used, err := s.mfaRepo.MarkStepUsed(dbCtx, enrollment.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errInvalidMFACode
+	}
+	return nil
+}
+
+// useRecoveryCode consume un código de recuperación del usuario
+func (s *MFAService) useRecoveryCode(ctx context.Context, userID uint, recoveryCode string) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	used, err := s.mfaRepo.UseRecoveryCode(dbCtx, userID, hashRecoveryCode(recoveryCode), s.now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errInvalidMFACode
+	}
+
+	log.Printf("el usuario %d usó un código de recuperación MFA", userID)
+	return nil
+}
+
+// newRecoveryCodes genera los códigos de recuperación en claro y sus hashes. Cada código tiene
+// 50 bits aleatorios, por lo que se almacena con SHA-256 como los tokens opacos
+func (s *MFAService) newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, s.config.RecoveryCodes)
+	hashes := make([]string, s.config.RecoveryCodes)
+	for i := range codes {
+		buf := make([]byte, 10)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf)[:10])
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode obtiene el hash de un código de recuperación, sin distinguir mayúsculas,
+// guiones ni espacios
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashOpaqueToken(normalized)
+}
+
+// isMFAPolicyRole indica si se puede configurar una política MFA para el rol
+func isMFAPolicyRole(role string) bool {
+	for _, r := range mfaPolicyRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,544 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"crabi-test/pkg/totp"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// MockMFARepository para testing
+type MockMFARepository struct {
+	mu            sync.Mutex
+	enrollments   map[uint]*domain.MFAEnrollment
+	recoveryCodes map[uint]map[string]bool
+}
+
+func NewMockMFARepository() *MockMFARepository {
+	return &MockMFARepository{
+		enrollments:   make(map[uint]*domain.MFAEnrollment),
+		recoveryCodes: make(map[uint]map[string]bool),
+	}
+}
+
+func (m *MockMFARepository) GetEnrollment(ctx context.Context, userID uint) (*domain.MFAEnrollment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if enrollment, exists := m.enrollments[userID]; exists {
+		found := *enrollment
+		return &found, nil
+	}
+	return nil, nil
+}
+
+func (m *MockMFARepository) SaveEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, exists := m.enrollments[enrollment.UserID]; exists && existing.Confirmed() {
+		return false, nil
+	}
+	saved := *enrollment
+	m.enrollments[enrollment.UserID] = &saved
+	return true, nil
+}
+
+func (m *MockMFARepository) ConfirmEnrollment(ctx context.Context, userID uint, step int64, confirmedAt time.Time, codeHashes []string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	enrollment, exists := m.enrollments[userID]
+	if !exists || enrollment.Confirmed() || enrollment.LastUsedStep >= step {
+		return false, nil
+	}
+	enrollment.ConfirmedAt = &confirmedAt
+	enrollment.LastUsedStep = step
+	m.replaceRecoveryCodes(userID, codeHashes)
+	return true, nil
+}
+
+func (m *MockMFARepository) DeleteEnrollment(ctx context.Context, userID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.enrollments, userID)
+	delete(m.recoveryCodes, userID)
+	return nil
+}
+
+func (m *MockMFARepository) MarkStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	enrollment, exists := m.enrollments[userID]
+	if !exists || enrollment.LastUsedStep >= step {
+		return false, nil
+	}
+	enrollment.LastUsedStep = step
+	return true, nil
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string, createdAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.replaceRecoveryCodes(userID, codeHashes)
+	return nil
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if unused, exists := m.recoveryCodes[userID][codeHash]; !exists || !unused {
+		return false, nil
+	}
+	m.recoveryCodes[userID][codeHash] = false
+	return true, nil
+}
+
+func (m *MockMFARepository) CountRecoveryCodes(ctx context.Context, userID uint) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, unused := range m.recoveryCodes[userID] {
+		if unused {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockMFARepository) replaceRecoveryCodes(userID uint, codeHashes []string) {
+	m.recoveryCodes[userID] = make(map[string]bool, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		m.recoveryCodes[userID][codeHash] = true
+	}
+}
+
+// MockMFAChallengeRepository para testing
+type MockMFAChallengeRepository struct {
+	mu         sync.Mutex
+	challenges []*domain.MFAChallenge
+}
+
+func (m *MockMFAChallengeRepository) CreateWithinLimit(ctx context.Context, challenge *domain.MFAChallenge, since time.Time, maxChallenges int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, existing := range m.challenges {
+		if existing.UserID == challenge.UserID && existing.CreatedAt.After(since) {
+			count++
+		}
+	}
+	if count >= maxChallenges {
+		return false, nil
+	}
+
+	challenge.ID = uint(len(m.challenges) + 1)
+	m.challenges = append(m.challenges, challenge)
+	return true, nil
+}
+
+func (m *MockMFAChallengeRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, challenge := range m.challenges {
+		if challenge.TokenHash == tokenHash {
+			found := *challenge
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockMFAChallengeRepository) ReserveAttempt(ctx context.Context, id uint, maxAttempts int, now time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, challenge := range m.challenges {
+		if challenge.ID == id && challenge.Attempts < maxAttempts && challenge.CompletedAt == nil && now.Before(challenge.ExpiresAt) {
+			challenge.Attempts++
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockMFAChallengeRepository) MarkCompleted(ctx context.Context, id uint, completedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, challenge := range m.challenges {
+		if challenge.ID == id && challenge.CompletedAt == nil {
+			challenge.CompletedAt = &completedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MockMFAPolicyRepository para testing
+type MockMFAPolicyRepository struct {
+	policies map[string]domain.MFARolePolicy
+	lists    int
+}
+
+func (m *MockMFAPolicyRepository) List(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	m.lists++
+	var policies []domain.MFARolePolicy
+	for _, policy := range m.policies {
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func (m *MockMFAPolicyRepository) Save(ctx context.Context, policy *domain.MFARolePolicy) error {
+	if m.policies == nil {
+		m.policies = make(map[string]domain.MFARolePolicy)
+	}
+	m.policies[policy.Role] = *policy
+	return nil
+}
+
+// testClock es un reloj manual para generar códigos TOTP de distintos pasos
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// nextStep avanza el reloj al siguiente paso TOTP
+func (c *testClock) nextStep() {
+	c.now = c.now.Add(totp.Period)
+}
+
+func newTestMFAService(t *testing.T) (*MFAService, *domain.User, *testClock) {
+	t.Helper()
+	userRepo := NewMockUserRepository()
+	user := &domain.User{Name: "Juan Pérez", Email: "juan@email.com", IDNumber: "12345678", Role: domain.RoleUser, Status: domain.UserStatusActive}
+	if err := userRepo.Create(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+	refreshTokenService := NewRefreshTokenService(userRepo, &MockRefreshTokenRepository{}, authService, DefaultRefreshTokenConfig())
+	service := NewMFAService(userRepo, NewMockMFARepository(), &MockMFAChallengeRepository{}, &MockMFAPolicyRepository{}, authService, refreshTokenService, DefaultMFAConfig())
+	service.now = clock.Now
+	return service, user, clock
+}
+
+// enrollTestUser activa MFA para el usuario y retorna el secreto y los códigos de recuperación
+func enrollTestUser(t *testing.T, service *MFAService, user *domain.User, clock *testClock) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	setup, err := service.StartEnrollment(ctx, user)
+	if err != nil {
+		t.Fatalf("Expected no error starting enrollment, got %v", err)
+	}
+
+	code, _ := totp.Code(setup.Secret, clock.now)
+	recoveryCodes, err := service.ConfirmEnrollment(ctx, user.ID, code)
+	if err != nil {
+		t.Fatalf("Expected no error confirming enrollment, got %v", err)
+	}
+	return setup.Secret, recoveryCodes
+}
+
+func TestMFAService_Enrollment(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+
+	setup, err := service.StartEnrollment(ctx, user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(setup.URI, "otpauth://totp/Crabi:") || !strings.Contains(setup.URI, "secret="+setup.Secret) {
+		t.Errorf("Unexpected otpauth URI %s", setup.URI)
+	}
+	if enabled, _ := service.IsEnabled(ctx, user.ID); enabled {
+		t.Error("Expected MFA not to be enabled before confirming")
+	}
+
+	if _, err := service.ConfirmEnrollment(ctx, user.ID, "000000"); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("Expected ErrInvalidCredentials for a wrong code, got %v", err)
+	}
+
+	code, _ := totp.Code(setup.Secret, clock.now)
+	recoveryCodes, err := service.ConfirmEnrollment(ctx, user.ID, code)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(recoveryCodes) != DefaultMFAConfig().RecoveryCodes {
+		t.Errorf("Expected %d recovery codes, got %d", DefaultMFAConfig().RecoveryCodes, len(recoveryCodes))
+	}
+
+	if _, err := service.ConfirmEnrollment(ctx, user.ID, code); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Expected ErrConflict confirming twice, got %v", err)
+	}
+	if _, err := service.StartEnrollment(ctx, user); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Expected ErrConflict enrolling an enabled user, got %v", err)
+	}
+
+	status, err := service.Status(ctx, user)
+	if err != nil || !status.Enabled || status.RecoveryCodesRemaining != len(recoveryCodes) {
+		t.Errorf("Expected enabled status with all recovery codes, got %+v (%v)", status, err)
+	}
+}
+
+func TestMFAService_VerifyChallenge(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+	secret, _ := enrollTestUser(t, service, user, clock)
+
+	challenge, _, err := service.CreateChallenge(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// El código con el que se confirmó la inscripción no se puede reutilizar
+	usedCode, _ := totp.Code(secret, clock.now)
+	if _, err := service.VerifyChallenge(ctx, challenge, usedCode, ""); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("Expected ErrInvalidCredentials for a used code, got %v", err)
+	}
+
+	clock.nextStep()
+	code, _ := totp.Code(secret, clock.now)
+	verified, err := service.VerifyChallenge(ctx, challenge, code, "")
+	if err != nil || verified.ID != user.ID {
+		t.Fatalf("Expected user %d, got %+v (%v)", user.ID, verified, err)
+	}
+
+	// Un desafío se completa una sola vez
+	clock.nextStep()
+	code, _ = totp.Code(secret, clock.now)
+	if _, err := service.VerifyChallenge(ctx, challenge, code, ""); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a completed challenge, got %v", err)
+	}
+	if _, err := service.VerifyChallenge(ctx, "desconocido", code, ""); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an unknown challenge, got %v", err)
+	}
+}
+
+func TestMFAService_RecoveryCodes(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+	secret, recoveryCodes := enrollTestUser(t, service, user, clock)
+
+	challenge, _, _ := service.CreateChallenge(ctx, user.ID)
+	// Los códigos de recuperación no distinguen mayúsculas ni guiones
+	recoveryCode := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	if _, err := service.VerifyChallenge(ctx, challenge, "", recoveryCode); err != nil {
+		t.Fatalf("Expected no error with a recovery code, got %v", err)
+	}
+
+	challenge, _, _ = service.CreateChallenge(ctx, user.ID)
+	if _, err := service.VerifyChallenge(ctx, challenge, "", recoveryCodes[0]); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for a used recovery code, got %v", err)
+	}
+	if _, err := service.VerifyChallenge(ctx, challenge, "", ""); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a code, got %v", err)
+	}
+
+	clock.nextStep()
+	code, _ := totp.Code(secret, clock.now)
+	regenerated, err := service.RegenerateRecoveryCodes(ctx, user.ID, code)
+	if err != nil || len(regenerated) != len(recoveryCodes) {
+		t.Fatalf("Expected new recovery codes, got %v (%v)", regenerated, err)
+	}
+	if _, err := service.VerifyChallenge(ctx, challenge, "", recoveryCodes[1]); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("Expected replaced recovery code to be rejected, got %v", err)
+	}
+	if _, err := service.VerifyChallenge(ctx, challenge, "", regenerated[0]); err != nil {
+		t.Errorf("Expected no error with a regenerated code, got %v", err)
+	}
+}
+
+func TestMFAService_ChallengeAttempts(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+	secret, _ := enrollTestUser(t, service, user, clock)
+
+	challenge, _, _ := service.CreateChallenge(ctx, user.ID)
+	for i := 0; i < DefaultMFAConfig().MaxAttempts; i++ {
+		if _, err := service.VerifyChallenge(ctx, challenge, "000000", ""); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("Expected ErrInvalidCredentials on attempt %d, got %v", i+1, err)
+		}
+	}
+
+	// Agotados los intentos, ni un código correcto completa el desafío
+	clock.nextStep()
+	code, _ := totp.Code(secret, clock.now)
+	if _, err := service.VerifyChallenge(ctx, challenge, code, ""); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken after too many attempts, got %v", err)
+	}
+
+	expired, _, _ := service.CreateChallenge(ctx, user.ID)
+	clock.now = clock.now.Add(DefaultMFAConfig().ChallengeTTL)
+	code, _ = totp.Code(secret, clock.now)
+	if _, err := service.VerifyChallenge(ctx, expired, code, ""); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an expired challenge, got %v", err)
+	}
+}
+
+func TestMFAService_ConcurrentAttempts(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+	enrollTestUser(t, service, user, clock)
+
+	challenge, _, _ := service.CreateChallenge(ctx, user.ID)
+
+	// Las solicitudes simultáneas reservan su intento antes de verificar el código, por lo que
+	// solo MaxAttempts llegan a verificarlo
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	verified := 0
+	for i := 0; i < 4*DefaultMFAConfig().MaxAttempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.VerifyChallenge(ctx, challenge, "000000", ""); errors.Is(err, domain.ErrInvalidCredentials) {
+				mu.Lock()
+				verified++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if verified != DefaultMFAConfig().MaxAttempts {
+		t.Errorf("Expected %d verified attempts, got %d", DefaultMFAConfig().MaxAttempts, verified)
+	}
+}
+
+func TestMFAService_ConfirmEnrollment_RevokesSessions(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+
+	// Sesión abierta solo con contraseña antes de activar MFA
+	token, err := service.authService.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Expected no error generating token, got %v", err)
+	}
+	refreshToken, _, err := service.refreshTokenService.IssueRefreshToken(ctx, user.ID, false)
+	if err != nil {
+		t.Fatalf("Expected no error issuing refresh token, got %v", err)
+	}
+
+	enrollTestUser(t, service, user, clock)
+
+	if _, err := service.authService.ValidateToken(ctx, token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected the access token to be revoked, got %v", err)
+	}
+	if _, _, err := service.refreshTokenService.Refresh(ctx, refreshToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected the refresh token to be revoked, got %v", err)
+	}
+
+	// Las sesiones abiertas después, con el segundo factor, son válidas
+	mfaToken, _ := service.authService.GenerateMFAToken(user)
+	if _, err := service.authService.ValidateToken(ctx, mfaToken); err != nil {
+		t.Errorf("Expected no error for a token issued after enabling MFA, got %v", err)
+	}
+}
+
+func TestMFAService_ChallengeThrottling(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+	enrollTestUser(t, service, user, clock)
+
+	config := DefaultMFAConfig()
+	for i := 0; i < config.MaxChallenges; i++ {
+		if _, _, err := service.CreateChallenge(ctx, user.ID); err != nil {
+			t.Fatalf("Expected no error on challenge %d, got %v", i+1, err)
+		}
+	}
+	if _, _, err := service.CreateChallenge(ctx, user.ID); !errors.Is(err, domain.ErrTooManyAttempts) {
+		t.Errorf("Expected ErrTooManyAttempts after %d challenges, got %v", config.MaxChallenges, err)
+	}
+
+	clock.now = clock.now.Add(config.ChallengeWindow)
+	if _, _, err := service.CreateChallenge(ctx, user.ID); err != nil {
+		t.Errorf("Expected no error after the window, got %v", err)
+	}
+}
+
+func TestMFAService_ConcurrentChallenges(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	enrollTestUser(t, service, user, clock)
+
+	// Inicios de sesión simultáneos no superan el límite de desafíos
+	config := DefaultMFAConfig()
+	var created atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2*config.MaxChallenges; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := service.CreateChallenge(context.Background(), user.ID); err == nil {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if int(created.Load()) != config.MaxChallenges {
+		t.Errorf("Expected %d challenges, got %d", config.MaxChallenges, created.Load())
+	}
+}
+
+func TestMFAService_RolePolicies(t *testing.T) {
+	service, user, clock := newTestMFAService(t)
+	ctx := context.Background()
+	_, recoveryCodes := enrollTestUser(t, service, user, clock)
+	policyRepo := service.policyRepo.(*MockMFAPolicyRepository)
+
+	if _, err := service.SetPolicy(ctx, "superusuario", true, 99); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for an unknown role, got %v", err)
+	}
+	if _, err := service.SetPolicy(ctx, domain.RoleUser, true, 99); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if required, err := service.IsRequired(ctx, domain.RoleUser); err != nil || !required {
+		t.Errorf("Expected MFA to be required for users, got %t (%v)", required, err)
+	}
+	if required, _ := service.IsRequired(ctx, domain.RoleAdmin); required {
+		t.Error("Expected MFA not to be required for admins")
+	}
+	// Las políticas se consultan en memoria hasta que cambian
+	if policyRepo.lists != 1 {
+		t.Errorf("Expected policies to be loaded once, got %d", policyRepo.lists)
+	}
+
+	policies, err := service.ListPolicies(ctx)
+	if err != nil || len(policies) != 2 || !policies[0].Required || policies[0].UpdatedBy != 99 || policies[1].Required {
+		t.Errorf("Expected a policy for each role, got %+v (%v)", policies, err)
+	}
+
+	if err := service.Disable(ctx, user, "", recoveryCodes[0]); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden disabling required MFA, got %v", err)
+	}
+
+	if _, err := service.SetPolicy(ctx, domain.RoleUser, false, 99); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := service.Disable(ctx, user, "", recoveryCodes[0]); err != nil {
+		t.Fatalf("Expected no error disabling MFA, got %v", err)
+	}
+	if enabled, _ := service.IsEnabled(ctx, user.ID); enabled {
+		t.Error("Expected MFA to be disabled")
+	}
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// newOpaqueToken genera un token aleatorio para entregar al cliente y el hash con el que se
+// almacena
+func newOpaqueToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashOpaqueToken(token), nil
+}
+
+// hashOpaqueToken obtiene el hash con el que se almacena un token. SHA-256 es suficiente
+// porque el token es aleatorio y de alta entropía
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRandomID genera un identificador aleatorio, como el jti de un token de acceso o el de
+// una familia de refresh tokens
+func newRandomID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// errInvalidResetToken se retorna para cualquier token de restablecimiento que no se pueda
+// usar, sin distinguir si no existe, venció o ya se usó
+var errInvalidResetToken = domain.NewError(domain.ErrInvalidToken, "token de restablecimiento inválido o vencido")
+
+// PasswordResetConfig define la vigencia de los tokens de restablecimiento de contraseña
+type PasswordResetConfig struct {
+	TokenTTL time.Duration
+}
+
+// DefaultPasswordResetConfig retorna la configuración por defecto
+func DefaultPasswordResetConfig() PasswordResetConfig {
+	return PasswordResetConfig{TokenTTL: 30 * time.Minute}
+}
+
+// PasswordResetConfigFromEnv obtiene la configuración de las variables de entorno,
+// usando los valores por defecto para las que no estén definidas
+func PasswordResetConfigFromEnv() PasswordResetConfig {
+	config := DefaultPasswordResetConfig()
+
+	if v, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TOKEN_TTL")); err == nil && v > 0 {
+		config.TokenTTL = v
+	}
+
+	return config
+}
+
+// PasswordService implementa el cambio y el restablecimiento de contraseñas. Al cambiar la
+// contraseña cierra todas las sesiones del usuario
+type PasswordService struct {
+	userRepo            ports.UserRepository
+	passwordWriter      ports.UserPasswordWriter
+	resetRepo           ports.PasswordResetRepository
+	notifier            ports.Notifier
+	authService         *AuthService
+	refreshTokenService *RefreshTokenService
+	config              PasswordResetConfig
+	timeouts            Timeouts
+}
+
+// NewPasswordService crea una nueva instancia del servicio de contraseñas
+func NewPasswordService(userRepo ports.UserRepository, passwordWriter ports.UserPasswordWriter, resetRepo ports.PasswordResetRepository, notifier ports.Notifier, authService *AuthService, refreshTokenService *RefreshTokenService, config PasswordResetConfig) *PasswordService {
+	return &PasswordService{
+		userRepo:            userRepo,
+		passwordWriter:      passwordWriter,
+		resetRepo:           resetRepo,
+		notifier:            notifier,
+		authService:         authService,
+		refreshTokenService: refreshTokenService,
+		config:              config,
+		timeouts:            TimeoutsFromEnv(),
+	}
+}
+
+// ChangePassword cambia la contraseña del usuario verificando la contraseña actual. También
+// se revocan sus sesiones, incluida la actual
+func (s *PasswordService) ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	user, err := s.userRepo.GetByID(dbCtx, userID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return domain.NewError(domain.ErrInvalidCredentials, "la contraseña actual es incorrecta")
+	}
+	if currentPassword == newPassword {
+		return domain.NewError(domain.ErrInvalidInput, "la nueva contraseña debe ser distinta de la actual")
+	}
+
+	return s.setPassword(ctx, user.ID, newPassword)
+}
+
+// RequestPasswordReset genera un token de restablecimiento y lo envía al usuario por el
+// notificador. No revela si el email está registrado: un email desconocido no es un error,
+// y una falla del notificador solo se registra en el log
+func (s *PasswordService) RequestPasswordReset(ctx context.Context, email string) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	user, err := s.userRepo.GetByEmail(dbCtx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	// Solo el token más reciente es válido
+	now := time.Now()
+	if err := s.resetRepo.InvalidateByUserID(dbCtx, user.ID, now); err != nil {
+		return err
+	}
+
+	resetToken := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(s.config.TokenTTL),
+		CreatedAt: now,
+	}
+	if err := s.resetRepo.Create(dbCtx, resetToken); err != nil {
+		return err
+	}
+
+	notice := domain.PasswordResetNotice{
+		UserID:    user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: resetToken.ExpiresAt,
+	}
+	if err := s.notifier.NotifyPasswordReset(ctx, notice); err != nil {
+		log.Printf("error enviando restablecimiento de contraseña al usuario %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword establece una nueva contraseña con un token de restablecimiento. El token
+// se puede usar una sola vez y deja sin efecto los demás tokens del usuario. Las sesiones
+// abiertas se revocan para que quien las haya robado pierda el acceso
+func (s *PasswordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	resetToken, err := s.resetRepo.GetByTokenHash(dbCtx, hashOpaqueToken(token))
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if resetToken == nil || !resetToken.Usable(now) {
+		return errInvalidResetToken
+	}
+
+	// Marcar el token antes de cambiar la contraseña impide usarlo dos veces en paralelo
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	used, err := s.resetRepo.MarkUsed(dbCtx, resetToken.ID, now)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errInvalidResetToken
+	}
+
+	if err := s.setPassword(ctx, resetToken.UserID, newPassword); err != nil {
+		return err
+	}
+
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	if err := s.resetRepo.InvalidateByUserID(dbCtx, resetToken.UserID, now); err != nil {
+		log.Printf("error invalidando tokens de restablecimiento del usuario %d: %v", resetToken.UserID, err)
+	}
+	return nil
+}
+
+// setPassword guarda el hash de la nueva contraseña y revoca los tokens de acceso y los
+// refresh tokens del usuario
+func (s *PasswordService) setPassword(ctx context.Context, userID uint, password string) error {
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	err = s.passwordWriter.UpdatePassword(dbCtx, userID, hashedPassword, time.Now())
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenService.RevokeAll(ctx, userID); err != nil {
+		return err
+	}
+	return s.authService.RevokeAllTokens(ctx, userID)
+}
+
+// hashPassword encripta una contraseña con bcrypt
+func hashPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.New("error encriptando contraseña")
+	}
+	return string(hashedPassword), nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string, updatedAt time.Time) error {
+	user, exists := m.users[id]
+	if !exists {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+	user.Password = passwordHash
+	user.UpdatedAt = updatedAt
+	return nil
+}
+
+// MockPasswordResetRepository para testing
+type MockPasswordResetRepository struct {
+	mu     sync.Mutex
+	tokens []*domain.PasswordResetToken
+}
+
+func (m *MockPasswordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token.ID = uint(len(m.tokens) + 1)
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *MockPasswordResetRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPasswordResetRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.ID == id && token.UsedAt == nil {
+			token.UsedAt = &usedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockPasswordResetRepository) InvalidateByUserID(ctx context.Context, userID uint, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+// RecordingNotifier guarda los avisos enviados
+type RecordingNotifier struct {
+	notices []domain.PasswordResetNotice
+	err     error
+}
+
+func (n *RecordingNotifier) NotifyPasswordReset(ctx context.Context, notice domain.PasswordResetNotice) error {
+	n.notices = append(n.notices, notice)
+	return n.err
+}
+
+func newTestPasswordService(t *testing.T) (*PasswordService, *MockUserRepository, *MockPasswordResetRepository, *RecordingNotifier) {
+	t.Helper()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user := &domain.User{Name: "Juan Pérez", Email: "juan@email.com", Password: "password123", IDNumber: "12345678"}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+
+	resetRepo := &MockPasswordResetRepository{}
+	notifier := &RecordingNotifier{}
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+	refreshTokenService := NewRefreshTokenService(userRepo, &MockRefreshTokenRepository{}, authService, DefaultRefreshTokenConfig())
+	return NewPasswordService(userRepo, userRepo, resetRepo, notifier, authService, refreshTokenService, DefaultPasswordResetConfig()), userRepo, resetRepo, notifier
+}
+
+// openSession inicia una sesión del usuario 1 y retorna su token de acceso y su refresh token
+func openSession(t *testing.T, passwordService *PasswordService, userRepo *MockUserRepository) (string, string) {
+	t.Helper()
+	user, _ := userRepo.GetByID(context.Background(), 1)
+	token, err := passwordService.authService.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Expected no error generating token, got %v", err)
+	}
+	refreshToken, _, err := passwordService.refreshTokenService.IssueRefreshToken(context.Background(), 1, false)
+	if err != nil {
+		t.Fatalf("Expected no error issuing refresh token, got %v", err)
+	}
+	return token, refreshToken
+}
+
+// assertSessionRevoked verifica que el token de acceso y el refresh token ya no se acepten
+func assertSessionRevoked(t *testing.T, passwordService *PasswordService, token, refreshToken string) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := passwordService.authService.ValidateToken(ctx, token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected the access token to be revoked, got %v", err)
+	}
+	if _, _, err := passwordService.refreshTokenService.Refresh(ctx, refreshToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected the refresh token to be revoked, got %v", err)
+	}
+}
+
+// assertPassword verifica la contraseña almacenada del usuario 1
+func assertPassword(t *testing.T, userRepo *MockUserRepository, password string) {
+	t.Helper()
+	user, _ := userRepo.GetByID(context.Background(), 1)
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		t.Errorf("Expected password %q to be stored", password)
+	}
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	passwordService, userRepo, _, _ := newTestPasswordService(t)
+	ctx := context.Background()
+
+	if err := passwordService.ChangePassword(ctx, 1, "incorrecta", "nueva-password"); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials, got %v", err)
+	}
+	if err := passwordService.ChangePassword(ctx, 1, "password123", "password123"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for the same password, got %v", err)
+	}
+	if err := passwordService.ChangePassword(ctx, 99, "password123", "nueva-password"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	token, refreshToken := openSession(t, passwordService, userRepo)
+	if err := passwordService.ChangePassword(ctx, 1, "password123", "nueva-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assertPassword(t, userRepo, "nueva-password")
+	assertSessionRevoked(t, passwordService, token, refreshToken)
+}
+
+func TestPasswordService_ResetFlow(t *testing.T) {
+	passwordService, userRepo, resetRepo, notifier := newTestPasswordService(t)
+	ctx := context.Background()
+
+	if err := passwordService.RequestPasswordReset(ctx, "juan@email.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notifier.notices) != 1 || notifier.notices[0].Email != "juan@email.com" || notifier.notices[0].Token == "" {
+		t.Fatalf("Expected a password reset notice, got %+v", notifier.notices)
+	}
+	token := notifier.notices[0].Token
+	if resetRepo.tokens[0].TokenHash == token {
+		t.Error("Expected the token to be stored hashed")
+	}
+
+	accessToken, refreshToken := openSession(t, passwordService, userRepo)
+	if err := passwordService.ResetPassword(ctx, token, "nueva-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assertPassword(t, userRepo, "nueva-password")
+	assertSessionRevoked(t, passwordService, accessToken, refreshToken)
+
+	// El token es de un solo uso
+	if err := passwordService.ResetPassword(ctx, token, "otra-password"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a used token, got %v", err)
+	}
+	if err := passwordService.ResetPassword(ctx, "desconocido", "otra-password"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an unknown token, got %v", err)
+	}
+	assertPassword(t, userRepo, "nueva-password")
+}
+
+func TestPasswordService_ResetTokenExpiresAndIsReplaced(t *testing.T) {
+	passwordService, _, resetRepo, notifier := newTestPasswordService(t)
+	ctx := context.Background()
+
+	passwordService.RequestPasswordReset(ctx, "juan@email.com")
+	passwordService.RequestPasswordReset(ctx, "juan@email.com")
+	if len(notifier.notices) != 2 {
+		t.Fatalf("Expected two notices, got %d", len(notifier.notices))
+	}
+
+	// Solo el token más reciente es válido
+	if err := passwordService.ResetPassword(ctx, notifier.notices[0].Token, "nueva-password"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a replaced token, got %v", err)
+	}
+
+	resetRepo.tokens[1].ExpiresAt = time.Now().Add(-time.Minute)
+	if err := passwordService.ResetPassword(ctx, notifier.notices[1].Token, "nueva-password"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an expired token, got %v", err)
+	}
+}
+
+func TestPasswordService_RequestPasswordResetDoesNotRevealEmails(t *testing.T) {
+	passwordService, _, resetRepo, notifier := newTestPasswordService(t)
+	ctx := context.Background()
+
+	if err := passwordService.RequestPasswordReset(ctx, "desconocido@email.com"); err != nil {
+		t.Errorf("Expected no error for an unknown email, got %v", err)
+	}
+	if len(notifier.notices) != 0 || len(resetRepo.tokens) != 0 {
+		t.Errorf("Expected no token for an unknown email, got %+v", resetRepo.tokens)
+	}
+
+	notifier.err = errors.New("smtp no disponible")
+	if err := passwordService.RequestPasswordReset(ctx, "juan@email.com"); err != nil {
+		t.Errorf("Expected notifier errors not to be exposed, got %v", err)
+	}
+}
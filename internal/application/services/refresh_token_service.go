@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"errors"
+	"log"
+	"os"
+	"time"
+)
+
+// errInvalidRefreshToken se retorna para cualquier refresh token que no se pueda usar, sin
+// distinguir si no existe, venció o fue revocado
+var errInvalidRefreshToken = domain.NewError(domain.ErrInvalidToken, "refresh token inválido o vencido")
+
+// RefreshTokenConfig define la vigencia de los refresh tokens
+type RefreshTokenConfig struct {
+	TTL time.Duration
+}
+
+// DefaultRefreshTokenConfig retorna la configuración por defecto
+func DefaultRefreshTokenConfig() RefreshTokenConfig {
+	return RefreshTokenConfig{TTL: 30 * 24 * time.Hour}
+}
+
+// RefreshTokenConfigFromEnv obtiene la configuración de las variables de entorno, usando los
+// valores por defecto para las que no estén definidas
+func RefreshTokenConfigFromEnv() RefreshTokenConfig {
+	config := DefaultRefreshTokenConfig()
+
+	if v, err := time.ParseDuration(os.Getenv("JWT_REFRESH_TOKEN_TTL")); err == nil && v > 0 {
+		config.TTL = v
+	}
+
+	return config
+}
+
+// RefreshTokenService emite y rota los refresh tokens con los que el cliente obtiene nuevos
+// tokens de acceso sin volver a enviar la contraseña
+type RefreshTokenService struct {
+	userRepo    ports.UserRepository
+	tokenRepo   ports.RefreshTokenRepository
+	authService *AuthService
+	config      RefreshTokenConfig
+	timeouts    Timeouts
+}
+
+// NewRefreshTokenService crea una nueva instancia del servicio de refresh tokens
+func NewRefreshTokenService(userRepo ports.UserRepository, tokenRepo ports.RefreshTokenRepository, authService *AuthService, config RefreshTokenConfig) *RefreshTokenService {
+	return &RefreshTokenService{
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		authService: authService,
+		config:      config,
+		timeouts:    TimeoutsFromEnv(),
+	}
+}
+
+// IssueRefreshToken emite el refresh token de un nuevo inicio de sesión, que abre una
+// familia de tokens. mfa indica si la sesión se inició con un segundo factor; los tokens de
+// acceso renovados con la familia lo conservan
+func (s *RefreshTokenService) IssueRefreshToken(ctx context.Context, userID uint, mfa bool) (string, time.Time, error) {
+	familyID, err := newRandomID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return s.issue(ctx, userID, familyID, mfa)
+}
+
+// Refresh rota un refresh token: lo marca como usado y emite un token de acceso y un
+// refresh token nuevos de la misma familia. Presentar un token ya rotado indica que fue
+// robado, por lo que se revoca toda la familia y el cliente debe iniciar sesión de nuevo
+func (s *RefreshTokenService) Refresh(ctx context.Context, refreshToken string) (*domain.User, *domain.TokenPair, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	token, err := s.tokenRepo.GetByTokenHash(dbCtx, hashOpaqueToken(refreshToken))
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case token == nil, token.RevokedAt != nil, !now.Before(token.ExpiresAt):
+		return nil, nil, errInvalidRefreshToken
+	case token.RotatedAt != nil:
+		return nil, nil, s.revokeReusedFamily(ctx, token)
+	}
+
+	// La condición de MarkRotated detecta también dos renovaciones simultáneas del mismo token
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	rotated, err := s.tokenRepo.MarkRotated(dbCtx, token.ID, now)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rotated {
+		return nil, nil, s.revokeReusedFamily(ctx, token)
+	}
+
+	dbCtx, cancel = withTimeout(ctx, s.timeouts.Database)
+	user, err := s.userRepo.GetByID(dbCtx, token.UserID)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, domain.NewError(domain.ErrInvalidToken, "usuario no encontrado")
+	}
+	if err := inactiveUserError(user); err != nil {
+		return nil, nil, err
+	}
+
+	generateToken := s.authService.GenerateToken
+	if token.MFA {
+		generateToken = s.authService.GenerateMFAToken
+	}
+	accessToken, err := generateToken(user)
+	if err != nil {
+		return nil, nil, errors.New("error generando token")
+	}
+
+	newRefreshToken, expiresAt, err := s.issue(ctx, user.ID, token.FamilyID, token.MFA)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, &domain.TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresIn:  s.authService.AccessTokenTTL(),
+		RefreshToken:          newRefreshToken,
+		RefreshTokenExpiresAt: expiresAt,
+	}, nil
+}
+
+// RevokeSession revoca la familia de un refresh token del usuario al cerrar la sesión. Los
+// tokens desconocidos o de otro usuario se ignoran
+func (s *RefreshTokenService) RevokeSession(ctx context.Context, userID uint, refreshToken string) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	token, err := s.tokenRepo.GetByTokenHash(dbCtx, hashOpaqueToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if token == nil || token.UserID != userID {
+		return nil
+	}
+	return s.tokenRepo.RevokeFamily(dbCtx, token.FamilyID, time.Now())
+}
+
+// RevokeAll revoca todos los refresh tokens del usuario al cerrar todas sus sesiones
+func (s *RefreshTokenService) RevokeAll(ctx context.Context, userID uint) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.tokenRepo.RevokeByUserID(dbCtx, userID, time.Now())
+}
+
+// issue emite un refresh token de la familia indicada
+func (s *RefreshTokenService) issue(ctx context.Context, userID uint, familyID string, mfa bool) (string, time.Time, error) {
+	token, tokenHash, err := newOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	refreshToken := &domain.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		MFA:       mfa,
+		ExpiresAt: now.Add(s.config.TTL),
+		CreatedAt: now,
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	if err := s.tokenRepo.Create(dbCtx, refreshToken); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, refreshToken.ExpiresAt, nil
+}
+
+// revokeReusedFamily revoca la familia de un token reutilizado y retorna el error para el
+// cliente. La revocación se completa aunque el cliente cancele la solicitud
+func (s *RefreshTokenService) revokeReusedFamily(ctx context.Context, token *domain.RefreshToken) error {
+	log.Printf("refresh token reutilizado del usuario %d: se revoca la familia %s", token.UserID, token.FamilyID)
+
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+	if err := s.tokenRepo.RevokeFamily(dbCtx, token.FamilyID, time.Now()); err != nil {
+		log.Printf("error revocando la familia de refresh tokens %s: %v", token.FamilyID, err)
+	}
+	return domain.NewError(domain.ErrInvalidToken, "refresh token reutilizado, la sesión fue revocada")
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockRefreshTokenRepository para testing
+type MockRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens []*domain.RefreshToken
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token.ID = uint(len(m.tokens) + 1)
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRefreshTokenRepository) MarkRotated(ctx context.Context, id uint, rotatedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.ID == id && token.RotatedAt == nil && token.RevokedAt == nil {
+			token.RotatedAt = &rotatedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeByUserID(ctx context.Context, userID uint, revokedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func newTestRefreshTokenService(t *testing.T) (*RefreshTokenService, *MockUserRepository, *MockRefreshTokenRepository) {
+	t.Helper()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user := &domain.User{Name: "Juan Pérez", Email: "juan@email.com", Password: "password123", IDNumber: "12345678"}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+
+	tokenRepo := &MockRefreshTokenRepository{}
+	return NewRefreshTokenService(userRepo, tokenRepo, NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing), DefaultRefreshTokenConfig()), userRepo, tokenRepo
+}
+
+func TestRefreshTokenService_Rotation(t *testing.T) {
+	refreshService, _, tokenRepo := newTestRefreshTokenService(t)
+	ctx := context.Background()
+
+	refreshToken, _, err := refreshService.IssueRefreshToken(ctx, 1, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tokenRepo.tokens[0].TokenHash == refreshToken {
+		t.Error("Expected the refresh token to be stored hashed")
+	}
+
+	user, pair, err := refreshService.Refresh(ctx, refreshToken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.ID != 1 || pair.AccessToken == "" || pair.RefreshToken == "" || pair.RefreshToken == refreshToken {
+		t.Fatalf("Expected a new token pair for user 1, got %+v", pair)
+	}
+	if tokenRepo.tokens[1].FamilyID != tokenRepo.tokens[0].FamilyID {
+		t.Error("Expected the rotated token to stay in the same family")
+	}
+
+	// El token nuevo también se puede rotar
+	if _, _, err := refreshService.Refresh(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestRefreshTokenService_KeepsMFA(t *testing.T) {
+	refreshService, _, _ := newTestRefreshTokenService(t)
+	ctx := context.Background()
+
+	for _, mfa := range []bool{false, true} {
+		refreshToken, _, err := refreshService.IssueRefreshToken(ctx, 1, mfa)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, pair, err := refreshService.Refresh(ctx, refreshToken)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, claims, err := refreshService.authService.ValidateTokenClaims(ctx, pair.AccessToken)
+		if err != nil {
+			t.Fatalf("Expected valid access token, got %v", err)
+		}
+		if claims.MFA() != mfa {
+			t.Errorf("Expected refreshed access token MFA=%t, got amr %v", mfa, claims.AMR)
+		}
+	}
+}
+
+func TestRefreshTokenService_ReuseRevokesFamily(t *testing.T) {
+	refreshService, _, tokenRepo := newTestRefreshTokenService(t)
+	ctx := context.Background()
+
+	refreshToken, _, _ := refreshService.IssueRefreshToken(ctx, 1, false)
+	_, pair, err := refreshService.Refresh(ctx, refreshToken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, _, err := refreshService.Refresh(ctx, refreshToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("Expected ErrInvalidToken for a reused token, got %v", err)
+	}
+	for _, token := range tokenRepo.tokens {
+		if token.RevokedAt == nil {
+			t.Errorf("Expected token %d to be revoked", token.ID)
+		}
+	}
+
+	// El token vigente de la familia también queda revocado
+	if _, _, err := refreshService.Refresh(ctx, pair.RefreshToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken after the family was revoked, got %v", err)
+	}
+}
+
+func TestRefreshTokenService_InvalidTokens(t *testing.T) {
+	refreshService, userRepo, tokenRepo := newTestRefreshTokenService(t)
+	ctx := context.Background()
+
+	if _, _, err := refreshService.Refresh(ctx, "desconocido"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an unknown token, got %v", err)
+	}
+
+	expired, _, _ := refreshService.IssueRefreshToken(ctx, 1, false)
+	tokenRepo.tokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+	if _, _, err := refreshService.Refresh(ctx, expired); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an expired token, got %v", err)
+	}
+
+	rejected, _, _ := refreshService.IssueRefreshToken(ctx, 1, false)
+	user, _ := userRepo.GetByID(ctx, 1)
+	user.Status = domain.UserStatusRejected
+	if _, _, err := refreshService.Refresh(ctx, rejected); !errors.Is(err, domain.ErrRejected) {
+		t.Errorf("Expected ErrRejected for a rejected user, got %v", err)
+	}
+}
+
+func TestRefreshTokenService_RevokeSession(t *testing.T) {
+	refreshService, _, _ := newTestRefreshTokenService(t)
+	ctx := context.Background()
+
+	session, _, _ := refreshService.IssueRefreshToken(ctx, 1, false)
+	otherSession, _, _ := refreshService.IssueRefreshToken(ctx, 1, false)
+
+	// Un token de otro usuario se ignora
+	if err := refreshService.RevokeSession(ctx, 2, session); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := refreshService.RevokeSession(ctx, 1, session); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, _, err := refreshService.Refresh(ctx, session); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a revoked session, got %v", err)
+	}
+
+	_, pair, err := refreshService.Refresh(ctx, otherSession)
+	if err != nil {
+		t.Fatalf("Expected the other session to stay valid, got %v", err)
+	}
+
+	if err := refreshService.RevokeAll(ctx, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, _, err := refreshService.Refresh(ctx, pair.RefreshToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken after revoking every session, got %v", err)
+	}
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRescreeningInProgress se retorna cuando se solicita un re-screening mientras otro está en curso
+var ErrRescreeningInProgress = domain.NewError(domain.ErrConflict, "ya hay un re-screening en curso")
+
+// RescreeningConfig define la ejecución del re-screening de la base de usuarios
+type RescreeningConfig struct {
+	// Interval es el intervalo entre ejecuciones programadas (0 = sin programación)
+	Interval time.Duration
+	// BatchSize es el número de usuarios leídos por lote
+	BatchSize int
+	// RatePerSecond es el máximo de validaciones PLD por segundo (0 = sin límite)
+	RatePerSecond float64
+}
+
+// DefaultRescreeningConfig retorna la configuración por defecto del re-screening
+func DefaultRescreeningConfig() RescreeningConfig {
+	return RescreeningConfig{
+		BatchSize:     100,
+		RatePerSecond: 5,
+	}
+}
+
+// RescreeningConfigFromEnv construye la configuración a partir de variables de entorno
+func RescreeningConfigFromEnv() RescreeningConfig {
+	config := DefaultRescreeningConfig()
+
+	if v, err := time.ParseDuration(os.Getenv("RESCREENING_INTERVAL")); err == nil && v > 0 {
+		config.Interval = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("RESCREENING_BATCH_SIZE")); err == nil && v > 0 {
+		config.BatchSize = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("RESCREENING_RATE_LIMIT"), 64); err == nil && v >= 0 {
+		config.RatePerSecond = v
+	}
+
+	return config
+}
+
+// RescreeningService vuelve a validar a los usuarios existentes contra el servicio PLD
+// y marca a los que entraron en lista negra después de su alta
+type RescreeningService struct {
+	flagWriter  ports.UserFlagWriter
+	batchReader ports.UserBatchReader
+	runRepo     ports.RescreeningRunRepository
+	screener    *screener
+	config      RescreeningConfig
+	timeouts    Timeouts
+	sleep       func(ctx context.Context, d time.Duration) error
+
+	mu      sync.Mutex
+	running bool
+}
+
+// NewRescreeningService crea una nueva instancia del servicio de re-screening
+func NewRescreeningService(flagWriter ports.UserFlagWriter, batchReader ports.UserBatchReader, pldService ports.PLDService, screeningRepo ports.ScreeningRepository, runRepo ports.RescreeningRunRepository, config RescreeningConfig) *RescreeningService {
+	if config.BatchSize < 1 {
+		config.BatchSize = DefaultRescreeningConfig().BatchSize
+	}
+
+	timeouts := TimeoutsFromEnv()
+	return &RescreeningService{
+		flagWriter:  flagWriter,
+		batchReader: batchReader,
+		runRepo:     runRepo,
+		screener:    newScreener(pldService, screeningRepo, timeouts),
+		config:      config,
+		timeouts:    timeouts,
+		sleep:       sleepContext,
+	}
+}
+
+// Trigger inicia un re-screening en segundo plano y retorna la ejecución creada. Si quedó
+// una ejecución sin terminar (por ejemplo, por un reinicio) se reanuda desde su cursor
+func (s *RescreeningService) Trigger(ctx context.Context, trigger string) (*domain.RescreeningRun, error) {
+	run, err := s.begin(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := *run
+	// La ejecución continúa aunque termine la solicitud que la inició
+	go s.execute(context.WithoutCancel(ctx), run)
+
+	return &snapshot, nil
+}
+
+// Run ejecuta un re-screening completo de forma síncrona y retorna la ejecución final
+func (s *RescreeningService) Run(ctx context.Context, trigger string) (*domain.RescreeningRun, error) {
+	run, err := s.begin(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	s.execute(ctx, run)
+	return run, nil
+}
+
+// LatestRun obtiene la ejecución más reciente
+func (s *RescreeningService) LatestRun(ctx context.Context) (*domain.RescreeningRun, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	run, err := s.runRepo.GetLatest(dbCtx)
+	if err != nil {
+		return nil, errors.New("error obteniendo re-screening")
+	}
+	if run == nil {
+		return nil, domain.NewError(domain.ErrNotFound, "no hay re-screenings registrados")
+	}
+
+	return run, nil
+}
+
+// StartScheduler reanuda la ejecución pendiente, si la hay, y programa ejecuciones
+// periódicas según el intervalo configurado hasta que se cancele ctx
+func (s *RescreeningService) StartScheduler(ctx context.Context) {
+	go func() {
+		dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+		unfinished, err := s.runRepo.GetUnfinished(dbCtx)
+		cancel()
+		if err != nil {
+			log.Printf("re-screening: error buscando ejecución pendiente: %v", err)
+		} else if unfinished != nil {
+			log.Printf("re-screening: reanudando ejecución %d desde el usuario %d", unfinished.ID, unfinished.LastUserID)
+			s.runLogged(ctx, unfinished.Trigger)
+		}
+
+		if s.config.Interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runLogged(ctx, domain.RescreeningTriggerScheduled)
+			}
+		}
+	}()
+}
+
+// runLogged ejecuta un re-screening registrando en el log su resultado
+func (s *RescreeningService) runLogged(ctx context.Context, trigger string) {
+	run, err := s.Run(ctx, trigger)
+	if err != nil {
+		log.Printf("re-screening: %v", err)
+		return
+	}
+	log.Printf("re-screening %d %s: %d procesados, %d marcados, %d fallidos", run.ID, run.Status, run.Processed, run.Flagged, run.Failed)
+}
+
+// begin reserva la ejecución y obtiene la ejecución pendiente o crea una nueva
+func (s *RescreeningService) begin(ctx context.Context, trigger string) (*domain.RescreeningRun, error) {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return nil, ErrRescreeningInProgress
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	run, err := s.loadOrCreateRun(ctx, trigger)
+	if err != nil {
+		s.finishExecution()
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// loadOrCreateRun obtiene la ejecución sin terminar o registra una nueva
+func (s *RescreeningService) loadOrCreateRun(ctx context.Context, trigger string) (*domain.RescreeningRun, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	run, err := s.runRepo.GetUnfinished(dbCtx)
+	if err != nil {
+		return nil, errors.New("error obteniendo re-screening pendiente")
+	}
+	if run != nil {
+		return run, nil
+	}
+
+	now := time.Now()
+	run = &domain.RescreeningRun{
+		Trigger:   trigger,
+		Status:    domain.RescreeningStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.runRepo.Create(dbCtx, run); err != nil {
+		return nil, errors.New("error registrando re-screening")
+	}
+
+	return run, nil
+}
+
+// finishExecution libera la reserva de ejecución
+func (s *RescreeningService) finishExecution() {
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+}
+
+// execute recorre los usuarios por lotes a partir del cursor de la ejecución. Si ctx se
+// cancela la ejecución queda en curso para reanudarse en el próximo arranque
+func (s *RescreeningService) execute(ctx context.Context, run *domain.RescreeningRun) {
+	defer s.finishExecution()
+
+	first := true
+	for {
+		dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+		users, err := s.batchReader.ListAfterID(dbCtx, run.LastUserID, s.config.BatchSize)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.finish(ctx, run, domain.RescreeningStatusFailed, "error leyendo usuarios: "+err.Error())
+			return
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		for _, user := range users {
+			if !first {
+				if err := s.sleep(ctx, s.rateInterval()); err != nil {
+					return
+				}
+			}
+			first = false
+
+			if err := s.rescreenUser(ctx, run, user); err != nil {
+				return
+			}
+
+			run.LastUserID = user.ID
+			s.saveProgress(ctx, run)
+		}
+	}
+
+	s.finish(ctx, run, domain.RescreeningStatusCompleted, "")
+}
+
+// rescreenUser valida un usuario y lo marca si entró en lista negra. Solo retorna error
+// si ctx fue cancelado; las fallas de validación se contabilizan en la ejecución
+func (s *RescreeningService) rescreenUser(ctx context.Context, run *domain.RescreeningRun, user *domain.User) error {
+	pldResponse, _, err := s.screener.screen(ctx, user.PLDRequest(), &user.ID)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	run.Processed++
+	if err != nil {
+		run.Failed++
+		log.Printf("re-screening: error validando usuario %d: %v", user.ID, err)
+		return nil
+	}
+
+	if !pldResponse.IsBlacklisted || user.FlaggedAt != nil {
+		return nil
+	}
+
+	// Solo se escribe la marca: el usuario del lote puede estar desactualizado si se aprobó
+	// una revisión o se editó el perfil mientras corre el re-screening
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+
+	flagged, err := s.flagWriter.Flag(dbCtx, user.ID, pldResponse.Reason, time.Now())
+	if err != nil {
+		run.Failed++
+		log.Printf("re-screening: error marcando usuario %d: %v", user.ID, err)
+		return nil
+	}
+	if !flagged {
+		return nil
+	}
+
+	run.Flagged++
+	log.Printf("re-screening: usuario %d marcado en lista negra: %s", user.ID, pldResponse.Reason)
+	return nil
+}
+
+// rateInterval calcula la espera entre validaciones según el límite configurado
+func (s *RescreeningService) rateInterval() time.Duration {
+	if s.config.RatePerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / s.config.RatePerSecond)
+}
+
+// saveProgress persiste el cursor y los contadores de la ejecución
+func (s *RescreeningService) saveProgress(ctx context.Context, run *domain.RescreeningRun) {
+	run.UpdatedAt = time.Now()
+
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+
+	if err := s.runRepo.Update(dbCtx, run); err != nil {
+		log.Printf("re-screening: error guardando progreso de la ejecución %d: %v", run.ID, err)
+	}
+}
+
+// finish marca la ejecución como terminada
+func (s *RescreeningService) finish(ctx context.Context, run *domain.RescreeningRun, status, reason string) {
+	now := time.Now()
+	run.Status = status
+	run.Error = reason
+	run.FinishedAt = &now
+	s.saveProgress(ctx, run)
+}
+
+// sleepContext espera la duración indicada o hasta que se cancele ctx
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
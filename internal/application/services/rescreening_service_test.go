@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+// ListAfterID permite usar MockUserRepository como ports.UserBatchReader. Retorna copias,
+// como la base de datos, para que los cambios posteriores no se reflejen en el lote
+func (m *MockUserRepository) ListAfterID(ctx context.Context, afterID uint, limit int) ([]*domain.User, error) {
+	var result []*domain.User
+	for id, user := range m.users {
+		if id > afterID {
+			snapshot := *user
+			result = append(result, &snapshot)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// Flag permite usar MockUserRepository como ports.UserFlagWriter
+func (m *MockUserRepository) Flag(ctx context.Context, id uint, reason string, flaggedAt time.Time) (bool, error) {
+	user, exists := m.users[id]
+	if !exists || user.FlaggedAt != nil {
+		return false, nil
+	}
+	user.FlaggedAt = &flaggedAt
+	user.FlagReason = reason
+	user.UpdatedAt = flaggedAt
+	return true, nil
+}
+
+// MockRescreeningRunRepository para testing
+type MockRescreeningRunRepository struct {
+	runs    []*domain.RescreeningRun
+	updates int
+}
+
+func NewMockRescreeningRunRepository() *MockRescreeningRunRepository {
+	return &MockRescreeningRunRepository{}
+}
+
+func (m *MockRescreeningRunRepository) Create(ctx context.Context, run *domain.RescreeningRun) error {
+	run.ID = uint(len(m.runs) + 1)
+	stored := *run
+	m.runs = append(m.runs, &stored)
+	return nil
+}
+
+func (m *MockRescreeningRunRepository) Update(ctx context.Context, run *domain.RescreeningRun) error {
+	m.updates++
+	stored := *run
+	m.runs[run.ID-1] = &stored
+	return nil
+}
+
+func (m *MockRescreeningRunRepository) GetLatest(ctx context.Context) (*domain.RescreeningRun, error) {
+	if len(m.runs) == 0 {
+		return nil, nil
+	}
+	run := *m.runs[len(m.runs)-1]
+	return &run, nil
+}
+
+func (m *MockRescreeningRunRepository) GetUnfinished(ctx context.Context) (*domain.RescreeningRun, error) {
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		if m.runs[i].Status == domain.RescreeningStatusRunning {
+			run := *m.runs[i]
+			return &run, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListPLDService marca en lista negra los números de identificación indicados
+type ListPLDService struct {
+	blacklisted map[string]bool
+	failing     map[string]bool
+	calls       []string
+	// onValidate, si no es nil, se ejecuta en cada validación
+	onValidate func(idNumber string)
+}
+
+func (m *ListPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	m.calls = append(m.calls, request.IDNumber)
+	if m.onValidate != nil {
+		m.onValidate(request.IDNumber)
+	}
+	if m.failing[request.IDNumber] {
+		return nil, errors.New("servicio PLD no disponible")
+	}
+	if m.blacklisted[request.IDNumber] {
+		return &domain.PLDResponse{IsBlacklisted: true, Status: "blacklisted", Reason: "Sanción reciente"}, nil
+	}
+	return &domain.PLDResponse{Status: "clean"}, nil
+}
+
+func newTestRescreeningService(userRepo *MockUserRepository, pldService *ListPLDService, runRepo *MockRescreeningRunRepository, screeningRepo *MockScreeningRepository) *RescreeningService {
+	service := NewRescreeningService(userRepo, userRepo, pldService, screeningRepo, runRepo, RescreeningConfig{BatchSize: 2})
+	service.sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+	return service
+}
+
+func seedUsers(userRepo *MockUserRepository, idNumbers ...string) {
+	for _, idNumber := range idNumbers {
+		userRepo.Create(context.Background(), &domain.User{
+			Name:     "Usuario " + idNumber,
+			Email:    idNumber + "@email.com",
+			IDNumber: idNumber,
+		})
+	}
+}
+
+func TestRescreeningService_Run_FlagsNewlyBlacklistedUsers(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	seedUsers(userRepo, "111", "222", "333", "444", "555")
+
+	pldService := &ListPLDService{
+		blacklisted: map[string]bool{"222": true},
+		failing:     map[string]bool{"444": true},
+	}
+	runRepo := NewMockRescreeningRunRepository()
+	screeningRepo := NewMockScreeningRepository()
+	service := newTestRescreeningService(userRepo, pldService, runRepo, screeningRepo)
+
+	run, err := service.Run(context.Background(), domain.RescreeningTriggerManual)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if run.Status != domain.RescreeningStatusCompleted || run.FinishedAt == nil {
+		t.Errorf("Expected completed run, got %+v", run)
+	}
+	if run.Processed != 5 || run.Flagged != 1 || run.Failed != 1 {
+		t.Errorf("Expected 5 processed, 1 flagged and 1 failed, got %+v", run)
+	}
+	if run.LastUserID != 5 {
+		t.Errorf("Expected cursor at user 5, got %d", run.LastUserID)
+	}
+
+	flagged, _ := userRepo.GetByID(context.Background(), 2)
+	if flagged.FlaggedAt == nil || flagged.FlagReason != "Sanción reciente" {
+		t.Errorf("Expected user 2 to be flagged, got %+v", flagged)
+	}
+	clean, _ := userRepo.GetByID(context.Background(), 1)
+	if clean.FlaggedAt != nil {
+		t.Error("Expected user 1 not to be flagged")
+	}
+
+	// Cada validación queda registrada y vinculada al usuario
+	if len(screeningRepo.screenings) != 5 {
+		t.Fatalf("Expected 5 screenings, got %d", len(screeningRepo.screenings))
+	}
+	if screeningRepo.screenings[0].UserID == nil || *screeningRepo.screenings[0].UserID != 1 {
+		t.Error("Expected screening to be linked to user 1")
+	}
+
+	// Un usuario ya marcado no vuelve a contabilizarse
+	run, err = service.Run(context.Background(), domain.RescreeningTriggerScheduled)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if run.ID != 2 || run.Flagged != 0 {
+		t.Errorf("Expected a new run without new flags, got %+v", run)
+	}
+}
+
+func TestRescreeningService_Run_KeepsConcurrentChanges(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	seedUsers(userRepo, "111")
+
+	// Mientras se valida al usuario, un administrador aprueba su revisión y edita su nombre
+	pldService := &ListPLDService{
+		blacklisted: map[string]bool{"111": true},
+		onValidate: func(idNumber string) {
+			updated := *userRepo.users[1]
+			updated.Status = domain.UserStatusActive
+			updated.Name = "Nombre corregido"
+			userRepo.Update(context.Background(), &updated)
+		},
+	}
+	service := newTestRescreeningService(userRepo, pldService, NewMockRescreeningRunRepository(), NewMockScreeningRepository())
+
+	run, err := service.Run(context.Background(), domain.RescreeningTriggerManual)
+	if err != nil || run.Flagged != 1 {
+		t.Fatalf("Expected one flagged user, got %+v (%v)", run, err)
+	}
+
+	user, _ := userRepo.GetByID(context.Background(), 1)
+	if user.FlaggedAt == nil || user.FlagReason != "Sanción reciente" {
+		t.Errorf("Expected user to be flagged, got %+v", user)
+	}
+	if user.Status != domain.UserStatusActive || user.Name != "Nombre corregido" {
+		t.Errorf("Expected the concurrent changes to be kept, got %+v", user)
+	}
+}
+
+func TestRescreeningService_Run_ResumesUnfinishedRun(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	seedUsers(userRepo, "111", "222", "333")
+
+	runRepo := NewMockRescreeningRunRepository()
+	runRepo.Create(context.Background(), &domain.RescreeningRun{
+		Trigger:    domain.RescreeningTriggerScheduled,
+		Status:     domain.RescreeningStatusRunning,
+		LastUserID: 2,
+		Processed:  2,
+	})
+
+	pldService := &ListPLDService{}
+	service := newTestRescreeningService(userRepo, pldService, runRepo, NewMockScreeningRepository())
+
+	run, err := service.Run(context.Background(), domain.RescreeningTriggerManual)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if run.ID != 1 || run.Trigger != domain.RescreeningTriggerScheduled {
+		t.Errorf("Expected the unfinished run to be resumed, got %+v", run)
+	}
+	if len(pldService.calls) != 1 || pldService.calls[0] != "333" {
+		t.Errorf("Expected only user 3 to be screened, got %v", pldService.calls)
+	}
+	if run.Processed != 3 || run.Status != domain.RescreeningStatusCompleted {
+		t.Errorf("Expected completed run with 3 processed, got %+v", run)
+	}
+}
+
+func TestRescreeningService_Run_CancellationKeepsRunResumable(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	seedUsers(userRepo, "111", "222", "333")
+
+	runRepo := NewMockRescreeningRunRepository()
+	pldService := &ListPLDService{}
+	service := newTestRescreeningService(userRepo, pldService, runRepo, NewMockScreeningRepository())
+
+	// Cancelar durante la espera del límite de tasa, después del primer usuario
+	ctx, cancel := context.WithCancel(context.Background())
+	service.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	run, err := service.Run(ctx, domain.RescreeningTriggerManual)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stored, _ := runRepo.GetUnfinished(context.Background())
+	if stored == nil || stored.ID != run.ID || stored.LastUserID != 1 {
+		t.Fatalf("Expected the run to remain unfinished at user 1, got %+v", stored)
+	}
+	if service.running {
+		t.Error("Expected the execution slot to be released")
+	}
+}
+
+func TestRescreeningService_Trigger_RejectsConcurrentRun(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	service := newTestRescreeningService(userRepo, &ListPLDService{}, NewMockRescreeningRunRepository(), NewMockScreeningRepository())
+
+	service.running = true
+	if _, err := service.Trigger(context.Background(), domain.RescreeningTriggerManual); !errors.Is(err, ErrRescreeningInProgress) {
+		t.Errorf("Expected ErrRescreeningInProgress, got %v", err)
+	}
+}
+
+func TestRescreeningService_LatestRun_NoRuns(t *testing.T) {
+	service := newTestRescreeningService(NewMockUserRepository(), &ListPLDService{}, NewMockRescreeningRunRepository(), NewMockScreeningRepository())
+
+	if _, err := service.LatestRun(context.Background()); err == nil {
+		t.Error("Expected error when there are no runs")
+	}
+}
+
+func TestRescreeningConfigFromEnv(t *testing.T) {
+	t.Setenv("RESCREENING_INTERVAL", "24h")
+	t.Setenv("RESCREENING_BATCH_SIZE", "50")
+	t.Setenv("RESCREENING_RATE_LIMIT", "2.5")
+
+	config := RescreeningConfigFromEnv()
+
+	if config.Interval != 24*time.Hour || config.BatchSize != 50 || config.RatePerSecond != 2.5 {
+		t.Errorf("Unexpected config %+v", config)
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+// errNotPendingReview se retorna al decidir un alta que ya no está pendiente de revisión
+var errNotPendingReview = domain.NewError(domain.ErrConflict, "el usuario no está pendiente de revisión")
+
+// ReviewService implementa la cola de revisión manual de las altas con screening no concluyente
+type ReviewService struct {
+	userRepo      ports.UserRepository
+	statusReader  ports.UserStatusReader
+	screeningRepo ports.ScreeningRepository
+	decisionRepo  ports.ReviewDecisionRepository
+	rejectedRepo  ports.RejectedApplicationRepository
+	timeouts      Timeouts
+}
+
+// NewReviewService crea una nueva instancia del servicio de revisión
+func NewReviewService(userRepo ports.UserRepository, statusReader ports.UserStatusReader, screeningRepo ports.ScreeningRepository, decisionRepo ports.ReviewDecisionRepository, rejectedRepo ports.RejectedApplicationRepository) *ReviewService {
+	return &ReviewService{
+		userRepo:      userRepo,
+		statusReader:  statusReader,
+		screeningRepo: screeningRepo,
+		decisionRepo:  decisionRepo,
+		rejectedRepo:  rejectedRepo,
+		timeouts:      TimeoutsFromEnv(),
+	}
+}
+
+// ListPending obtiene la cola de altas pendientes de revisión, de la más antigua a la más reciente
+func (s *ReviewService) ListPending(ctx context.Context) ([]*domain.ReviewItem, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	users, err := s.statusReader.ListByStatus(dbCtx, domain.UserStatusPendingReview)
+	if err != nil {
+		return nil, errors.New("error obteniendo cola de revisión")
+	}
+
+	items := make([]*domain.ReviewItem, 0, len(users))
+	for _, user := range users {
+		screening, err := s.latestScreening(dbCtx, user.ID)
+		if err != nil {
+			return nil, errors.New("error obteniendo cola de revisión")
+		}
+		items = append(items, &domain.ReviewItem{User: user, Screening: screening})
+	}
+
+	return items, nil
+}
+
+// Decide aprueba o rechaza un alta pendiente de revisión. La justificación es obligatoria
+// y la decisión queda registrada en el log de auditoría en la misma transacción que cambia el
+// estado del usuario, por lo que solo la primera de dos decisiones simultáneas tiene efecto
+func (s *ReviewService) Decide(ctx context.Context, userID uint, reviewer *domain.User, decision, justification string) (*domain.ReviewDecision, error) {
+	if decision != domain.ReviewDecisionApproved && decision != domain.ReviewDecisionRejected {
+		return nil, domain.NewError(domain.ErrInvalidInput, "decisión inválida")
+	}
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return nil, domain.NewError(domain.ErrInvalidInput, "la justificación es obligatoria")
+	}
+
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+
+	user, err := s.userRepo.GetByID(dbCtx, userID)
+	if err != nil {
+		return nil, errors.New("error obteniendo usuario")
+	}
+	if user == nil {
+		return nil, domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+	if user.Status != domain.UserStatusPendingReview {
+		return nil, errNotPendingReview
+	}
+
+	status := domain.UserStatusActive
+	if decision == domain.ReviewDecisionRejected {
+		status = domain.UserStatusRejected
+	}
+
+	now := time.Now()
+	record := &domain.ReviewDecision{
+		UserID:        user.ID,
+		ReviewerID:    reviewer.ID,
+		Decision:      decision,
+		Justification: justification,
+		CreatedAt:     now,
+	}
+	recorded, err := s.decisionRepo.RecordDecision(dbCtx, record, status)
+	if err != nil {
+		return nil, errors.New("error registrando decisión de revisión")
+	}
+	if !recorded {
+		return nil, errNotPendingReview
+	}
+	user.Status = status
+	user.UpdatedAt = now
+
+	if decision == domain.ReviewDecisionRejected {
+		s.recordRejection(ctx, user, justification)
+	}
+
+	return record, nil
+}
+
+// ListDecisions obtiene el registro de decisiones de revisión de un usuario
+func (s *ReviewService) ListDecisions(ctx context.Context, userID uint) ([]*domain.ReviewDecision, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.decisionRepo.ListByUserID(dbCtx, userID)
+}
+
+// latestScreening obtiene el screening más reciente de un usuario, o nil si no tiene
+func (s *ReviewService) latestScreening(ctx context.Context, userID uint) (*domain.Screening, error) {
+	screenings, err := s.screeningRepo.ListByUserID(ctx, userID)
+	if err != nil || len(screenings) == 0 {
+		return nil, err
+	}
+	return screenings[0], nil
+}
+
+// recordRejection agrega el alta rechazada en revisión al listado de solicitudes rechazadas
+func (s *ReviewService) recordRejection(ctx context.Context, user *domain.User, justification string) {
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+
+	application := &domain.RejectedApplication{
+		Name:      user.Name,
+		Email:     user.Email,
+		IDNumber:  user.IDNumber,
+		Reason:    "Rechazado en revisión de cumplimiento: " + justification,
+		Provider:  "manual-review",
+		CreatedAt: time.Now(),
+	}
+	if screening, err := s.latestScreening(dbCtx, user.ID); err == nil && screening != nil {
+		application.Provider = screening.Provider
+		application.ScreeningID = &screening.ID
+	}
+
+	if err := s.rejectedRepo.Create(dbCtx, application); err != nil {
+		log.Printf("error registrando solicitud rechazada de %s: %v", user.Email, err)
+	}
+}
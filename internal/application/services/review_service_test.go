@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ListByStatus permite usar MockUserRepository como ports.UserStatusReader
+func (m *MockUserRepository) ListByStatus(ctx context.Context, status string) ([]*domain.User, error) {
+	var result []*domain.User
+	for _, user := range m.users {
+		if user.Status == status {
+			result = append(result, user)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// MockReviewDecisionRepository para testing. Cambia el estado de los usuarios de users
+type MockReviewDecisionRepository struct {
+	users     *MockUserRepository
+	decisions []*domain.ReviewDecision
+}
+
+func (m *MockReviewDecisionRepository) Create(ctx context.Context, decision *domain.ReviewDecision) error {
+	decision.ID = uint(len(m.decisions) + 1)
+	m.decisions = append(m.decisions, decision)
+	return nil
+}
+
+func (m *MockReviewDecisionRepository) RecordDecision(ctx context.Context, decision *domain.ReviewDecision, status string) (bool, error) {
+	user, exists := m.users.users[decision.UserID]
+	if !exists || user.Status != domain.UserStatusPendingReview {
+		return false, nil
+	}
+	user.Status = status
+	user.UpdatedAt = decision.CreatedAt
+	return true, m.Create(ctx, decision)
+}
+
+func (m *MockReviewDecisionRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.ReviewDecision, error) {
+	var result []*domain.ReviewDecision
+	for i := len(m.decisions) - 1; i >= 0; i-- {
+		if m.decisions[i].UserID == userID {
+			result = append(result, m.decisions[i])
+		}
+	}
+	return result, nil
+}
+
+// ReviewMockPLDService retorna una coincidencia no concluyente
+type ReviewMockPLDService struct{}
+
+func (m *ReviewMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	return &domain.PLDResponse{
+		Status:     domain.ScreeningStatusReview,
+		Provider:   "local-watchlist",
+		MatchScore: 0.8,
+		Reason:     "Posible coincidencia en lista uif_lpb: María López, similitud 0.80",
+	}, nil
+}
+
+// createPendingUser da de alta un usuario con screening no concluyente
+func createPendingUser(t *testing.T, userRepo *MockUserRepository, screeningRepo *MockScreeningRepository) *domain.User {
+	t.Helper()
+
+	userService := NewUserService(userRepo, userRepo, &ReviewMockPLDService{}, screeningRepo, NewMockRejectedApplicationRepository())
+	user := &domain.User{
+		Name:     "María López",
+		Email:    "maria.lopez@email.com",
+		Password: "password123",
+		IDNumber: "87654321",
+	}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return user
+}
+
+func TestUserService_CreateUser_InconclusiveScreeningPendsReview(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	screeningRepo := NewMockScreeningRepository()
+
+	user := createPendingUser(t, userRepo, screeningRepo)
+
+	if user.Status != domain.UserStatusPendingReview || !strings.Contains(user.ReviewReason, "Posible coincidencia") {
+		t.Errorf("Expected user pending review, got status %q and reason %q", user.Status, user.ReviewReason)
+	}
+	if len(screeningRepo.screenings) != 1 || screeningRepo.screenings[0].Status != domain.ScreeningStatusReview {
+		t.Errorf("Expected a screening with review status, got %+v", screeningRepo.screenings)
+	}
+}
+
+func TestAuthService_Login_RejectsUsersNotActive(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	pending := &domain.User{Email: "pending@email.com", Password: string(hashedPassword), Status: domain.UserStatusPendingReview}
+	rejected := &domain.User{Email: "rejected@email.com", Password: string(hashedPassword), Status: domain.UserStatusRejected}
+	userRepo.Create(context.Background(), pending)
+	userRepo.Create(context.Background(), rejected)
+
+	if _, _, err := authService.Login(context.Background(), "pending@email.com", "password123"); err == nil || err.Error() != "usuario pendiente de revisión de cumplimiento" {
+		t.Errorf("Expected pending review error, got %v", err)
+	}
+	if _, _, err := authService.Login(context.Background(), "rejected@email.com", "password123"); err == nil || err.Error() != "usuario rechazado por cumplimiento" {
+		t.Errorf("Expected rejected error, got %v", err)
+	}
+}
+
+func TestAuthService_ValidateToken_RejectsUsersNotActive(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
+
+	user := &domain.User{Email: "juan@email.com", Status: domain.UserStatusActive}
+	userRepo.Create(context.Background(), user)
+	token, err := authService.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := authService.ValidateToken(context.Background(), token); err != nil {
+		t.Fatalf("Expected valid token, got %v", err)
+	}
+
+	// Los tokens emitidos antes de pasar a revisión o de ser rechazado dejan de aceptarse
+	user.Status = domain.UserStatusPendingReview
+	if _, err := authService.ValidateToken(context.Background(), token); !errors.Is(err, domain.ErrPendingReview) {
+		t.Errorf("Expected ErrPendingReview, got %v", err)
+	}
+	user.Status = domain.UserStatusRejected
+	if _, err := authService.ValidateToken(context.Background(), token); !errors.Is(err, domain.ErrRejected) {
+		t.Errorf("Expected ErrRejected, got %v", err)
+	}
+}
+
+func TestReviewService_ListPending(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	screeningRepo := NewMockScreeningRepository()
+	seedUsers(userRepo, "111")
+	pending := createPendingUser(t, userRepo, screeningRepo)
+
+	reviewService := NewReviewService(userRepo, userRepo, screeningRepo, &MockReviewDecisionRepository{users: userRepo}, NewMockRejectedApplicationRepository())
+
+	items, err := reviewService.ListPending(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 1 || items[0].User.ID != pending.ID {
+		t.Fatalf("Expected only the pending user in the queue, got %+v", items)
+	}
+	if items[0].Screening == nil || items[0].Screening.MatchScore != 0.8 {
+		t.Errorf("Expected the latest screening in the queue item, got %+v", items[0].Screening)
+	}
+}
+
+func TestReviewService_Decide(t *testing.T) {
+	reviewer := &domain.User{ID: 99, Role: domain.RoleAdmin}
+
+	t.Run("aprobación", func(t *testing.T) {
+		userRepo := NewMockUserRepository()
+		decisionRepo := &MockReviewDecisionRepository{users: userRepo}
+		rejectedRepo := NewMockRejectedApplicationRepository()
+		user := createPendingUser(t, userRepo, NewMockScreeningRepository())
+		reviewService := NewReviewService(userRepo, userRepo, NewMockScreeningRepository(), decisionRepo, rejectedRepo)
+
+		decision, err := reviewService.Decide(context.Background(), user.ID, reviewer, domain.ReviewDecisionApproved, "  Homonimia descartada por CURP  ")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if decision.ReviewerID != reviewer.ID || decision.Justification != "Homonimia descartada por CURP" {
+			t.Errorf("Unexpected decision %+v", decision)
+		}
+		if user.Status != domain.UserStatusActive || len(rejectedRepo.applications) != 0 {
+			t.Errorf("Expected active user without rejected application, got %q", user.Status)
+		}
+
+		if _, err := reviewService.Decide(context.Background(), user.ID, reviewer, domain.ReviewDecisionRejected, "Otra decisión"); err == nil || err.Error() != "el usuario no está pendiente de revisión" {
+			t.Errorf("Expected error deciding twice, got %v", err)
+		}
+	})
+
+	t.Run("rechazo", func(t *testing.T) {
+		userRepo := NewMockUserRepository()
+		screeningRepo := NewMockScreeningRepository()
+		decisionRepo := &MockReviewDecisionRepository{users: userRepo}
+		rejectedRepo := NewMockRejectedApplicationRepository()
+		user := createPendingUser(t, userRepo, screeningRepo)
+		reviewService := NewReviewService(userRepo, userRepo, screeningRepo, decisionRepo, rejectedRepo)
+
+		if _, err := reviewService.Decide(context.Background(), user.ID, reviewer, domain.ReviewDecisionRejected, "Coincide fecha de nacimiento"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if user.Status != domain.UserStatusRejected {
+			t.Errorf("Expected rejected user, got %q", user.Status)
+		}
+		if len(rejectedRepo.applications) != 1 || rejectedRepo.applications[0].Provider != "local-watchlist" || rejectedRepo.applications[0].ScreeningID == nil {
+			t.Errorf("Expected a rejected application linked to the screening, got %+v", rejectedRepo.applications)
+		}
+
+		decisions, _ := reviewService.ListDecisions(context.Background(), user.ID)
+		if len(decisions) != 1 || decisions[0].Decision != domain.ReviewDecisionRejected {
+			t.Errorf("Expected the rejection in the audit log, got %+v", decisions)
+		}
+	})
+
+	t.Run("validaciones", func(t *testing.T) {
+		userRepo := NewMockUserRepository()
+		decisionRepo := &MockReviewDecisionRepository{users: userRepo}
+		user := createPendingUser(t, userRepo, NewMockScreeningRepository())
+		reviewService := NewReviewService(userRepo, userRepo, NewMockScreeningRepository(), decisionRepo, NewMockRejectedApplicationRepository())
+
+		tests := []struct {
+			userID        uint
+			decision      string
+			justification string
+			expected      string
+		}{
+			{user.ID, "escalated", "Motivo", "decisión inválida"},
+			{user.ID, domain.ReviewDecisionApproved, "   ", "la justificación es obligatoria"},
+			{999, domain.ReviewDecisionApproved, "Motivo", "usuario no encontrado"},
+		}
+		for _, tt := range tests {
+			if _, err := reviewService.Decide(context.Background(), tt.userID, reviewer, tt.decision, tt.justification); err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected %q, got %v", tt.expected, err)
+			}
+		}
+		if len(decisionRepo.decisions) != 0 || user.Status != domain.UserStatusPendingReview {
+			t.Errorf("Expected no decisions recorded, got %+v", decisionRepo.decisions)
+		}
+	})
+}
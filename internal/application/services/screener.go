@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// screener valida identidades contra el servicio PLD y registra cada resultado en el
+// historial de screenings. Lo comparten el alta de usuarios y el re-screening periódico
+type screener struct {
+	pldService    ports.PLDService
+	screeningRepo ports.ScreeningRepository
+	timeouts      Timeouts
+}
+
+// newScreener crea un screener con los plazos indicados
+func newScreener(pldService ports.PLDService, screeningRepo ports.ScreeningRepository, timeouts Timeouts) *screener {
+	return &screener{
+		pldService:    pldService,
+		screeningRepo: screeningRepo,
+		timeouts:      timeouts,
+	}
+}
+
+// screen valida una identidad contra el servicio PLD y registra el resultado en el
+// historial de screenings, tanto si la validación fue exitosa como si falló. userID
+// vincula el screening con un usuario existente (nil durante el alta)
+func (s *screener) screen(ctx context.Context, request domain.PLDRequest, userID *uint) (*domain.PLDResponse, *domain.Screening, error) {
+	pldCtx, cancel := withTimeout(ctx, s.timeouts.PLD)
+	start := time.Now()
+	pldResponse, pldErr := s.pldService.ValidateUser(pldCtx, request)
+	latency := time.Since(start)
+	cancel()
+
+	screening := newScreening(request, pldResponse, pldErr, latency)
+	screening.UserID = userID
+
+	// El registro de auditoría se guarda aunque el cliente haya cancelado la solicitud
+	dbCtx, cancelDB := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	recordErr := s.screeningRepo.Create(dbCtx, screening)
+	cancelDB()
+	if recordErr != nil {
+		log.Printf("error registrando screening PLD: %v", recordErr)
+	}
+
+	if pldErr != nil {
+		// Si el cliente canceló la solicitud no se trata de una falla del servicio PLD
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, domain.ErrPLDUnavailable
+	}
+
+	// Sin registro de auditoría el resultado no puede usarse
+	if recordErr != nil {
+		return nil, nil, errors.New("error registrando screening PLD")
+	}
+
+	return pldResponse, screening, nil
+}
+
+// newScreening construye el registro de auditoría de una validación PLD
+func newScreening(request domain.PLDRequest, response *domain.PLDResponse, pldErr error, latency time.Duration) *domain.Screening {
+	screening := &domain.Screening{
+		IDNumber:  request.IDNumber,
+		Email:     request.Email,
+		Status:    domain.ScreeningStatusError,
+		Provider:  "unknown",
+		LatencyMs: latency.Milliseconds(),
+		CreatedAt: time.Now(),
+	}
+
+	if response != nil {
+		screening.RequestPayload = response.RequestPayload
+		screening.RawResponse = response.RawResponse
+		if response.Provider != "" {
+			screening.Provider = response.Provider
+		}
+		screening.MatchScore = response.MatchScore
+		screening.Status = domain.ScreeningStatusClean
+		if response.IsBlacklisted {
+			screening.Status = domain.ScreeningStatusBlacklisted
+		} else if response.Status == domain.ScreeningStatusReview {
+			screening.Status = domain.ScreeningStatusReview
+		}
+	}
+
+	// Si el proveedor no expone su payload se registra la solicitud en formato interno
+	if screening.RequestPayload == "" {
+		payload, _ := json.Marshal(request)
+		screening.RequestPayload = string(payload)
+	}
+
+	if pldErr != nil {
+		screening.Status = domain.ScreeningStatusError
+		screening.Error = pldErr.Error()
+	}
+
+	return screening
+}
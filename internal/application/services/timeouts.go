@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Timeouts define los plazos máximos por operación que aplican los servicios
+// sobre el contexto recibido de la solicitud
+type Timeouts struct {
+	// PLD es el plazo para la validación completa contra el servicio PLD (incluye reintentos)
+	PLD time.Duration
+	// Database es el plazo para cada operación contra el repositorio
+	Database time.Duration
+}
+
+// DefaultTimeouts retorna los plazos por defecto
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		PLD:      45 * time.Second,
+		Database: 5 * time.Second,
+	}
+}
+
+// TimeoutsFromEnv construye los plazos a partir de variables de entorno
+func TimeoutsFromEnv() Timeouts {
+	timeouts := DefaultTimeouts()
+
+	if v, err := time.ParseDuration(os.Getenv("PLD_CALL_TIMEOUT")); err == nil && v > 0 {
+		timeouts.PLD = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("DB_QUERY_TIMEOUT")); err == nil && v > 0 {
+		timeouts.Database = v
+	}
+
+	return timeouts
+}
+
+// withTimeout deriva un contexto con plazo; un plazo no positivo solo agrega cancelación
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
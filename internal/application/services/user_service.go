@@ -1,52 +1,75 @@
 package services
 
 import (
+	"context"
 	"crabi-test/internal/application/ports"
 	"crabi-test/internal/domain"
-	"errors"
+	"log"
 	"time"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 // UserService implementa la lógica de negocio para usuarios
 type UserService struct {
-	userRepo   ports.UserRepository
-	pldService ports.PLDService
+	userRepo      ports.UserRepository
+	profileWriter ports.UserProfileWriter
+	screeningRepo ports.ScreeningRepository
+	rejectedRepo  ports.RejectedApplicationRepository
+	screener      *screener
+	timeouts      Timeouts
 }
 
 // NewUserService crea una nueva instancia del servicio de usuarios
-func NewUserService(userRepo ports.UserRepository, pldService ports.PLDService) *UserService {
+func NewUserService(userRepo ports.UserRepository, profileWriter ports.UserProfileWriter, pldService ports.PLDService, screeningRepo ports.ScreeningRepository, rejectedRepo ports.RejectedApplicationRepository) *UserService {
+	timeouts := TimeoutsFromEnv()
 	return &UserService{
-		userRepo:   userRepo,
-		pldService: pldService,
+		userRepo:      userRepo,
+		profileWriter: profileWriter,
+		screeningRepo: screeningRepo,
+		rejectedRepo:  rejectedRepo,
+		screener:      newScreener(pldService, screeningRepo, timeouts),
+		timeouts:      timeouts,
 	}
 }
 
 // CreateUser crea un nuevo usuario validando contra el servicio PLD
-func (s *UserService) CreateUser(user *domain.User) error {
+func (s *UserService) CreateUser(ctx context.Context, user *domain.User) error {
 	// Validar que el email no exista
-	existingUser, err := s.userRepo.GetByEmail(user.Email)
+	existingUser, err := s.getByEmail(ctx, user.Email)
 	if err == nil && existingUser != nil {
-		return errors.New("el email ya está registrado")
+		return domain.ErrEmailTaken
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
 	}
 
 	// Validar contra el servicio PLD
-	pldResponse, err := s.pldService.ValidateUser(user.IDNumber, user.Name, user.Email)
+	pldResponse, screening, err := s.screener.screen(ctx, user.PLDRequest(), nil)
 	if err != nil {
-		return errors.New("error validando usuario con servicio PLD")
+		return err
 	}
 
 	if pldResponse.IsBlacklisted {
-		return errors.New("usuario en lista negra: " + pldResponse.Reason)
+		s.recordRejection(ctx, user, pldResponse, screening)
+		return domain.Errorf(domain.ErrBlacklisted, "usuario en lista negra: %s", pldResponse.Reason)
 	}
 
 	// Encriptar contraseña
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(user.Password)
 	if err != nil {
-		return errors.New("error encriptando contraseña")
+		return err
+	}
+	user.Password = hashedPassword
+
+	// El registro público nunca otorga privilegios: los administradores se designan con
+	// cmd/user-role sobre una cuenta existente
+	user.Role = domain.RoleUser
+
+	// Las coincidencias no concluyentes quedan pendientes de revisión de cumplimiento
+	user.Status = domain.UserStatusActive
+	if pldResponse.Status == domain.ScreeningStatusReview {
+		user.Status = domain.UserStatusPendingReview
+		user.ReviewReason = pldResponse.Reason
 	}
-	user.Password = string(hashedPassword)
 
 	// Establecer timestamps
 	now := time.Now()
@@ -54,26 +77,158 @@ func (s *UserService) CreateUser(user *domain.User) error {
 	user.UpdatedAt = now
 
 	// Guardar en base de datos
-	return s.userRepo.Create(user)
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	if err := s.userRepo.Create(dbCtx, user); err != nil {
+		return err
+	}
+
+	// Vincular el screening con el usuario creado
+	if err := s.screeningRepo.LinkUser(dbCtx, screening.ID, user.ID); err != nil {
+		log.Printf("error vinculando screening %d con usuario %d: %v", screening.ID, user.ID, err)
+	}
+
+	return nil
 }
 
 // GetUser obtiene un usuario por ID
-func (s *UserService) GetUser(id uint) (*domain.User, error) {
-	return s.userRepo.GetByID(id)
+func (s *UserService) GetUser(ctx context.Context, id uint) (*domain.User, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.userRepo.GetByID(dbCtx, id)
 }
 
 // GetUserByEmail obtiene un usuario por email
-func (s *UserService) GetUserByEmail(email string) (*domain.User, error) {
-	return s.userRepo.GetByEmail(email)
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return s.getByEmail(ctx, email)
 }
 
 // UpdateUser actualiza un usuario
-func (s *UserService) UpdateUser(user *domain.User) error {
+func (s *UserService) UpdateUser(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now()
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.userRepo.Update(dbCtx, user)
+}
+
+// UpdateProfile aplica una actualización parcial al perfil del usuario. Si cambia la
+// identidad enviada al servicio PLD (nombre, email, número de identificación o datos
+// estructurados) el usuario se valida de nuevo: si la nueva identidad está en lista negra el
+// cambio se rechaza y la cuenta queda marcada, y si la coincidencia no es concluyente el
+// usuario queda pendiente de revisión de cumplimiento. Solo se escriben los datos de perfil, por
+// lo que un re-screening o una decisión de revisión en paralelo no se pierden
+func (s *UserService) UpdateProfile(ctx context.Context, id uint, update domain.UserUpdate) (*domain.User, error) {
+	current, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+
+	user := *current
+	update.Apply(&user)
+	if user.GivenNames != "" && user.PaternalSurname == "" {
+		return nil, domain.NewError(domain.ErrInvalidInput, "el apellido paterno es obligatorio si se indican los nombres de pila")
+	}
+
+	// Validar que el nuevo email no pertenezca a otro usuario
+	if user.Email != current.Email {
+		existingUser, err := s.getByEmail(ctx, user.Email)
+		if err != nil {
+			return nil, err
+		}
+		if existingUser != nil && existingUser.ID != user.ID {
+			return nil, domain.ErrEmailTaken
+		}
+	}
+
+	markForReview := false
+	if user.PLDRequest() != current.PLDRequest() {
+		pldResponse, _, err := s.screener.screen(ctx, user.PLDRequest(), &user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if pldResponse.IsBlacklisted {
+			s.flagUser(ctx, current.ID, pldResponse)
+			return nil, domain.Errorf(domain.ErrBlacklisted, "usuario en lista negra: %s", pldResponse.Reason)
+		}
+
+		// El estado solo pasa a revisión si el usuario sigue activo al guardar
+		if pldResponse.Status == domain.ScreeningStatusReview {
+			markForReview = true
+			user.ReviewReason = pldResponse.Reason
+		}
+	}
+
 	user.UpdatedAt = time.Now()
-	return s.userRepo.Update(user)
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	err = s.profileWriter.UpdateProfile(dbCtx, &user, markForReview)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	// Releer el usuario para responder con el estado y las marcas vigentes
+	return s.GetUser(ctx, id)
 }
 
 // DeleteUser elimina un usuario
-func (s *UserService) DeleteUser(id uint) error {
-	return s.userRepo.Delete(id)
+func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.userRepo.Delete(dbCtx, id)
+}
+
+// GetUserScreenings obtiene el historial de screenings PLD de un usuario
+func (s *UserService) GetUserScreenings(ctx context.Context, userID uint) ([]*domain.Screening, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.screeningRepo.ListByUserID(dbCtx, userID)
+}
+
+// recordRejection registra una solicitud de alta rechazada por el servicio PLD.
+// La contraseña del solicitante nunca se almacena
+func (s *UserService) recordRejection(ctx context.Context, user *domain.User, response *domain.PLDResponse, screening *domain.Screening) {
+	application := &domain.RejectedApplication{
+		Name:        user.Name,
+		Email:       user.Email,
+		IDNumber:    user.IDNumber,
+		Reason:      response.Reason,
+		Provider:    screening.Provider,
+		ScreeningID: &screening.ID,
+		CreatedAt:   time.Now(),
+	}
+	if application.Reason == "" {
+		application.Reason = "Usuario en lista negra"
+	}
+
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+	if err := s.rejectedRepo.Create(dbCtx, application); err != nil {
+		log.Printf("error registrando solicitud rechazada de %s: %v", user.Email, err)
+	}
+}
+
+// flagUser marca al usuario cuya nueva identidad está en lista negra, igual que el
+// re-screening periódico. Solo se escribe la marca: la identidad registrada no cambia
+func (s *UserService) flagUser(ctx context.Context, id uint, response *domain.PLDResponse) {
+	dbCtx, cancel := withTimeout(context.WithoutCancel(ctx), s.timeouts.Database)
+	defer cancel()
+	flagged, err := s.profileWriter.Flag(dbCtx, id, response.Reason, time.Now())
+	if err != nil {
+		log.Printf("error marcando usuario %d: %v", id, err)
+		return
+	}
+	if flagged {
+		log.Printf("usuario %d marcado en lista negra al actualizar su perfil: %s", id, response.Reason)
+	}
+}
+
+// getByEmail busca un usuario por email aplicando el plazo de base de datos
+func (s *UserService) getByEmail(ctx context.Context, email string) (*domain.User, error) {
+	dbCtx, cancel := withTimeout(ctx, s.timeouts.Database)
+	defer cancel()
+	return s.userRepo.GetByEmail(dbCtx, email)
 }
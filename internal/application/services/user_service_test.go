@@ -1,8 +1,12 @@
 package services
 
 import (
+	"context"
 	"crabi-test/internal/domain"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -20,34 +24,61 @@ func NewMockUserRepository() *MockUserRepository {
 	}
 }
 
-func (m *MockUserRepository) Create(user *domain.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	user.ID = uint(len(m.users) + 1)
 	m.users[user.ID] = user
 	m.emails[user.Email] = user
 	return nil
 }
 
-func (m *MockUserRepository) GetByID(id uint) (*domain.User, error) {
+func (m *MockUserRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	if user, exists := m.users[id]; exists {
 		return user, nil
 	}
 	return nil, nil
 }
 
-func (m *MockUserRepository) GetByEmail(email string) (*domain.User, error) {
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	if user, exists := m.emails[email]; exists {
 		return user, nil
 	}
 	return nil, nil
 }
 
-func (m *MockUserRepository) Update(user *domain.User) error {
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	m.users[user.ID] = user
 	m.emails[user.Email] = user
 	return nil
 }
 
-func (m *MockUserRepository) Delete(id uint) error {
+// UpdateProfile permite usar MockUserRepository como ports.UserProfileWriter. Solo escribe los
+// datos de perfil sobre el usuario guardado, como la base de datos
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, user *domain.User, markForReview bool) error {
+	stored, exists := m.users[user.ID]
+	if !exists {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+
+	delete(m.emails, stored.Email)
+	stored.Name = user.Name
+	stored.Email = user.Email
+	stored.IDNumber = user.IDNumber
+	stored.GivenNames = user.GivenNames
+	stored.PaternalSurname = user.PaternalSurname
+	stored.MaternalSurname = user.MaternalSurname
+	stored.DateOfBirth = user.DateOfBirth
+	stored.Nationality = user.Nationality
+	stored.UpdatedAt = user.UpdatedAt
+	m.emails[stored.Email] = stored
+
+	if markForReview && stored.Status == domain.UserStatusActive {
+		stored.Status = domain.UserStatusPendingReview
+		stored.ReviewReason = user.ReviewReason
+	}
+	return nil
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
 	if user, exists := m.users[id]; exists {
 		delete(m.users, id)
 		delete(m.emails, user.Email)
@@ -66,7 +97,7 @@ func NewMockPLDService(shouldBlacklist bool) *MockPLDService {
 	}
 }
 
-func (m *MockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *MockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	if m.shouldBlacklist {
 		return &domain.PLDResponse{
 			IsBlacklisted: true,
@@ -82,10 +113,84 @@ func (m *MockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLD
 	}, nil
 }
 
+// MockScreeningRepository para testing
+type MockScreeningRepository struct {
+	mu         sync.Mutex
+	screenings []*domain.Screening
+}
+
+func NewMockScreeningRepository() *MockScreeningRepository {
+	return &MockScreeningRepository{}
+}
+
+func (m *MockScreeningRepository) Create(ctx context.Context, screening *domain.Screening) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	screening.ID = uint(len(m.screenings) + 1)
+	m.screenings = append(m.screenings, screening)
+	return nil
+}
+
+func (m *MockScreeningRepository) LinkUser(ctx context.Context, screeningID, userID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, screening := range m.screenings {
+		if screening.ID == screeningID {
+			id := userID
+			screening.UserID = &id
+		}
+	}
+	return nil
+}
+
+func (m *MockScreeningRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.Screening, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []*domain.Screening
+	for _, screening := range m.screenings {
+		if screening.UserID != nil && *screening.UserID == userID {
+			result = append(result, screening)
+		}
+	}
+	return result, nil
+}
+
+// MockRejectedApplicationRepository para testing
+type MockRejectedApplicationRepository struct {
+	applications []*domain.RejectedApplication
+}
+
+func NewMockRejectedApplicationRepository() *MockRejectedApplicationRepository {
+	return &MockRejectedApplicationRepository{}
+}
+
+func (m *MockRejectedApplicationRepository) Create(ctx context.Context, application *domain.RejectedApplication) error {
+	application.ID = uint(len(m.applications) + 1)
+	m.applications = append(m.applications, application)
+	return nil
+}
+
+func (m *MockRejectedApplicationRepository) List(ctx context.Context, filter domain.RejectedApplicationFilter) ([]*domain.RejectedApplication, error) {
+	var result []*domain.RejectedApplication
+	for _, application := range m.applications {
+		if !filter.From.IsZero() && application.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && application.CreatedAt.After(filter.To) {
+			continue
+		}
+		result = append(result, application)
+	}
+	return result, nil
+}
+
 func TestUserService_CreateUser_Success(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -94,7 +199,7 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -115,7 +220,7 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 func TestUserService_CreateUser_Blacklisted(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(true)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -124,7 +229,7 @@ func TestUserService_CreateUser_Blacklisted(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error for blacklisted user")
 	}
@@ -137,7 +242,7 @@ func TestUserService_CreateUser_Blacklisted(t *testing.T) {
 func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario existente
 	existingUser := &domain.User{
@@ -146,7 +251,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 		Password: "password123",
 		IDNumber: "12345678",
 	}
-	userRepo.Create(existingUser)
+	userRepo.Create(context.Background(), existingUser)
 
 	// Intentar crear usuario con mismo email
 	newUser := &domain.User{
@@ -156,7 +261,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 		IDNumber: "87654321",
 	}
 
-	err := userService.CreateUser(newUser)
+	err := userService.CreateUser(context.Background(), newUser)
 	if err == nil {
 		t.Error("Expected error for duplicate email")
 	}
@@ -169,7 +274,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 func TestUserService_GetUser(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario
 	user := &domain.User{
@@ -180,10 +285,10 @@ func TestUserService_GetUser(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test GetUser
-	retrievedUser, err := userService.GetUser(1)
+	retrievedUser, err := userService.GetUser(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -195,10 +300,10 @@ func TestUserService_GetUser(t *testing.T) {
 func TestUserService_GetUser_NotFound(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test GetUser con ID inexistente
-	retrievedUser, err := userService.GetUser(999)
+	retrievedUser, err := userService.GetUser(context.Background(), 999)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -210,7 +315,7 @@ func TestUserService_GetUser_NotFound(t *testing.T) {
 func TestUserService_GetUserByEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario
 	user := &domain.User{
@@ -221,10 +326,10 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test GetUserByEmail
-	retrievedUser, err := userService.GetUserByEmail("juan.perez@email.com")
+	retrievedUser, err := userService.GetUserByEmail(context.Background(), "juan.perez@email.com")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -236,10 +341,10 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 func TestUserService_GetUserByEmail_NotFound(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test GetUserByEmail con email inexistente
-	retrievedUser, err := userService.GetUserByEmail("nonexistent@email.com")
+	retrievedUser, err := userService.GetUserByEmail(context.Background(), "nonexistent@email.com")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -251,7 +356,7 @@ func TestUserService_GetUserByEmail_NotFound(t *testing.T) {
 func TestUserService_UpdateUser(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario
 	user := &domain.User{
@@ -263,11 +368,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test UpdateUser
 	user.Name = "Juan Carlos Pérez"
-	err := userService.UpdateUser(user)
+	err := userService.UpdateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -276,7 +381,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 func TestUserService_UpdateUser_NotFound(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test UpdateUser con usuario inexistente
 	user := &domain.User{
@@ -289,7 +394,7 @@ func TestUserService_UpdateUser_NotFound(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	err := userService.UpdateUser(user)
+	err := userService.UpdateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -298,7 +403,7 @@ func TestUserService_UpdateUser_NotFound(t *testing.T) {
 func TestUserService_DeleteUser(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario
 	user := &domain.User{
@@ -309,10 +414,10 @@ func TestUserService_DeleteUser(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test DeleteUser
-	err := userService.DeleteUser(1)
+	err := userService.DeleteUser(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -321,10 +426,10 @@ func TestUserService_DeleteUser(t *testing.T) {
 func TestUserService_DeleteUser_NotFound(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test DeleteUser con ID inexistente
-	err := userService.DeleteUser(999)
+	err := userService.DeleteUser(context.Background(), 999)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -333,7 +438,7 @@ func TestUserService_DeleteUser_NotFound(t *testing.T) {
 func TestUserService_CreateUser_WithExistingUser(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario existente
 	existingUser := &domain.User{
@@ -344,7 +449,7 @@ func TestUserService_CreateUser_WithExistingUser(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(existingUser)
+	userRepo.Create(context.Background(), existingUser)
 
 	// Crear nuevo usuario
 	newUser := &domain.User{
@@ -354,7 +459,7 @@ func TestUserService_CreateUser_WithExistingUser(t *testing.T) {
 		IDNumber: "22222222",
 	}
 
-	err := userService.CreateUser(newUser)
+	err := userService.CreateUser(context.Background(), newUser)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -367,7 +472,7 @@ func TestUserService_CreateUser_WithExistingUser(t *testing.T) {
 func TestUserService_GetUser_WithMultipleUsers(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear múltiples usuarios
 	users := []*domain.User{
@@ -399,12 +504,12 @@ func TestUserService_GetUser_WithMultipleUsers(t *testing.T) {
 
 	// Crear usuarios
 	for _, user := range users {
-		userRepo.Create(user)
+		userRepo.Create(context.Background(), user)
 	}
 
 	// Test GetUser para cada usuario
 	for i, user := range users {
-		retrievedUser, err := userService.GetUser(uint(i + 1))
+		retrievedUser, err := userService.GetUser(context.Background(), uint(i+1))
 		if err != nil {
 			t.Errorf("Expected no error for user %d, got %v", i+1, err)
 		}
@@ -420,7 +525,7 @@ func TestUserService_GetUser_WithMultipleUsers(t *testing.T) {
 func TestUserService_GetUserByEmail_WithMultipleUsers(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear múltiples usuarios
 	users := []*domain.User{
@@ -444,12 +549,12 @@ func TestUserService_GetUserByEmail_WithMultipleUsers(t *testing.T) {
 
 	// Crear usuarios
 	for _, user := range users {
-		userRepo.Create(user)
+		userRepo.Create(context.Background(), user)
 	}
 
 	// Test GetUserByEmail para cada usuario
 	for _, user := range users {
-		retrievedUser, err := userService.GetUserByEmail(user.Email)
+		retrievedUser, err := userService.GetUserByEmail(context.Background(), user.Email)
 		if err != nil {
 			t.Errorf("Expected no error for email %s, got %v", user.Email, err)
 		}
@@ -465,7 +570,7 @@ func TestUserService_GetUserByEmail_WithMultipleUsers(t *testing.T) {
 func TestUserService_UpdateUser_WithMultipleUpdates(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario
 	user := &domain.User{
@@ -477,7 +582,7 @@ func TestUserService_UpdateUser_WithMultipleUpdates(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Múltiples actualizaciones
 	updates := []struct {
@@ -495,13 +600,13 @@ func TestUserService_UpdateUser_WithMultipleUpdates(t *testing.T) {
 		user.Email = update.email
 		user.Password = update.password
 
-		err := userService.UpdateUser(user)
+		err := userService.UpdateUser(context.Background(), user)
 		if err != nil {
 			t.Errorf("Expected no error for update %d, got %v", i+1, err)
 		}
 
 		// Verificar actualización
-		retrievedUser, err := userService.GetUser(user.ID)
+		retrievedUser, err := userService.GetUser(context.Background(), user.ID)
 		if err != nil {
 			t.Errorf("Expected no error getting user after update %d, got %v", i+1, err)
 		}
@@ -514,7 +619,7 @@ func TestUserService_UpdateUser_WithMultipleUpdates(t *testing.T) {
 func TestUserService_DeleteUser_WithMultipleUsers(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear múltiples usuarios
 	users := []*domain.User{
@@ -546,18 +651,18 @@ func TestUserService_DeleteUser_WithMultipleUsers(t *testing.T) {
 
 	// Crear usuarios
 	for _, user := range users {
-		userRepo.Create(user)
+		userRepo.Create(context.Background(), user)
 	}
 
 	// Eliminar usuarios en orden inverso
 	for i := len(users) - 1; i >= 0; i-- {
-		err := userService.DeleteUser(uint(i + 1))
+		err := userService.DeleteUser(context.Background(), uint(i+1))
 		if err != nil {
 			t.Errorf("Expected no error deleting user %d, got %v", i+1, err)
 		}
 
 		// Verificar que el usuario fue eliminado
-		retrievedUser, err := userService.GetUser(uint(i + 1))
+		retrievedUser, err := userService.GetUser(context.Background(), uint(i+1))
 		if err != nil {
 			t.Errorf("Expected no error checking deleted user %d, got %v", i+1, err)
 		}
@@ -570,7 +675,7 @@ func TestUserService_DeleteUser_WithMultipleUsers(t *testing.T) {
 func TestUserService_CreateUser_WithSpecialCharacters(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con caracteres especiales en el nombre
 	user := &domain.User{
@@ -580,7 +685,7 @@ func TestUserService_CreateUser_WithSpecialCharacters(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -593,7 +698,7 @@ func TestUserService_CreateUser_WithSpecialCharacters(t *testing.T) {
 func TestUserService_CreateUser_WithUnicodeCharacters(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con caracteres Unicode
 	user := &domain.User{
@@ -603,7 +708,7 @@ func TestUserService_CreateUser_WithUnicodeCharacters(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -616,7 +721,7 @@ func TestUserService_CreateUser_WithUnicodeCharacters(t *testing.T) {
 func TestUserService_CreateUser_WithVeryLongName(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con nombre muy largo
 	longName := "Juan Carlos María José Francisco de Paula Juan Nepomuceno María de los Remedios Cipriano de la Santísima Trinidad Ruiz y Picasso"
@@ -627,7 +732,7 @@ func TestUserService_CreateUser_WithVeryLongName(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -640,7 +745,7 @@ func TestUserService_CreateUser_WithVeryLongName(t *testing.T) {
 func TestUserService_CreateUser_WithPLDServiceError(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &ErrorMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -649,7 +754,7 @@ func TestUserService_CreateUser_WithPLDServiceError(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from PLD service")
 	}
@@ -658,14 +763,14 @@ func TestUserService_CreateUser_WithPLDServiceError(t *testing.T) {
 // ErrorMockPLDService para testing de errores del PLD
 type ErrorMockPLDService struct{}
 
-func (m *ErrorMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *ErrorMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return nil, fmt.Errorf("PLD service error")
 }
 
 func TestUserService_CreateUser_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -674,7 +779,7 @@ func TestUserService_CreateUser_WithRepositoryError(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from repository")
 	}
@@ -683,33 +788,41 @@ func TestUserService_CreateUser_WithRepositoryError(t *testing.T) {
 // ErrorMockUserRepository para testing de errores del repositorio
 type ErrorMockUserRepository struct{}
 
-func (m *ErrorMockUserRepository) Create(user *domain.User) error {
+func (m *ErrorMockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	return fmt.Errorf("database error")
 }
 
-func (m *ErrorMockUserRepository) GetByID(id uint) (*domain.User, error) {
+func (m *ErrorMockUserRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	return nil, fmt.Errorf("database error")
 }
 
-func (m *ErrorMockUserRepository) GetByEmail(email string) (*domain.User, error) {
+func (m *ErrorMockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	return nil, fmt.Errorf("database error")
 }
 
-func (m *ErrorMockUserRepository) Update(user *domain.User) error {
+func (m *ErrorMockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	return fmt.Errorf("database error")
 }
 
-func (m *ErrorMockUserRepository) Delete(id uint) error {
+func (m *ErrorMockUserRepository) UpdateProfile(ctx context.Context, user *domain.User, markForReview bool) error {
+	return fmt.Errorf("database error")
+}
+
+func (m *ErrorMockUserRepository) Flag(ctx context.Context, id uint, reason string, flaggedAt time.Time) (bool, error) {
+	return false, fmt.Errorf("database error")
+}
+
+func (m *ErrorMockUserRepository) Delete(ctx context.Context, id uint) error {
 	return fmt.Errorf("database error")
 }
 
 func TestUserService_GetUser_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test GetUser con error del repositorio
-	user, err := userService.GetUser(1)
+	user, err := userService.GetUser(context.Background(), 1)
 	if err == nil {
 		t.Error("Expected error from repository")
 	}
@@ -721,10 +834,10 @@ func TestUserService_GetUser_WithRepositoryError(t *testing.T) {
 func TestUserService_GetUserByEmail_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test GetUserByEmail con error del repositorio
-	user, err := userService.GetUserByEmail("test@email.com")
+	user, err := userService.GetUserByEmail(context.Background(), "test@email.com")
 	if err == nil {
 		t.Error("Expected error from repository")
 	}
@@ -736,7 +849,7 @@ func TestUserService_GetUserByEmail_WithRepositoryError(t *testing.T) {
 func TestUserService_UpdateUser_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		ID:        1,
@@ -749,7 +862,7 @@ func TestUserService_UpdateUser_WithRepositoryError(t *testing.T) {
 	}
 
 	// Test UpdateUser con error del repositorio
-	err := userService.UpdateUser(user)
+	err := userService.UpdateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from repository")
 	}
@@ -758,10 +871,10 @@ func TestUserService_UpdateUser_WithRepositoryError(t *testing.T) {
 func TestUserService_DeleteUser_WithRepositoryError(t *testing.T) {
 	userRepo := &ErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test DeleteUser con error del repositorio
-	err := userService.DeleteUser(1)
+	err := userService.DeleteUser(context.Background(), 1)
 	if err == nil {
 		t.Error("Expected error from repository")
 	}
@@ -770,7 +883,7 @@ func TestUserService_DeleteUser_WithRepositoryError(t *testing.T) {
 func TestUserService_CreateUser_WithPLDBlacklistedResponse(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &BlacklistedMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -779,7 +892,7 @@ func TestUserService_CreateUser_WithPLDBlacklistedResponse(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error for blacklisted user")
 	}
@@ -792,7 +905,7 @@ func TestUserService_CreateUser_WithPLDBlacklistedResponse(t *testing.T) {
 // BlacklistedMockPLDService para testing de usuarios en lista negra
 type BlacklistedMockPLDService struct{}
 
-func (m *BlacklistedMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *BlacklistedMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return &domain.PLDResponse{
 		IsBlacklisted: true,
 		Status:        "blacklisted",
@@ -803,7 +916,7 @@ func (m *BlacklistedMockPLDService) ValidateUser(idNumber, name, email string) (
 func TestUserService_CreateUser_WithPLDServiceTimeout(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &TimeoutMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -812,7 +925,7 @@ func TestUserService_CreateUser_WithPLDServiceTimeout(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from PLD service timeout")
 	}
@@ -821,14 +934,14 @@ func TestUserService_CreateUser_WithPLDServiceTimeout(t *testing.T) {
 // TimeoutMockPLDService para testing de timeouts
 type TimeoutMockPLDService struct{}
 
-func (m *TimeoutMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *TimeoutMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return nil, fmt.Errorf("PLD service timeout")
 }
 
 func TestUserService_CreateUser_WithDuplicateEmailInRepository(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario existente
 	existingUser := &domain.User{
@@ -839,7 +952,7 @@ func TestUserService_CreateUser_WithDuplicateEmailInRepository(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(existingUser)
+	userRepo.Create(context.Background(), existingUser)
 
 	// Intentar crear usuario con mismo email
 	newUser := &domain.User{
@@ -849,7 +962,7 @@ func TestUserService_CreateUser_WithDuplicateEmailInRepository(t *testing.T) {
 		IDNumber: "22222222",
 	}
 
-	err := userService.CreateUser(newUser)
+	err := userService.CreateUser(context.Background(), newUser)
 	if err == nil {
 		t.Error("Expected error for duplicate email")
 	}
@@ -862,7 +975,7 @@ func TestUserService_CreateUser_WithDuplicateEmailInRepository(t *testing.T) {
 func TestUserService_CreateUser_WithSpecialCharactersInEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con email que contiene caracteres especiales
 	user := &domain.User{
@@ -872,7 +985,7 @@ func TestUserService_CreateUser_WithSpecialCharactersInEmail(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -885,7 +998,7 @@ func TestUserService_CreateUser_WithSpecialCharactersInEmail(t *testing.T) {
 func TestUserService_CreateUser_WithVeryLongEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con email muy largo
 	longEmail := "very.long.email.address.that.exceeds.normal.length.but.should.still.be.valid@very.long.domain.name.com"
@@ -896,7 +1009,7 @@ func TestUserService_CreateUser_WithVeryLongEmail(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -909,7 +1022,7 @@ func TestUserService_CreateUser_WithVeryLongEmail(t *testing.T) {
 func TestUserService_CreateUser_WithNumericName(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con nombre que contiene números
 	user := &domain.User{
@@ -919,7 +1032,7 @@ func TestUserService_CreateUser_WithNumericName(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -932,7 +1045,7 @@ func TestUserService_CreateUser_WithNumericName(t *testing.T) {
 func TestUserService_CreateUser_WithSpecialCharactersInPassword(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test con contraseña que contiene caracteres especiales
 	user := &domain.User{
@@ -942,7 +1055,7 @@ func TestUserService_CreateUser_WithSpecialCharactersInPassword(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -955,7 +1068,7 @@ func TestUserService_CreateUser_WithSpecialCharactersInPassword(t *testing.T) {
 func TestUserService_CreateUser_WithPLDServiceNetworkError(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &NetworkErrorMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -964,7 +1077,7 @@ func TestUserService_CreateUser_WithPLDServiceNetworkError(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from PLD service network error")
 	}
@@ -973,17 +1086,17 @@ func TestUserService_CreateUser_WithPLDServiceNetworkError(t *testing.T) {
 // NetworkErrorMockPLDService para testing de errores de red
 type NetworkErrorMockPLDService struct{}
 
-func (m *NetworkErrorMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *NetworkErrorMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return nil, fmt.Errorf("network timeout")
 }
 
 func TestUserService_GetUser_WithDatabaseConnectionError(t *testing.T) {
 	userRepo := &ConnectionErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test GetUser con error de conexión a base de datos
-	user, err := userService.GetUser(1)
+	user, err := userService.GetUser(context.Background(), 1)
 	if err == nil {
 		t.Error("Expected error from database connection")
 	}
@@ -995,33 +1108,41 @@ func TestUserService_GetUser_WithDatabaseConnectionError(t *testing.T) {
 // ConnectionErrorMockUserRepository para testing de errores de conexión
 type ConnectionErrorMockUserRepository struct{}
 
-func (m *ConnectionErrorMockUserRepository) Create(user *domain.User) error {
+func (m *ConnectionErrorMockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	return fmt.Errorf("database connection failed")
 }
 
-func (m *ConnectionErrorMockUserRepository) GetByID(id uint) (*domain.User, error) {
+func (m *ConnectionErrorMockUserRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	return nil, fmt.Errorf("database connection failed")
 }
 
-func (m *ConnectionErrorMockUserRepository) GetByEmail(email string) (*domain.User, error) {
+func (m *ConnectionErrorMockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	return nil, fmt.Errorf("database connection failed")
 }
 
-func (m *ConnectionErrorMockUserRepository) Update(user *domain.User) error {
+func (m *ConnectionErrorMockUserRepository) Update(ctx context.Context, user *domain.User) error {
+	return fmt.Errorf("database connection failed")
+}
+
+func (m *ConnectionErrorMockUserRepository) UpdateProfile(ctx context.Context, user *domain.User, markForReview bool) error {
 	return fmt.Errorf("database connection failed")
 }
 
-func (m *ConnectionErrorMockUserRepository) Delete(id uint) error {
+func (m *ConnectionErrorMockUserRepository) Flag(ctx context.Context, id uint, reason string, flaggedAt time.Time) (bool, error) {
+	return false, fmt.Errorf("database connection failed")
+}
+
+func (m *ConnectionErrorMockUserRepository) Delete(ctx context.Context, id uint) error {
 	return fmt.Errorf("database connection failed")
 }
 
 func TestUserService_GetUserByEmail_WithDatabaseConnectionError(t *testing.T) {
 	userRepo := &ConnectionErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test GetUserByEmail con error de conexión a base de datos
-	user, err := userService.GetUserByEmail("test@email.com")
+	user, err := userService.GetUserByEmail(context.Background(), "test@email.com")
 	if err == nil {
 		t.Error("Expected error from database connection")
 	}
@@ -1033,7 +1154,7 @@ func TestUserService_GetUserByEmail_WithDatabaseConnectionError(t *testing.T) {
 func TestUserService_UpdateUser_WithDatabaseConnectionError(t *testing.T) {
 	userRepo := &ConnectionErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		ID:        1,
@@ -1046,7 +1167,7 @@ func TestUserService_UpdateUser_WithDatabaseConnectionError(t *testing.T) {
 	}
 
 	// Test UpdateUser con error de conexión a base de datos
-	err := userService.UpdateUser(user)
+	err := userService.UpdateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from database connection")
 	}
@@ -1055,10 +1176,10 @@ func TestUserService_UpdateUser_WithDatabaseConnectionError(t *testing.T) {
 func TestUserService_DeleteUser_WithDatabaseConnectionError(t *testing.T) {
 	userRepo := &ConnectionErrorMockUserRepository{}
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test DeleteUser con error de conexión a base de datos
-	err := userService.DeleteUser(1)
+	err := userService.DeleteUser(context.Background(), 1)
 	if err == nil {
 		t.Error("Expected error from database connection")
 	}
@@ -1067,7 +1188,7 @@ func TestUserService_DeleteUser_WithDatabaseConnectionError(t *testing.T) {
 func TestUserService_CreateUser_WithPLDServiceUnavailable(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &UnavailableMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -1076,7 +1197,7 @@ func TestUserService_CreateUser_WithPLDServiceUnavailable(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from PLD service unavailable")
 	}
@@ -1085,14 +1206,14 @@ func TestUserService_CreateUser_WithPLDServiceUnavailable(t *testing.T) {
 // UnavailableMockPLDService para testing de servicio no disponible
 type UnavailableMockPLDService struct{}
 
-func (m *UnavailableMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *UnavailableMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return nil, fmt.Errorf("PLD service unavailable")
 }
 
 func TestUserService_CreateUser_WithPLDServiceRateLimit(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &RateLimitMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -1101,7 +1222,7 @@ func TestUserService_CreateUser_WithPLDServiceRateLimit(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error from PLD service rate limit")
 	}
@@ -1110,7 +1231,7 @@ func TestUserService_CreateUser_WithPLDServiceRateLimit(t *testing.T) {
 // RateLimitMockPLDService para testing de límite de tasa
 type RateLimitMockPLDService struct{}
 
-func (m *RateLimitMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *RateLimitMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return nil, fmt.Errorf("rate limit exceeded")
 }
 
@@ -1118,7 +1239,7 @@ func (m *RateLimitMockPLDService) ValidateUser(idNumber, name, email string) (*d
 func TestUserService_CreateUser_WithEmptyName(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "",
@@ -1127,7 +1248,7 @@ func TestUserService_CreateUser_WithEmptyName(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for empty name, got %v", err)
 	}
@@ -1136,7 +1257,7 @@ func TestUserService_CreateUser_WithEmptyName(t *testing.T) {
 func TestUserService_CreateUser_WithEmptyEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Test User",
@@ -1145,7 +1266,7 @@ func TestUserService_CreateUser_WithEmptyEmail(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for empty email, got %v", err)
 	}
@@ -1154,7 +1275,7 @@ func TestUserService_CreateUser_WithEmptyEmail(t *testing.T) {
 func TestUserService_CreateUser_WithEmptyPassword(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Test User",
@@ -1163,7 +1284,7 @@ func TestUserService_CreateUser_WithEmptyPassword(t *testing.T) {
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for empty password, got %v", err)
 	}
@@ -1172,7 +1293,7 @@ func TestUserService_CreateUser_WithEmptyPassword(t *testing.T) {
 func TestUserService_CreateUser_WithEmptyIDNumber(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Test User",
@@ -1181,7 +1302,7 @@ func TestUserService_CreateUser_WithEmptyIDNumber(t *testing.T) {
 		IDNumber: "",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for empty ID number, got %v", err)
 	}
@@ -1190,7 +1311,7 @@ func TestUserService_CreateUser_WithEmptyIDNumber(t *testing.T) {
 func TestUserService_CreateUser_WithAllEmptyFields(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "",
@@ -1199,7 +1320,7 @@ func TestUserService_CreateUser_WithAllEmptyFields(t *testing.T) {
 		IDNumber: "",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for all empty fields, got %v", err)
 	}
@@ -1208,9 +1329,9 @@ func TestUserService_CreateUser_WithAllEmptyFields(t *testing.T) {
 func TestUserService_GetUser_WithZeroID(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
-	user, err := userService.GetUser(0)
+	user, err := userService.GetUser(context.Background(), 0)
 	if err != nil {
 		t.Errorf("Expected no error for zero ID, got %v", err)
 	}
@@ -1223,9 +1344,9 @@ func TestUserService_GetUser_WithZeroID(t *testing.T) {
 func TestUserService_GetUserByEmail_WithEmptyEmail(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
-	user, err := userService.GetUserByEmail("")
+	user, err := userService.GetUserByEmail(context.Background(), "")
 	if err != nil {
 		t.Errorf("Expected no error for empty email, got %v", err)
 	}
@@ -1238,9 +1359,9 @@ func TestUserService_GetUserByEmail_WithEmptyEmail(t *testing.T) {
 func TestUserService_DeleteUser_WithZeroID(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
-	err := userService.DeleteUser(0)
+	err := userService.DeleteUser(context.Background(), 0)
 	if err != nil {
 		t.Errorf("Expected no error for zero ID, got %v", err)
 	}
@@ -1249,7 +1370,7 @@ func TestUserService_DeleteUser_WithZeroID(t *testing.T) {
 func TestUserService_CreateUser_WithPLDServiceReturningEmptyResponse(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &EmptyResponseMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Test User",
@@ -1258,7 +1379,7 @@ func TestUserService_CreateUser_WithPLDServiceReturningEmptyResponse(t *testing.
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for empty PLD response, got %v", err)
 	}
@@ -1266,14 +1387,14 @@ func TestUserService_CreateUser_WithPLDServiceReturningEmptyResponse(t *testing.
 
 type EmptyResponseMockPLDService struct{}
 
-func (m *EmptyResponseMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *EmptyResponseMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return &domain.PLDResponse{}, nil
 }
 
 func TestUserService_CreateUser_WithPLDServiceReturningPartialResponse(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := &PartialResponseMockPLDService{}
-	userService := NewUserService(userRepo, pldService)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Test User",
@@ -1282,7 +1403,7 @@ func TestUserService_CreateUser_WithPLDServiceReturningPartialResponse(t *testin
 		IDNumber: "12345678",
 	}
 
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error for partial PLD response, got %v", err)
 	}
@@ -1290,9 +1411,431 @@ func TestUserService_CreateUser_WithPLDServiceReturningPartialResponse(t *testin
 
 type PartialResponseMockPLDService struct{}
 
-func (m *PartialResponseMockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *PartialResponseMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	return &domain.PLDResponse{
 		IsBlacklisted: false,
 		Status:        "clean",
 	}, nil
 }
+
+// BlockingMockPLDService bloquea hasta que el contexto se cancele
+type BlockingMockPLDService struct {
+	observedErr error
+}
+
+func (m *BlockingMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	<-ctx.Done()
+	m.observedErr = ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestUserService_CreateUser_ClientCancellationAbortsPLDCall(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	pldService := &BlockingMockPLDService{}
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+
+	err := userService.CreateUser(ctx, user)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if !errors.Is(pldService.observedErr, context.Canceled) {
+		t.Errorf("Expected PLD call to observe cancellation, got %v", pldService.observedErr)
+	}
+	if len(userRepo.users) != 0 {
+		t.Error("Expected no user to be persisted")
+	}
+}
+
+func TestUserService_CreateUser_PLDDeadline(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	pldService := &BlockingMockPLDService{}
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	userService.screener.timeouts.PLD = 20 * time.Millisecond
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+
+	err := userService.CreateUser(context.Background(), user)
+	if err == nil || err.Error() != "error validando usuario con servicio PLD" {
+		t.Fatalf("Expected PLD validation error, got %v", err)
+	}
+	if !errors.Is(pldService.observedErr, context.DeadlineExceeded) {
+		t.Errorf("Expected PLD call to hit its deadline, got %v", pldService.observedErr)
+	}
+}
+
+func TestUserService_CreateUser_AlreadyCancelledContext(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	pldService := NewMockPLDService(false)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+
+	if err := userService.CreateUser(ctx, user); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(userRepo.users) != 0 {
+		t.Error("Expected no user to be persisted")
+	}
+}
+
+func TestUserService_CreateUser_RecordsLinkedScreening(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	screeningRepo := NewMockScreeningRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), screeningRepo, NewMockRejectedApplicationRepository())
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	screenings, _ := userService.GetUserScreenings(context.Background(), user.ID)
+	if len(screenings) != 1 {
+		t.Fatalf("Expected 1 screening, got %d", len(screenings))
+	}
+	if screenings[0].Status != domain.ScreeningStatusClean {
+		t.Errorf("Expected status clean, got %s", screenings[0].Status)
+	}
+	if screenings[0].RequestPayload == "" || screenings[0].IDNumber != "12345678" {
+		t.Errorf("Expected request data to be recorded, got %+v", screenings[0])
+	}
+}
+
+func TestUserService_CreateUser_RecordsBlacklistedScreening(t *testing.T) {
+	screeningRepo := NewMockScreeningRepository()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(true), screeningRepo, NewMockRejectedApplicationRepository())
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+	if err := userService.CreateUser(context.Background(), user); err == nil {
+		t.Fatal("Expected error for blacklisted user")
+	}
+
+	if len(screeningRepo.screenings) != 1 {
+		t.Fatalf("Expected 1 screening, got %d", len(screeningRepo.screenings))
+	}
+	screening := screeningRepo.screenings[0]
+	if screening.Status != domain.ScreeningStatusBlacklisted || screening.UserID != nil {
+		t.Errorf("Expected unlinked blacklisted screening, got %+v", screening)
+	}
+}
+
+func TestUserService_CreateUser_RecordsFailedScreening(t *testing.T) {
+	screeningRepo := NewMockScreeningRepository()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, &ErrorMockPLDService{}, screeningRepo, NewMockRejectedApplicationRepository())
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+	if err := userService.CreateUser(context.Background(), user); err == nil {
+		t.Fatal("Expected error from PLD service")
+	}
+
+	if len(screeningRepo.screenings) != 1 {
+		t.Fatalf("Expected 1 screening, got %d", len(screeningRepo.screenings))
+	}
+	screening := screeningRepo.screenings[0]
+	if screening.Status != domain.ScreeningStatusError || screening.Error != "PLD service error" {
+		t.Errorf("Expected error screening, got %+v", screening)
+	}
+}
+
+func TestUserService_CreateUser_RecordsRejectedApplication(t *testing.T) {
+	screeningRepo := NewMockScreeningRepository()
+	rejectedRepo := NewMockRejectedApplicationRepository()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(true), screeningRepo, rejectedRepo)
+
+	user := &domain.User{
+		Name:     "Juan Pérez",
+		Email:    "juan.perez@email.com",
+		Password: "password123",
+		IDNumber: "12345678",
+	}
+	if err := userService.CreateUser(context.Background(), user); err == nil {
+		t.Fatal("Expected error for blacklisted user")
+	}
+
+	if len(rejectedRepo.applications) != 1 {
+		t.Fatalf("Expected 1 rejected application, got %d", len(rejectedRepo.applications))
+	}
+	application := rejectedRepo.applications[0]
+	if application.Email != "juan.perez@email.com" || application.IDNumber != "12345678" || application.Name != "Juan Pérez" {
+		t.Errorf("Unexpected applicant data %+v", application)
+	}
+	if application.Reason != "Usuario en lista negra por actividades sospechosas" {
+		t.Errorf("Expected provider reason, got %q", application.Reason)
+	}
+	if application.ScreeningID == nil || *application.ScreeningID != screeningRepo.screenings[0].ID {
+		t.Errorf("Expected rejection linked to screening, got %v", application.ScreeningID)
+	}
+}
+
+func TestUserService_CreateUser_NeverAssignsAdminRole(t *testing.T) {
+	// El email de una variable antigua o un rol enviado por el cliente no dan privilegios
+	t.Setenv("ADMIN_EMAILS", "compliance@email.com")
+
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+
+	user := &domain.User{Name: "Oficial", Email: "compliance@email.com", Password: "password123", IDNumber: "12345678", Role: domain.RoleAdmin}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.Role != domain.RoleUser {
+		t.Errorf("Expected user role, got %q", user.Role)
+	}
+}
+
+// RecordingMockPLDService registra la última solicitud recibida
+type RecordingMockPLDService struct {
+	MockPLDService
+	request domain.PLDRequest
+}
+
+func (m *RecordingMockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	m.request = request
+	return m.MockPLDService.ValidateUser(ctx, request)
+}
+
+func TestUserService_CreateUser_SendsStructuredIdentity(t *testing.T) {
+	pldService := &RecordingMockPLDService{}
+	screeningRepo := NewMockScreeningRepository()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, pldService, screeningRepo, NewMockRejectedApplicationRepository())
+
+	user := &domain.User{
+		Name:            "María de la Luz García López",
+		Email:           "maria@email.com",
+		Password:        "password123",
+		IDNumber:        "GALM850412MDFRPR05",
+		GivenNames:      "María de la Luz",
+		PaternalSurname: "García",
+		MaternalSurname: "López",
+		DateOfBirth:     "1985-04-12",
+		Nationality:     "MX",
+	}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if pldService.request != user.PLDRequest() {
+		t.Errorf("Expected PLD request %+v, got %+v", user.PLDRequest(), pldService.request)
+	}
+	if payload := screeningRepo.screenings[0].RequestPayload; !strings.Contains(payload, `"paternal_surname":"García"`) {
+		t.Errorf("Expected structured identity in recorded payload, got %s", payload)
+	}
+}
+
+func TestUserService_CreateUser_TypedErrors(t *testing.T) {
+	newUser := func() *domain.User {
+		return &domain.User{Name: "Juan Pérez", Email: "juan.perez@email.com", Password: "password123", IDNumber: "12345678"}
+	}
+
+	blacklistedRepo := NewMockUserRepository()
+	blacklisted := NewUserService(blacklistedRepo, blacklistedRepo, NewMockPLDService(true), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	if err := blacklisted.CreateUser(context.Background(), newUser()); !errors.Is(err, domain.ErrBlacklisted) {
+		t.Errorf("Expected ErrBlacklisted, got %v", err)
+	}
+
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	if err := userService.CreateUser(context.Background(), newUser()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := userService.CreateUser(context.Background(), newUser()); !errors.Is(err, domain.ErrEmailTaken) {
+		t.Errorf("Expected ErrEmailTaken, got %v", err)
+	}
+
+	unavailableRepo := NewMockUserRepository()
+	unavailable := NewUserService(unavailableRepo, unavailableRepo, &BlockingMockPLDService{}, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	unavailable.screener.timeouts.PLD = 10 * time.Millisecond
+	if err := unavailable.CreateUser(context.Background(), newUser()); !errors.Is(err, domain.ErrPLDUnavailable) {
+		t.Errorf("Expected ErrPLDUnavailable, got %v", err)
+	}
+}
+
+// newProfileTestUser crea un usuario activo para las pruebas de actualización de perfil
+func newProfileTestUser(t *testing.T, userService *UserService, email string) *domain.User {
+	t.Helper()
+	user := &domain.User{Name: "Juan Perez", Email: email, Password: "password123", IDNumber: "12345678"}
+	if err := userService.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+	return user
+}
+
+func TestUserService_UpdateProfile_RescreensIdentityChanges(t *testing.T) {
+	screeningRepo := NewMockScreeningRepository()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), screeningRepo, NewMockRejectedApplicationRepository())
+	user := newProfileTestUser(t, userService, "juan@email.com")
+
+	name := "Juan Pérez"
+	updated, err := userService.UpdateProfile(context.Background(), user.ID, domain.UserUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Name != name || updated.Email != "juan@email.com" || updated.Status != domain.UserStatusActive {
+		t.Errorf("Expected only the name to change, got %+v", updated)
+	}
+	if len(screeningRepo.screenings) != 2 || *screeningRepo.screenings[1].UserID != user.ID {
+		t.Errorf("Expected a new screening linked to the user, got %+v", screeningRepo.screenings)
+	}
+
+	// Reenviar los mismos datos no valida de nuevo
+	if _, err := userService.UpdateProfile(context.Background(), user.ID, domain.UserUpdate{Name: &name}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(screeningRepo.screenings) != 2 {
+		t.Errorf("Expected no screening without identity changes, got %d", len(screeningRepo.screenings))
+	}
+}
+
+func TestUserService_UpdateProfile_BlacklistedIdentity(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	pldService := NewMockPLDService(false)
+	userService := NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user := newProfileTestUser(t, userService, "juan@email.com")
+
+	pldService.shouldBlacklist = true
+	idNumber := "87654321"
+	_, err := userService.UpdateProfile(context.Background(), user.ID, domain.UserUpdate{IDNumber: &idNumber})
+	if !errors.Is(err, domain.ErrBlacklisted) {
+		t.Fatalf("Expected ErrBlacklisted, got %v", err)
+	}
+
+	stored, _ := userRepo.GetByID(context.Background(), user.ID)
+	if stored.IDNumber != "12345678" {
+		t.Errorf("Expected the identity change to be rejected, got %s", stored.IDNumber)
+	}
+	if stored.FlaggedAt == nil || stored.FlagReason == "" {
+		t.Errorf("Expected the user to be flagged, got %+v", stored)
+	}
+}
+
+func TestUserService_UpdateProfile_InconclusiveMatch(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user := newProfileTestUser(t, userService, "maria@email.com")
+
+	userService.screener.pldService = &ReviewMockPLDService{}
+	name := "María López"
+	updated, err := userService.UpdateProfile(context.Background(), user.ID, domain.UserUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Status != domain.UserStatusPendingReview || updated.ReviewReason == "" || updated.Name != name {
+		t.Errorf("Expected the user to be pending review, got %+v", updated)
+	}
+}
+
+// HookReviewPLDService responde una coincidencia no concluyente después de ejecutar onValidate
+type HookReviewPLDService struct {
+	ReviewMockPLDService
+	onValidate func()
+}
+
+func (m *HookReviewPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	m.onValidate()
+	return m.ReviewMockPLDService.ValidateUser(ctx, request)
+}
+
+func TestUserService_UpdateProfile_KeepsConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+
+	// Un re-screening marca al usuario mientras se valida su nueva identidad
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user := newProfileTestUser(t, userService, "juan@email.com")
+	userService.screener.pldService = &ListPLDService{onValidate: func(string) {
+		userRepo.Flag(ctx, user.ID, "Sanción reciente", time.Now())
+	}}
+
+	name := "Juan Pérez García"
+	updated, err := userService.UpdateProfile(ctx, user.ID, domain.UserUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Name != name || updated.FlaggedAt == nil || updated.FlagReason != "Sanción reciente" {
+		t.Errorf("Expected the profile change to keep the concurrent flag, got %+v", updated)
+	}
+
+	// Un administrador rechaza al usuario mientras el cambio queda en revisión: el rechazo se conserva
+	userRepo = NewMockUserRepository()
+	userService = NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user = newProfileTestUser(t, userService, "maria@email.com")
+	userService.screener.pldService = &HookReviewPLDService{onValidate: func() {
+		userRepo.users[user.ID].Status = domain.UserStatusRejected
+	}}
+
+	name = "María López"
+	updated, err = userService.UpdateProfile(ctx, user.ID, domain.UserUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Name != name || updated.Status != domain.UserStatusRejected {
+		t.Errorf("Expected the concurrent rejection to be kept, got %+v", updated)
+	}
+}
+
+func TestUserService_UpdateProfile_Errors(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo, userRepo, NewMockPLDService(false), NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
+	user := newProfileTestUser(t, userService, "juan@email.com")
+	newProfileTestUser(t, userService, "otro@email.com")
+
+	email := "otro@email.com"
+	if _, err := userService.UpdateProfile(context.Background(), user.ID, domain.UserUpdate{Email: &email}); !errors.Is(err, domain.ErrEmailTaken) {
+		t.Errorf("Expected ErrEmailTaken, got %v", err)
+	}
+
+	givenNames := "Juan"
+	if _, err := userService.UpdateProfile(context.Background(), user.ID, domain.UserUpdate{GivenNames: &givenNames}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without paternal surname, got %v", err)
+	}
+
+	if _, err := userService.UpdateProfile(context.Background(), 999, domain.UserUpdate{Email: &email}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
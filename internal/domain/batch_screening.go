@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// Estados de un lote de screening
+const (
+	BatchScreeningStatusRunning   = "running"
+	BatchScreeningStatusCompleted = "completed"
+)
+
+// BatchRowStatusPending indica una fila aún no validada. Las filas validadas usan los
+// estados de screening (clean, blacklisted, review, error)
+const BatchRowStatusPending = "pending"
+
+// Formatos de entrada de un lote de screening
+const (
+	BatchScreeningSourceJSON = "json"
+	BatchScreeningSourceCSV  = "csv"
+)
+
+// BatchScreeningJob representa un lote de prospectos validados contra el servicio PLD
+type BatchScreeningJob struct {
+	ID          uint       `json:"id"`
+	Status      string     `json:"status"`
+	Source      string     `json:"source"`
+	RequestedBy uint       `json:"requested_by"`
+	Total       int        `json:"total"`
+	Processed   int        `json:"processed"`
+	Clean       int        `json:"clean"`
+	Blacklisted int        `json:"blacklisted"`
+	Review      int        `json:"review"`
+	Failed      int        `json:"failed"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// BatchScreeningRow representa una fila de un lote y su resultado. Line es la posición
+// de la fila en el archivo recibido, empezando en 1
+type BatchScreeningRow struct {
+	ID          uint       `json:"id"`
+	JobID       uint       `json:"job_id"`
+	Line        int        `json:"line"`
+	IDNumber    string     `json:"id_number"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	Status      string     `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	MatchScore  float64    `json:"match_score"`
+	Provider    string     `json:"provider,omitempty"`
+	ScreeningID *uint      `json:"screening_id,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errores del dominio. Los servicios y repositorios los retornan directamente o como
+// categoría de un error con mensaje propio (ver NewError), de modo que la capa HTTP pueda
+// identificarlos con errors.Is sin comparar mensajes
+var (
+	// ErrNotFound indica que el recurso solicitado no existe
+	ErrNotFound = errors.New("recurso no encontrado")
+
+	// ErrInvalidInput indica datos de entrada que no cumplen las reglas del dominio
+	ErrInvalidInput = errors.New("datos de entrada inválidos")
+
+	// ErrConflict indica que la operación no es posible en el estado actual del recurso
+	ErrConflict = errors.New("conflicto con el estado actual del recurso")
+
+	// ErrEmailTaken indica que ya existe un usuario con el email indicado
+	ErrEmailTaken = errors.New("el email ya está registrado")
+
+	// ErrBlacklisted indica que el servicio PLD reportó a la persona en lista negra
+	ErrBlacklisted = errors.New("usuario en lista negra")
+
+	// ErrPLDUnavailable indica que no fue posible validar a la persona con el servicio PLD
+	ErrPLDUnavailable = errors.New("error validando usuario con servicio PLD")
+
+	// ErrInvalidCredentials indica un email o contraseña incorrectos
+	ErrInvalidCredentials = errors.New("credenciales inválidas")
+
+	// ErrInvalidToken indica un token mal formado, vencido, mal firmado o de un usuario inexistente
+	ErrInvalidToken = errors.New("token inválido")
+
+	// ErrUnauthenticated indica una solicitud sin credenciales
+	ErrUnauthenticated = errors.New("usuario no autenticado")
+
+	// ErrForbidden indica que el usuario autenticado no tiene permisos para la operación
+	ErrForbidden = errors.New("permisos insuficientes")
+
+	// ErrPendingReview indica que el usuario espera la revisión de cumplimiento
+	ErrPendingReview = errors.New("usuario pendiente de revisión de cumplimiento")
+
+	// ErrRejected indica que cumplimiento rechazó al usuario
+	ErrRejected = errors.New("usuario rechazado por cumplimiento")
+
+	// ErrMFARequired indica que la operación requiere una sesión iniciada con un segundo factor
+	ErrMFARequired = errors.New("se requiere autenticación multifactor")
+
+	// ErrTooManyAttempts indica que se superó el límite de intentos y se debe esperar para reintentar
+	ErrTooManyAttempts = errors.New("demasiados intentos")
+)
+
+// Error es un error del dominio con un mensaje específico. Kind es uno de los errores
+// anteriores y permite identificarlo con errors.Is. Si Args no está vacío, Message es un
+// formato de fmt; se conservan por separado para que la capa HTTP pueda traducir el
+// mensaje. Cause conserva el error técnico que lo originó, si existe, para el registro y
+// para que la capa HTTP pueda detallarlo
+type Error struct {
+	Kind    error
+	Message string
+	Args    []interface{}
+	Cause   error
+}
+
+// Error implementa error
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Detail() + ": " + e.Cause.Error()
+	}
+	return e.Detail()
+}
+
+// Detail retorna el mensaje con sus argumentos aplicados
+func (e *Error) Detail() string {
+	if len(e.Args) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf(e.Message, e.Args...)
+}
+
+// Unwrap expone la categoría y la causa del error
+func (e *Error) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Kind, e.Cause}
+	}
+	return []error{e.Kind}
+}
+
+// NewError crea un error de la categoría kind con un mensaje específico
+func NewError(kind error, message string) error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Errorf crea un error de la categoría kind con un mensaje formateado
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Message: format, Args: args}
+}
+
+// WrapError crea un error de la categoría kind que conserva el error que lo originó
+func WrapError(kind error, message string, cause error) error {
+	return &Error{Kind: kind, Message: message, Cause: cause}
+}
+
+// WrapErrorf crea un error de la categoría kind con un mensaje formateado que conserva el
+// error que lo originó
+func WrapErrorf(kind error, cause error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Message: format, Args: args, Cause: cause}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// MFAEnrollment es la inscripción TOTP de un usuario. Queda pendiente hasta que el usuario
+// confirma un código generado con el secreto. LastUsedStep es el último paso de tiempo
+// aceptado, para que un código no se pueda usar dos veces
+type MFAEnrollment struct {
+	UserID       uint       `json:"user_id"`
+	Secret       string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Confirmed indica si el usuario confirmó la inscripción, es decir, si tiene MFA activado
+func (e *MFAEnrollment) Confirmed() bool {
+	return e.ConfirmedAt != nil
+}
+
+// MFASetup es lo que recibe el usuario al iniciar la inscripción para configurar su
+// aplicación autenticadora
+type MFASetup struct {
+	Secret string
+	URI    string
+}
+
+// MFAStatus resume el estado de MFA de un usuario
+type MFAStatus struct {
+	Enabled                bool
+	Pending                bool
+	ConfirmedAt            *time.Time
+	RecoveryCodesRemaining int
+	Required               bool
+}
+
+// MFAChallenge es el segundo paso pendiente de un inicio de sesión con MFA. Solo se almacena
+// el hash del token que recibe el cliente
+type MFAChallenge struct {
+	ID          uint       `json:"id"`
+	UserID      uint       `json:"user_id"`
+	TokenHash   string     `json:"-"`
+	Attempts    int        `json:"attempts"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Usable indica si el desafío no se completó, no venció y no agotó sus intentos
+func (c *MFAChallenge) Usable(now time.Time, maxAttempts int) bool {
+	return c.CompletedAt == nil && now.Before(c.ExpiresAt) && c.Attempts < maxAttempts
+}
+
+// MFARolePolicy indica si los usuarios de un rol deben usar MFA
+type MFARolePolicy struct {
+	Role      string    `json:"role"`
+	Required  bool      `json:"required"`
+	UpdatedBy uint      `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
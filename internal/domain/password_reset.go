@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// PasswordResetToken representa una solicitud de restablecimiento de contraseña. Solo se
+// almacena el hash del token; el token en claro se entrega al usuario por el notificador
+type PasswordResetToken struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Usable indica si el token aún no se usó ni venció
+func (t *PasswordResetToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// PasswordResetNotice es el aviso que recibe el usuario para restablecer su contraseña
+type PasswordResetNotice struct {
+	UserID    uint      `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -1,8 +1,13 @@
 package domain
 
+import (
+	"context"
+	"strings"
+)
+
 // PLDService define las operaciones del servicio de PLD
 type PLDService interface {
-	ValidateUser(idNumber, name, email string) (*PLDResponse, error)
+	ValidateUser(ctx context.Context, request PLDRequest) (*PLDResponse, error)
 }
 
 // PLDResponse representa la respuesta del servicio PLD
@@ -10,11 +15,81 @@ type PLDResponse struct {
 	IsBlacklisted bool   `json:"is_blacklisted"`
 	Reason        string `json:"reason,omitempty"`
 	Status        string `json:"status"`
+	Provider      string `json:"provider,omitempty"`
+
+	// MatchScore es la similitud (0..1) de la mejor coincidencia encontrada
+	MatchScore float64    `json:"match_score"`
+	Matches    []PLDMatch `json:"matches,omitempty"`
+
+	// Verdicts conserva el resultado individual de cada proveedor cuando se combinan varios
+	Verdicts []PLDProviderVerdict `json:"verdicts,omitempty"`
+
+	// Datos crudos del intercambio con el proveedor, usados para auditoría
+	RequestPayload string `json:"-"`
+	RawResponse    string `json:"-"`
+}
+
+// PLDMatch representa una entrada de una lista de sanciones que coincide con el usuario validado
+type PLDMatch struct {
+	Source      string  `json:"source"`
+	ExternalID  string  `json:"external_id"`
+	Name        string  `json:"name"`
+	MatchedName string  `json:"matched_name"`
+	MatchedOn   string  `json:"matched_on"`
+	Score       float64 `json:"score"`
+	Program     string  `json:"program,omitempty"`
+}
+
+// PLDProviderVerdict representa el resultado de un proveedor dentro de un screening combinado
+type PLDProviderVerdict struct {
+	Provider      string  `json:"provider"`
+	Status        string  `json:"status"`
+	IsBlacklisted bool    `json:"is_blacklisted"`
+	Reason        string  `json:"reason,omitempty"`
+	MatchScore    float64 `json:"match_score"`
+	Error         string  `json:"error,omitempty"`
+	LatencyMs     int64   `json:"latency_ms"`
 }
 
-// PLDRequest representa la solicitud al servicio PLD
+// PLDRequest representa la solicitud al servicio PLD. Los campos estructurados del
+// nombre son opcionales: sin ellos los proveedores solo cuentan con el nombre completo
 type PLDRequest struct {
-	IDNumber string `json:"id_number"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
+	IDNumber        string `json:"id_number"`
+	Name            string `json:"name"`
+	GivenNames      string `json:"given_names,omitempty"`
+	PaternalSurname string `json:"paternal_surname,omitempty"`
+	MaternalSurname string `json:"maternal_surname,omitempty"`
+	DateOfBirth     string `json:"date_of_birth,omitempty"` // AAAA-MM-DD
+	Nationality     string `json:"nationality,omitempty"`   // ISO 3166-1 alfa-2
+	Email           string `json:"email"`
+}
+
+// FullName retorna el nombre completo de la solicitud, armándolo a partir de los
+// campos estructurados cuando no se indicó
+func (r PLDRequest) FullName() string {
+	if strings.TrimSpace(r.Name) != "" {
+		return r.Name
+	}
+	return FullName(r.GivenNames, r.PaternalSurname, r.MaternalSurname)
+}
+
+// Surnames retorna los apellidos paterno y materno separados por un espacio
+func (r PLDRequest) Surnames() string {
+	return FullName(r.PaternalSurname, r.MaternalSurname)
+}
+
+// HasStructuredName indica si la solicitud trae el nombre separado en nombres y apellidos
+func (r PLDRequest) HasStructuredName() bool {
+	return strings.TrimSpace(r.GivenNames) != "" && strings.TrimSpace(r.PaternalSurname) != ""
+}
+
+// FullName une las partes no vacías de un nombre separadas por un espacio
+func FullName(parts ...string) string {
+	fields := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			fields = append(fields, part)
+		}
+	}
+	return strings.Join(fields, " ")
 }
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// PLDCacheEntry representa un resultado de screening guardado en la caché
+type PLDCacheEntry struct {
+	Key string
+	// Response es la respuesta del proveedor serializada, incluyendo los datos de auditoría
+	Response string
+	// ListVersion identifica las listas de sanciones vigentes cuando se guardó el resultado
+	ListVersion string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
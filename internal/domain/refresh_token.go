@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// RefreshToken representa un refresh token opaco. Cada inicio de sesión crea una familia;
+// al renovar, el token se marca como rotado y se emite otro de la misma familia. Solo se
+// almacena el hash del token. MFA indica que la sesión se inició con un segundo factor, y
+// se conserva al rotar
+type RefreshToken struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	MFA       bool       `json:"mfa"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TokenPair es el par de tokens que recibe el cliente al iniciar sesión o renovar
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresIn  time.Duration
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// RejectedApplication representa una solicitud de alta rechazada por el servicio PLD.
+// Se conserva para los reportes regulatorios y nunca incluye la contraseña del solicitante
+type RejectedApplication struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	IDNumber    string    `json:"id_number"`
+	Reason      string    `json:"reason"`
+	Provider    string    `json:"provider"`
+	ScreeningID *uint     `json:"screening_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RejectedApplicationFilter filtra el listado de solicitudes rechazadas por rango de fechas.
+// Un valor cero indica que el extremo no está acotado
+type RejectedApplicationFilter struct {
+	From time.Time
+	To   time.Time
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Estados de una ejecución de re-screening
+const (
+	RescreeningStatusRunning   = "running"
+	RescreeningStatusCompleted = "completed"
+	RescreeningStatusFailed    = "failed"
+)
+
+// Origen de una ejecución de re-screening
+const (
+	RescreeningTriggerScheduled = "scheduled"
+	RescreeningTriggerManual    = "manual"
+)
+
+// RescreeningRun representa una ejecución del re-screening de la base de usuarios.
+// LastUserID es el cursor que permite reanudar la ejecución tras un reinicio
+type RescreeningRun struct {
+	ID         uint       `json:"id"`
+	Trigger    string     `json:"trigger"`
+	Status     string     `json:"status"`
+	LastUserID uint       `json:"last_user_id"`
+	Processed  int        `json:"processed"`
+	Flagged    int        `json:"flagged"`
+	Failed     int        `json:"failed"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
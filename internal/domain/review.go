@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Decisiones de la revisión manual de cumplimiento
+const (
+	ReviewDecisionApproved = "approved"
+	ReviewDecisionRejected = "rejected"
+)
+
+// ReviewDecision representa el registro de auditoría de una decisión de cumplimiento
+// sobre un alta pendiente de revisión
+type ReviewDecision struct {
+	ID            uint      `json:"id"`
+	UserID        uint      `json:"user_id"`
+	ReviewerID    uint      `json:"reviewer_id"`
+	Decision      string    `json:"decision"`
+	Justification string    `json:"justification"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ReviewItem representa un alta en la cola de revisión junto con su screening más reciente
+type ReviewItem struct {
+	User      *User      `json:"user"`
+	Screening *Screening `json:"screening,omitempty"`
+}
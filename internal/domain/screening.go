@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Estados normalizados de un screening PLD
+const (
+	ScreeningStatusClean       = "clean"
+	ScreeningStatusBlacklisted = "blacklisted"
+	ScreeningStatusReview      = "review"
+	ScreeningStatusError       = "error"
+)
+
+// Screening representa el registro de auditoría de una validación contra el servicio PLD
+type Screening struct {
+	ID             uint      `json:"id"`
+	UserID         *uint     `json:"user_id,omitempty"`
+	IDNumber       string    `json:"id_number"`
+	Email          string    `json:"email"`
+	RequestPayload string    `json:"request_payload"`
+	RawResponse    string    `json:"raw_response,omitempty"`
+	Status         string    `json:"status"`
+	Provider       string    `json:"provider"`
+	MatchScore     float64   `json:"match_score"`
+	LatencyMs      int64     `json:"latency_ms"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// TokenRevocation registra un token de acceso revocado antes de vencer, identificado por su
+// jti. Se conserva solo hasta que el token vence
+type TokenRevocation struct {
+	JTI       string    `json:"jti"`
+	UserID    uint      `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// UserTokenRevocation invalida todos los tokens de acceso de un usuario emitidos hasta
+// RevokedBefore, como al cerrar todas sus sesiones. Deja de ser necesaria en ExpiresAt,
+// cuando ya vencieron todos esos tokens
+type UserTokenRevocation struct {
+	UserID        uint      `json:"user_id"`
+	RevokedBefore time.Time `json:"revoked_before"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
@@ -1,9 +1,23 @@
 package domain
 
 import (
+	"context"
 	"time"
 )
 
+// Roles de usuario
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Estados de usuario
+const (
+	UserStatusActive        = "active"
+	UserStatusPendingReview = "pending_review"
+	UserStatusRejected      = "rejected"
+)
+
 // User representa la entidad de usuario en el dominio
 type User struct {
 	ID        uint      `json:"id"`
@@ -11,24 +25,89 @@ type User struct {
 	Email     string    `json:"email"`
 	Password  string    `json:"-"` // No se serializa en JSON
 	IDNumber  string    `json:"id_number"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Marca de PLD: se establece cuando un re-screening detecta al usuario en lista negra
+	FlaggedAt  *time.Time `json:"flagged_at,omitempty"`
+	FlagReason string     `json:"flag_reason,omitempty"`
+
+	// Motivo por el que el alta quedó pendiente de revisión de cumplimiento
+	ReviewReason string `json:"review_reason,omitempty"`
+
+	// Datos de identidad estructurados que se envían al servicio PLD
+	GivenNames      string `json:"given_names,omitempty"`
+	PaternalSurname string `json:"paternal_surname,omitempty"`
+	MaternalSurname string `json:"maternal_surname,omitempty"`
+	DateOfBirth     string `json:"date_of_birth,omitempty"` // AAAA-MM-DD
+	Nationality     string `json:"nationality,omitempty"`   // ISO 3166-1 alfa-2
+}
+
+// PLDRequest construye la solicitud de validación PLD con la identidad del usuario
+func (u *User) PLDRequest() PLDRequest {
+	return PLDRequest{
+		IDNumber:        u.IDNumber,
+		Name:            u.Name,
+		GivenNames:      u.GivenNames,
+		PaternalSurname: u.PaternalSurname,
+		MaternalSurname: u.MaternalSurname,
+		DateOfBirth:     u.DateOfBirth,
+		Nationality:     u.Nationality,
+		Email:           u.Email,
+	}
+}
+
+// UserUpdate describe una actualización parcial del perfil. Los campos nil no cambian
+type UserUpdate struct {
+	Name            *string
+	Email           *string
+	IDNumber        *string
+	GivenNames      *string
+	PaternalSurname *string
+	MaternalSurname *string
+	DateOfBirth     *string
+	Nationality     *string
+}
+
+// Apply aplica la actualización sobre el usuario
+func (u UserUpdate) Apply(user *User) {
+	fields := []struct {
+		value  *string
+		target *string
+	}{
+		{u.Name, &user.Name},
+		{u.Email, &user.Email},
+		{u.IDNumber, &user.IDNumber},
+		{u.GivenNames, &user.GivenNames},
+		{u.PaternalSurname, &user.PaternalSurname},
+		{u.MaternalSurname, &user.MaternalSurname},
+		{u.DateOfBirth, &user.DateOfBirth},
+		{u.Nationality, &user.Nationality},
+	}
+	for _, field := range fields {
+		if field.value != nil {
+			*field.target = *field.value
+		}
+	}
 }
 
 // UserRepository define las operaciones de persistencia para usuarios
 type UserRepository interface {
-	Create(user *User) error
-	GetByID(id uint) (*User, error)
-	GetByEmail(email string) (*User, error)
-	Update(user *User) error
-	Delete(id uint) error
+	Create(ctx context.Context, user *User) error
+	GetByID(ctx context.Context, id uint) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id uint) error
 }
 
 // UserService define las operaciones de negocio para usuarios
 type UserService interface {
-	CreateUser(user *User) error
-	GetUser(id uint) (*User, error)
-	GetUserByEmail(email string) (*User, error)
-	UpdateUser(user *User) error
-	DeleteUser(id uint) error
+	CreateUser(ctx context.Context, user *User) error
+	GetUser(ctx context.Context, id uint) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	UpdateUser(ctx context.Context, user *User) error
+	UpdateProfile(ctx context.Context, id uint, update UserUpdate) (*User, error)
+	DeleteUser(ctx context.Context, id uint) error
 }
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Listas de sanciones soportadas por el proveedor local
+const (
+	WatchlistSourceOFAC = "ofac_sdn"
+	WatchlistSourceUN   = "un_consolidated"
+	WatchlistSourceUIF  = "uif_lpb"
+)
+
+// WatchlistSources enumera las listas de sanciones soportadas
+var WatchlistSources = []string{WatchlistSourceOFAC, WatchlistSourceUN, WatchlistSourceUIF}
+
+// WatchlistEntry representa una persona o entidad de una lista de sanciones
+type WatchlistEntry struct {
+	ID         uint     `json:"id"`
+	Source     string   `json:"source"`
+	VersionID  uint     `json:"version_id"`
+	ExternalID string   `json:"external_id"`
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases,omitempty"`
+	IDNumbers  []string `json:"id_numbers,omitempty"`
+	Program    string   `json:"program,omitempty"`
+}
+
+// WatchlistVersion representa una importación de una lista de sanciones. La versión
+// más reciente de cada lista es la que se usa para el screening
+type WatchlistVersion struct {
+	ID         uint      `json:"id"`
+	Source     string    `json:"source"`
+	Version    string    `json:"version"`
+	Checksum   string    `json:"checksum"`
+	EntryCount int       `json:"entry_count"`
+	ImportedAt time.Time `json:"imported_at"`
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"log"
 	"os"
+	"strings"
 
 	_ "modernc.org/sqlite"
 )
@@ -21,8 +22,14 @@ func InitDB() (*sql.DB, error) {
 		}
 	}
 
-	// Abrir conexión a SQLite
-	db, err := sql.Open("sqlite", dbPath)
+	return OpenDB(dbPath)
+}
+
+// OpenDB abre la base de datos SQLite en dbPath y crea las tablas que falten
+func OpenDB(dbPath string) (*sql.DB, error) {
+	// Abrir conexión a SQLite. Con busy_timeout las escrituras concurrentes (por ejemplo,
+	// los lotes de screening) esperan el bloqueo en lugar de fallar con SQLITE_BUSY
+	db, err := sql.Open("sqlite", withBusyTimeout(dbPath))
 	if err != nil {
 		return nil, err
 	}
@@ -51,8 +58,18 @@ func createTables(db *sql.DB) error {
 		email TEXT UNIQUE NOT NULL,
 		password TEXT NOT NULL,
 		id_number TEXT NOT NULL,
+		role TEXT NOT NULL DEFAULT 'user',
 		created_at DATETIME NOT NULL,
-		updated_at DATETIME NOT NULL
+		updated_at DATETIME NOT NULL,
+		flagged_at DATETIME,
+		flag_reason TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'active',
+		review_reason TEXT NOT NULL DEFAULT '',
+		given_names TEXT NOT NULL DEFAULT '',
+		paternal_surname TEXT NOT NULL DEFAULT '',
+		maternal_surname TEXT NOT NULL DEFAULT '',
+		date_of_birth TEXT NOT NULL DEFAULT '',
+		nationality TEXT NOT NULL DEFAULT ''
 	);
 	`
 
@@ -61,6 +78,360 @@ func createTables(db *sql.DB) error {
 		return err
 	}
 
+	// Columnas agregadas después de la creación inicial de la tabla de usuarios
+	if err := addColumnIfMissing(db, "users", "role", "TEXT NOT NULL DEFAULT 'user'"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "flagged_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "flag_reason", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "status", "TEXT NOT NULL DEFAULT 'active'"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "review_reason", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "given_names", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "paternal_surname", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "maternal_surname", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "date_of_birth", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "users", "nationality", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	// Tabla de screenings PLD (auditoría regulatoria). user_id no usa FK para
+	// conservar el historial aunque el usuario sea eliminado
+	createScreeningsTable := `
+	CREATE TABLE IF NOT EXISTS screenings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER,
+		id_number TEXT NOT NULL,
+		email TEXT NOT NULL,
+		request_payload TEXT NOT NULL,
+		raw_response TEXT,
+		status TEXT NOT NULL,
+		provider TEXT NOT NULL,
+		match_score REAL NOT NULL DEFAULT 0,
+		latency_ms INTEGER NOT NULL,
+		error TEXT,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_screenings_user_id ON screenings(user_id);
+	`
+
+	_, err = db.Exec(createScreeningsTable)
+	if err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "screenings", "match_score", "REAL NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	// Tabla de solicitudes de alta rechazadas por el servicio PLD. Nunca almacena la contraseña
+	createRejectedApplicationsTable := `
+	CREATE TABLE IF NOT EXISTS rejected_applications (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		email TEXT NOT NULL,
+		id_number TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		provider TEXT NOT NULL,
+		screening_id INTEGER,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_rejected_applications_created_at ON rejected_applications(created_at);
+	`
+
+	_, err = db.Exec(createRejectedApplicationsTable)
+	if err != nil {
+		return err
+	}
+
+	// Tabla de ejecuciones del re-screening periódico (permite reanudar tras un reinicio)
+	createRescreeningRunsTable := `
+	CREATE TABLE IF NOT EXISTS rescreening_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		trigger TEXT NOT NULL,
+		status TEXT NOT NULL,
+		last_user_id INTEGER NOT NULL DEFAULT 0,
+		processed INTEGER NOT NULL DEFAULT 0,
+		flagged INTEGER NOT NULL DEFAULT 0,
+		failed INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		started_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		finished_at DATETIME
+	);
+	`
+
+	_, err = db.Exec(createRescreeningRunsTable)
+	if err != nil {
+		return err
+	}
+
+	// Tablas de listas de sanciones del proveedor PLD local
+	createWatchlistTables := `
+	CREATE TABLE IF NOT EXISTS watchlist_versions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source TEXT NOT NULL,
+		version TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		entry_count INTEGER NOT NULL,
+		imported_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_watchlist_versions_source ON watchlist_versions(source);
+
+	CREATE TABLE IF NOT EXISTS watchlist_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source TEXT NOT NULL,
+		version_id INTEGER NOT NULL REFERENCES watchlist_versions(id),
+		external_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		aliases TEXT NOT NULL DEFAULT '[]',
+		id_numbers TEXT NOT NULL DEFAULT '[]',
+		program TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS idx_watchlist_entries_source ON watchlist_entries(source);
+	`
+
+	_, err = db.Exec(createWatchlistTables)
+	if err != nil {
+		return err
+	}
+
+	// Registro de auditoría de las decisiones de la revisión manual de cumplimiento
+	createReviewDecisionsTable := `
+	CREATE TABLE IF NOT EXISTS review_decisions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		reviewer_id INTEGER NOT NULL,
+		decision TEXT NOT NULL,
+		justification TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_review_decisions_user_id ON review_decisions(user_id);
+	`
+
+	_, err = db.Exec(createReviewDecisionsTable)
+	if err != nil {
+		return err
+	}
+
+	// Caché persistente de resultados del servicio PLD (opcional, ver PLD_CACHE)
+	createPLDCacheTable := `
+	CREATE TABLE IF NOT EXISTS pld_cache (
+		key TEXT PRIMARY KEY,
+		response TEXT NOT NULL,
+		list_version TEXT NOT NULL DEFAULT '',
+		expires_at DATETIME NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_pld_cache_expires_at ON pld_cache(expires_at);
+	`
+
+	_, err = db.Exec(createPLDCacheTable)
+	if err != nil {
+		return err
+	}
+
+	// Lotes de screening de prospectos y el resultado de cada fila
+	createBatchScreeningTables := `
+	CREATE TABLE IF NOT EXISTS batch_screening_jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		status TEXT NOT NULL,
+		source TEXT NOT NULL,
+		requested_by INTEGER NOT NULL,
+		total INTEGER NOT NULL DEFAULT 0,
+		processed INTEGER NOT NULL DEFAULT 0,
+		clean INTEGER NOT NULL DEFAULT 0,
+		blacklisted INTEGER NOT NULL DEFAULT 0,
+		review INTEGER NOT NULL DEFAULT 0,
+		failed INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		finished_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS batch_screening_rows (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		job_id INTEGER NOT NULL,
+		line INTEGER NOT NULL,
+		id_number TEXT NOT NULL,
+		name TEXT NOT NULL,
+		email TEXT NOT NULL,
+		status TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		match_score REAL NOT NULL DEFAULT 0,
+		provider TEXT NOT NULL DEFAULT '',
+		screening_id INTEGER,
+		error TEXT NOT NULL DEFAULT '',
+		processed_at DATETIME
+	);
+	CREATE INDEX IF NOT EXISTS idx_batch_screening_rows_job_id ON batch_screening_rows(job_id, line);
+	`
+
+	_, err = db.Exec(createBatchScreeningTables)
+	if err != nil {
+		return err
+	}
+
+	// Tokens de restablecimiento de contraseña. Solo se guarda el hash del token
+	createPasswordResetTokensTable := `
+	CREATE TABLE IF NOT EXISTS password_reset_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+	`
+
+	_, err = db.Exec(createPasswordResetTokensTable)
+	if err != nil {
+		return err
+	}
+
+	// Refresh tokens. Cada inicio de sesión es una familia de tokens que se revoca completa
+	// si se reutiliza un token ya rotado. Solo se guarda el hash del token
+	createRefreshTokensTable := `
+	CREATE TABLE IF NOT EXISTS refresh_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		family_id TEXT NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		mfa INTEGER NOT NULL DEFAULT 0,
+		expires_at DATETIME NOT NULL,
+		rotated_at DATETIME,
+		revoked_at DATETIME,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+	CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+	`
+
+	_, err = db.Exec(createRefreshTokensTable)
+	if err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "refresh_tokens", "mfa", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	// Revocaciones de tokens de acceso: por jti al cerrar una sesión y por usuario al cerrar
+	// todas. Se conservan solo hasta que vencen los tokens que invalidan
+	createTokenRevocationsTables := `
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+		jti TEXT PRIMARY KEY,
+		user_id INTEGER NOT NULL,
+		expires_at DATETIME NOT NULL,
+		revoked_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+	CREATE TABLE IF NOT EXISTS user_token_revocations (
+		user_id INTEGER PRIMARY KEY,
+		revoked_before DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL
+	);
+	`
+
+	_, err = db.Exec(createTokenRevocationsTables)
+	if err != nil {
+		return err
+	}
+
+	// Autenticación multifactor: inscripciones TOTP, códigos de recuperación (solo su hash),
+	// desafíos pendientes del segundo paso del inicio de sesión y políticas por rol
+	createMFATables := `
+	CREATE TABLE IF NOT EXISTS mfa_enrollments (
+		user_id INTEGER PRIMARY KEY,
+		secret TEXT NOT NULL,
+		confirmed_at DATETIME,
+		last_used_step INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		code_hash TEXT NOT NULL,
+		used_at DATETIME,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id, code_hash);
+	CREATE TABLE IF NOT EXISTS mfa_challenges (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		token_hash TEXT UNIQUE NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		expires_at DATETIME NOT NULL,
+		completed_at DATETIME,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
+	CREATE INDEX IF NOT EXISTS idx_mfa_challenges_user_id_created_at ON mfa_challenges(user_id, created_at);
+	CREATE TABLE IF NOT EXISTS mfa_role_policies (
+		role TEXT PRIMARY KEY,
+		required INTEGER NOT NULL DEFAULT 0,
+		updated_by INTEGER NOT NULL DEFAULT 0,
+		updated_at DATETIME NOT NULL
+	);
+	`
+
+	_, err = db.Exec(createMFATables)
+	if err != nil {
+		return err
+	}
+
 	log.Println("Tablas creadas correctamente")
 	return nil
 }
+
+// addColumnIfMissing agrega una columna a una tabla existente si aún no existe
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+	return err
+}
+
+// IsInMemory indica si la ruta abre una base de datos en memoria
+func IsInMemory(dbPath string) bool {
+	return strings.HasPrefix(dbPath, ":memory:") || strings.HasPrefix(dbPath, "file::memory:") || strings.Contains(dbPath, "mode=memory")
+}
+
+// withBusyTimeout agrega a la ruta de la base de datos el tiempo de espera ante bloqueos
+func withBusyTimeout(dbPath string) string {
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
+	}
+	return dbPath + separator + "_pragma=busy_timeout(5000)"
+}
@@ -0,0 +1,292 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/watchlist"
+	"crabi-test/pkg/namematch"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CachedProviderPrefix se antepone al proveedor de las respuestas servidas desde la caché
+const CachedProviderPrefix = "cache:"
+
+// PLDCacheConfig define la vigencia de los resultados cacheados
+type PLDCacheConfig struct {
+	// CleanTTL es la vigencia de los resultados sin coincidencias
+	CleanTTL time.Duration
+	// BlacklistedTTL es la vigencia de los resultados en lista negra
+	BlacklistedTTL time.Duration
+}
+
+// DefaultPLDCacheConfig retorna la configuración por defecto de la caché. Los resultados
+// limpios duran menos porque una alta nueva en una lista debe detectarse pronto
+func DefaultPLDCacheConfig() PLDCacheConfig {
+	return PLDCacheConfig{
+		CleanTTL:       time.Hour,
+		BlacklistedTTL: 24 * time.Hour,
+	}
+}
+
+// PLDCacheConfigFromEnv construye la configuración de la caché a partir de variables de entorno
+func PLDCacheConfigFromEnv() PLDCacheConfig {
+	config := DefaultPLDCacheConfig()
+
+	if v, err := time.ParseDuration(os.Getenv("PLD_CACHE_CLEAN_TTL")); err == nil && v >= 0 {
+		config.CleanTTL = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("PLD_CACHE_BLACKLISTED_TTL")); err == nil && v >= 0 {
+		config.BlacklistedTTL = v
+	}
+
+	return config
+}
+
+// PLDCacheStats resume el uso de la caché
+type PLDCacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// CachedPLDService envuelve un ports.PLDService con una caché de resultados por identidad
+type CachedPLDService struct {
+	next        ports.PLDService
+	repo        ports.PLDCacheRepository
+	config      PLDCacheConfig
+	listVersion func() string
+	now         func() time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewCachedPLDService crea una caché alrededor del servicio PLD. listVersion identifica las
+// listas de sanciones vigentes: los resultados guardados con otra versión se descartan
+func NewCachedPLDService(next ports.PLDService, repo ports.PLDCacheRepository, config PLDCacheConfig, listVersion func() string) *CachedPLDService {
+	if listVersion == nil {
+		listVersion = func() string { return "" }
+	}
+
+	return &CachedPLDService{
+		next:        next,
+		repo:        repo,
+		config:      config,
+		listVersion: listVersion,
+		now:         time.Now,
+	}
+}
+
+// cachedResponse serializa la respuesta completa, incluyendo los datos crudos de auditoría
+type cachedResponse struct {
+	domain.PLDResponse
+	RequestPayload string `json:"request_payload"`
+	RawResponse    string `json:"raw_response"`
+}
+
+// ValidateUser retorna el resultado cacheado de la identidad o consulta al servicio PLD
+func (c *CachedPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	key := CacheKey(request)
+	listVersion := c.listVersion()
+
+	if response := c.lookup(ctx, key, listVersion); response != nil {
+		c.hits.Add(1)
+		return response, nil
+	}
+	c.misses.Add(1)
+
+	response, err := c.next.ValidateUser(ctx, request)
+	if err != nil {
+		return response, err
+	}
+
+	c.store(ctx, key, listVersion, response)
+	return response, nil
+}
+
+// Stats retorna los aciertos y fallos de la caché desde el arranque
+func (c *CachedPLDService) Stats() PLDCacheStats {
+	return PLDCacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
+
+// StartPurge elimina periódicamente las entradas vencidas hasta que se cancele ctx
+func (c *CachedPLDService) StartPurge(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := c.repo.DeleteExpired(ctx, c.now()); err != nil {
+					log.Printf("error depurando caché PLD: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// lookup obtiene una respuesta vigente de la caché, o nil si no la hay
+func (c *CachedPLDService) lookup(ctx context.Context, key, listVersion string) *domain.PLDResponse {
+	entry, err := c.repo.Get(ctx, key)
+	if err != nil {
+		log.Printf("error leyendo caché PLD: %v", err)
+		return nil
+	}
+	if entry == nil {
+		return nil
+	}
+
+	// Una entrada vencida o de otra versión de las listas ya no es válida
+	if !c.now().Before(entry.ExpiresAt) || entry.ListVersion != listVersion {
+		if err := c.repo.Delete(ctx, key); err != nil {
+			log.Printf("error invalidando caché PLD: %v", err)
+		}
+		return nil
+	}
+
+	var cached cachedResponse
+	if err := json.Unmarshal([]byte(entry.Response), &cached); err != nil {
+		log.Printf("error leyendo caché PLD: %v", err)
+		return nil
+	}
+
+	response := cached.PLDResponse
+	response.RequestPayload = cached.RequestPayload
+	response.RawResponse = cached.RawResponse
+	response.Provider = CachedProviderPrefix + response.Provider
+	return &response
+}
+
+// store guarda una respuesta según su resultado. Los resultados no concluyentes no se
+// cachean para que cada intento quede en manos de la revisión manual
+func (c *CachedPLDService) store(ctx context.Context, key, listVersion string, response *domain.PLDResponse) {
+	var ttl time.Duration
+	switch {
+	case response.IsBlacklisted:
+		ttl = c.config.BlacklistedTTL
+	case response.Status == domain.ScreeningStatusReview:
+		return
+	default:
+		ttl = c.config.CleanTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(cachedResponse{
+		PLDResponse:    *response,
+		RequestPayload: response.RequestPayload,
+		RawResponse:    response.RawResponse,
+	})
+	if err != nil {
+		log.Printf("error guardando caché PLD: %v", err)
+		return
+	}
+
+	now := c.now()
+	entry := &domain.PLDCacheEntry{
+		Key:         key,
+		Response:    string(data),
+		ListVersion: listVersion,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+	if err := c.repo.Put(context.WithoutCancel(ctx), entry); err != nil {
+		log.Printf("error guardando caché PLD: %v", err)
+	}
+}
+
+// CacheKey calcula la clave de caché de una identidad. Se normalizan mayúsculas, acentos,
+// separadores y espacios para que variaciones de captura compartan el mismo resultado.
+// Los nombres y apellidos estructurados forman parte de la clave porque el proveedor
+// puede responder distinto según cómo se separe el nombre
+func CacheKey(request domain.PLDRequest) string {
+	normalized := strings.Join([]string{
+		watchlist.NormalizeIDNumber(request.IDNumber),
+		foldName(request.FullName()),
+		foldName(request.GivenNames),
+		foldName(request.PaternalSurname),
+		foldName(request.MaternalSurname),
+		strings.TrimSpace(request.DateOfBirth),
+		strings.ToUpper(strings.TrimSpace(request.Nationality)),
+		strings.ToLower(strings.TrimSpace(request.Email)),
+	}, "|")
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// foldName normaliza un nombre para la clave de caché
+func foldName(name string) string {
+	return strings.Join(strings.Fields(namematch.Fold(name)), " ")
+}
+
+// MemoryPLDCacheRepository implementa la caché de resultados PLD en memoria
+type MemoryPLDCacheRepository struct {
+	mu      sync.Mutex
+	entries map[string]domain.PLDCacheEntry
+}
+
+// NewMemoryPLDCacheRepository crea una caché en memoria
+func NewMemoryPLDCacheRepository() *MemoryPLDCacheRepository {
+	return &MemoryPLDCacheRepository{entries: make(map[string]domain.PLDCacheEntry)}
+}
+
+// Get obtiene una entrada por su clave
+func (m *MemoryPLDCacheRepository) Get(ctx context.Context, key string) (*domain.PLDCacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// Put guarda o reemplaza una entrada
+func (m *MemoryPLDCacheRepository) Put(ctx context.Context, entry *domain.PLDCacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[entry.Key] = *entry
+	return nil
+}
+
+// Delete elimina una entrada
+func (m *MemoryPLDCacheRepository) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+// DeleteExpired elimina las entradas vencidas
+func (m *MemoryPLDCacheRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for key, entry := range m.entries {
+		if !now.Before(entry.ExpiresAt) {
+			delete(m.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
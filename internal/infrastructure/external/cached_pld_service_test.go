@@ -0,0 +1,130 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"testing"
+	"time"
+)
+
+func newTestCache(next *stubPLDService, listVersion *string) (*CachedPLDService, *time.Time) {
+	cache := NewCachedPLDService(next, NewMemoryPLDCacheRepository(), PLDCacheConfig{
+		CleanTTL:       time.Minute,
+		BlacklistedTTL: time.Hour,
+	}, func() string { return *listVersion })
+
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestCacheKey_NormalizesIdentity(t *testing.T) {
+	key := CacheKey(domain.PLDRequest{IDNumber: "12.345.678", Name: "Juan  Pérez", Email: "Juan@Email.com "})
+	if key != CacheKey(domain.PLDRequest{IDNumber: "12345678", Name: "JUAN PEREZ", Email: "juan@email.com"}) {
+		t.Error("Expected capture variations to share the same key")
+	}
+	if key == CacheKey(domain.PLDRequest{IDNumber: "12345678", Name: "Pérez Juan", Email: "juan@email.com"}) {
+		t.Error("Expected different name order to produce a different key")
+	}
+
+	structured := domain.PLDRequest{IDNumber: "12345678", Name: "María de la Luz García", GivenNames: "María de la Luz", PaternalSurname: "García", Email: "maria@email.com"}
+	split := structured
+	split.GivenNames, split.PaternalSurname, split.MaternalSurname = "María", "de la Luz", "García"
+	if CacheKey(structured) == CacheKey(split) {
+		t.Error("Expected different name structure to produce a different key")
+	}
+	withBirthDate := structured
+	withBirthDate.DateOfBirth = "1985-04-12"
+	if CacheKey(structured) == CacheKey(withBirthDate) {
+		t.Error("Expected date of birth to be part of the key")
+	}
+}
+
+func TestCachedPLDService_HitsAndTTL(t *testing.T) {
+	listVersion := "1"
+	next := cleanProvider()
+	next.response.Provider = PLDProviderName
+	cache, now := newTestCache(next, &listVersion)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		response, err := cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if i > 0 && (response.Provider != "cache:pld-http" || response.RawResponse != `{"is_in_blacklist":false}`) {
+			t.Errorf("Expected cached response with audit data, got %+v", response)
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("Expected 1 call to the provider, got %d", next.calls)
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	// El resultado limpio vence con su TTL
+	*now = now.Add(time.Minute)
+	cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+	if next.calls != 2 {
+		t.Errorf("Expected the expired entry to be refreshed, got %d calls", next.calls)
+	}
+}
+
+func TestCachedPLDService_SeparateTTLForBlacklisted(t *testing.T) {
+	listVersion := "1"
+	next := hitProvider(1)
+	cache, now := newTestCache(next, &listVersion)
+	ctx := context.Background()
+
+	cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+	*now = now.Add(30 * time.Minute)
+	response, _ := cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+	if next.calls != 1 || !response.IsBlacklisted || len(response.Matches) != 1 {
+		t.Errorf("Expected blacklisted result served from cache, got %d calls and %+v", next.calls, response)
+	}
+}
+
+func TestCachedPLDService_InvalidatesOnNewListVersion(t *testing.T) {
+	listVersion := "1"
+	next := cleanProvider()
+	cache, _ := newTestCache(next, &listVersion)
+	ctx := context.Background()
+
+	cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+	listVersion = "1,2"
+	cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+	cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+
+	if next.calls != 2 {
+		t.Errorf("Expected a new list version to invalidate the cache, got %d calls", next.calls)
+	}
+}
+
+func TestCachedPLDService_SkipsErrorsAndReviews(t *testing.T) {
+	listVersion := ""
+	ctx := context.Background()
+
+	for _, next := range []*stubPLDService{failingProvider(), reviewProvider()} {
+		cache, _ := newTestCache(next, &listVersion)
+		cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+		cache.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+		if next.calls != 2 {
+			t.Errorf("Expected errors and review results not to be cached, got %d calls", next.calls)
+		}
+	}
+}
+
+func TestMemoryPLDCacheRepository_DeleteExpired(t *testing.T) {
+	repo := NewMemoryPLDCacheRepository()
+	now := time.Now()
+	repo.Put(context.Background(), &domain.PLDCacheEntry{Key: "a", ExpiresAt: now.Add(-time.Second)})
+	repo.Put(context.Background(), &domain.PLDCacheEntry{Key: "b", ExpiresAt: now.Add(time.Hour)})
+
+	if deleted, _ := repo.DeleteExpired(context.Background(), now); deleted != 1 {
+		t.Errorf("Expected 1 expired entry, got %d", deleted)
+	}
+	if entry, _ := repo.Get(context.Background(), "b"); entry == nil {
+		t.Error("Expected the valid entry to be kept")
+	}
+}
@@ -0,0 +1,270 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen se retorna cuando el circuito está abierto y la llamada no se realiza
+var ErrCircuitOpen = errors.New("circuito abierto: servicio PLD no disponible")
+
+// CircuitState representa el estado del circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed deja pasar todas las llamadas
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rechaza las llamadas hasta que termine el tiempo de enfriamiento
+	CircuitOpen
+	// CircuitHalfOpen deja pasar un número limitado de llamadas de prueba
+	CircuitHalfOpen
+)
+
+// String retorna el nombre del estado
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig define los umbrales del circuit breaker
+type CircuitBreakerConfig struct {
+	// WindowSize es el número de llamadas recientes consideradas para la tasa de fallos
+	WindowSize int
+	// MinRequests es el mínimo de llamadas en la ventana antes de evaluar la tasa de fallos
+	MinRequests int
+	// FailureRateThreshold es la tasa de fallos (0..1) a partir de la cual se abre el circuito
+	FailureRateThreshold float64
+	// OpenTimeout es el tiempo de enfriamiento antes de pasar a half-open
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests es el número de llamadas de prueba permitidas en half-open
+	HalfOpenMaxRequests int
+}
+
+// DefaultCircuitBreakerConfig retorna la configuración por defecto del circuit breaker
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		WindowSize:           20,
+		MinRequests:          5,
+		FailureRateThreshold: 0.5,
+		OpenTimeout:          30 * time.Second,
+		HalfOpenMaxRequests:  1,
+	}
+}
+
+// CircuitBreakerConfigFromEnv construye la configuración a partir de variables de entorno
+func CircuitBreakerConfigFromEnv() CircuitBreakerConfig {
+	config := DefaultCircuitBreakerConfig()
+
+	if v, err := strconv.Atoi(os.Getenv("PLD_BREAKER_WINDOW_SIZE")); err == nil && v > 0 {
+		config.WindowSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("PLD_BREAKER_MIN_REQUESTS")); err == nil && v > 0 {
+		config.MinRequests = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("PLD_BREAKER_FAILURE_RATE"), 64); err == nil && v > 0 && v <= 1 {
+		config.FailureRateThreshold = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("PLD_BREAKER_OPEN_TIMEOUT")); err == nil && v > 0 {
+		config.OpenTimeout = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("PLD_BREAKER_HALF_OPEN_REQUESTS")); err == nil && v > 0 {
+		config.HalfOpenMaxRequests = v
+	}
+
+	return config
+}
+
+// CircuitBreakerPLDService envuelve un ports.PLDService con un circuit breaker
+type CircuitBreakerPLDService struct {
+	next   ports.PLDService
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu                sync.Mutex
+	state             CircuitState
+	generation        uint64 // aumenta con cada cambio de estado
+	results           []bool // ventana circular: true = fallo
+	position          int
+	count             int
+	openedAt          time.Time
+	halfOpenInFlight  int
+	halfOpenSuccesses int
+}
+
+// NewCircuitBreakerPLDService crea un circuit breaker alrededor del servicio PLD
+func NewCircuitBreakerPLDService(next ports.PLDService, config CircuitBreakerConfig) *CircuitBreakerPLDService {
+	if config.WindowSize < 1 {
+		config.WindowSize = 1
+	}
+	if config.MinRequests < 1 {
+		config.MinRequests = 1
+	}
+	if config.HalfOpenMaxRequests < 1 {
+		config.HalfOpenMaxRequests = 1
+	}
+
+	return &CircuitBreakerPLDService{
+		next:    next,
+		config:  config,
+		now:     time.Now,
+		state:   CircuitClosed,
+		results: make([]bool, config.WindowSize),
+	}
+}
+
+// ValidateUser valida un usuario si el circuito lo permite
+func (b *CircuitBreakerPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	generation, err := b.beforeCall()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.next.ValidateUser(ctx, request)
+
+	// Una cancelación del llamador no dice nada sobre la salud del proveedor
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		b.release(generation)
+		return response, err
+	}
+	b.afterCall(generation, err == nil)
+
+	return response, err
+}
+
+// State retorna el estado actual del circuito
+func (b *CircuitBreakerPLDService) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refreshState()
+	return b.state
+}
+
+// beforeCall decide si la llamada puede realizarse y retorna la generación del estado en el
+// que se admitió
+func (b *CircuitBreakerPLDService) beforeCall() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refreshState()
+
+	switch b.state {
+	case CircuitOpen:
+		return 0, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.halfOpenInFlight+b.halfOpenSuccesses >= b.config.HalfOpenMaxRequests {
+			return 0, ErrCircuitOpen
+		}
+		b.halfOpenInFlight++
+	}
+
+	return b.generation, nil
+}
+
+// afterCall registra el resultado de una llamada. Se ignoran las llamadas admitidas antes del
+// último cambio de estado: una llamada lenta admitida con el circuito cerrado no es una
+// llamada de prueba de half-open
+func (b *CircuitBreakerPLDService) afterCall(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitHalfOpen:
+		b.halfOpenInFlight--
+		if !success {
+			b.transition(CircuitOpen)
+			return
+		}
+		b.halfOpenSuccesses++
+		if b.halfOpenSuccesses >= b.config.HalfOpenMaxRequests {
+			b.transition(CircuitClosed)
+		}
+	case CircuitClosed:
+		b.record(!success)
+		if b.count >= b.config.MinRequests && b.failureRate() >= b.config.FailureRateThreshold {
+			b.transition(CircuitOpen)
+		}
+	}
+}
+
+// release libera una llamada de prueba sin registrar su resultado
+func (b *CircuitBreakerPLDService) release(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation == b.generation && b.state == CircuitHalfOpen {
+		b.halfOpenInFlight--
+	}
+}
+
+// refreshState pasa de open a half-open cuando termina el enfriamiento
+func (b *CircuitBreakerPLDService) refreshState() {
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.config.OpenTimeout {
+		b.transition(CircuitHalfOpen)
+	}
+}
+
+// transition cambia de estado y reinicia los contadores correspondientes
+func (b *CircuitBreakerPLDService) transition(to CircuitState) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.generation++
+
+	switch to {
+	case CircuitOpen:
+		b.openedAt = b.now()
+	case CircuitHalfOpen:
+		b.halfOpenInFlight = 0
+		b.halfOpenSuccesses = 0
+	case CircuitClosed:
+		b.position = 0
+		b.count = 0
+	}
+
+	log.Printf("PLD circuit breaker: %s -> %s", from, to)
+}
+
+// record agrega un resultado a la ventana circular
+func (b *CircuitBreakerPLDService) record(failure bool) {
+	b.results[b.position] = failure
+	b.position = (b.position + 1) % len(b.results)
+	if b.count < len(b.results) {
+		b.count++
+	}
+}
+
+// failureRate calcula la tasa de fallos en la ventana actual
+func (b *CircuitBreakerPLDService) failureRate() float64 {
+	if b.count == 0 {
+		return 0
+	}
+	failures := 0
+	for i := 0; i < b.count; i++ {
+		if b.results[i] {
+			failures++
+		}
+	}
+	return float64(failures) / float64(b.count)
+}
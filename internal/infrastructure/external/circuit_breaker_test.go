@@ -0,0 +1,181 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakePLDService permite controlar el resultado de cada llamada
+type fakePLDService struct {
+	fail  bool
+	calls int
+}
+
+func (f *fakePLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("servicio PLD retornó código 503")
+	}
+	return &domain.PLDResponse{Status: "clean"}, nil
+}
+
+func newTestBreaker(next *fakePLDService) (*CircuitBreakerPLDService, *time.Time) {
+	breaker := NewCircuitBreakerPLDService(next, CircuitBreakerConfig{
+		WindowSize:           4,
+		MinRequests:          4,
+		FailureRateThreshold: 0.5,
+		OpenTimeout:          10 * time.Second,
+		HalfOpenMaxRequests:  1,
+	})
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	next := &fakePLDService{}
+	breaker, _ := newTestBreaker(next)
+
+	// 2 éxitos y 2 fallos: tasa de fallos 0.5
+	breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	next.fail = true
+	breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	if breaker.State() != CircuitClosed {
+		t.Fatal("Expected circuit to stay closed below min requests")
+	}
+	breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected circuit to be open, got %s", breaker.State())
+	}
+
+	calls := next.calls
+	if _, err := breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"}); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if next.calls != calls {
+		t.Error("Expected no call to the provider while the circuit is open")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenSuccessCloses(t *testing.T) {
+	next := &fakePLDService{fail: true}
+	breaker, now := newTestBreaker(next)
+
+	for i := 0; i < 4; i++ {
+		breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected circuit to be open, got %s", breaker.State())
+	}
+
+	*now = now.Add(10 * time.Second)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected circuit to be half-open, got %s", breaker.State())
+	}
+
+	next.fail = false
+	if _, err := breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"}); err != nil {
+		t.Fatalf("Expected trial call to succeed, got %v", err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected circuit to be closed, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	next := &fakePLDService{fail: true}
+	breaker, now := newTestBreaker(next)
+
+	for i := 0; i < 4; i++ {
+		breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	}
+	*now = now.Add(10 * time.Second)
+
+	if _, err := breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"}); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected trial call to reach the provider and fail, got %v", err)
+	}
+	if breaker.State() != CircuitOpen {
+		t.Errorf("Expected circuit to reopen, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresCallsFromPreviousState(t *testing.T) {
+	next := &fakePLDService{fail: true}
+	breaker, now := newTestBreaker(next)
+
+	// Una llamada lenta admitida con el circuito cerrado termina cuando ya está en half-open
+	generation, err := breaker.beforeCall()
+	if err != nil {
+		t.Fatalf("Expected call to be admitted, got %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	}
+	*now = now.Add(10 * time.Second)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected circuit to be half-open, got %s", breaker.State())
+	}
+
+	breaker.afterCall(generation, true)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected a stale success not to close the circuit, got %s", breaker.State())
+	}
+	breaker.release(generation)
+
+	// La llamada de prueba sigue disponible y decide el estado
+	breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"})
+	if breaker.State() != CircuitOpen {
+		t.Errorf("Expected the failed probe to reopen the circuit, got %s", breaker.State())
+	}
+	if breaker.halfOpenInFlight != 0 {
+		t.Errorf("Expected no probes in flight, got %d", breaker.halfOpenInFlight)
+	}
+}
+
+func TestCircuitBreaker_SuccessfulCallsKeepCircuitClosed(t *testing.T) {
+	next := &fakePLDService{}
+	breaker, _ := newTestBreaker(next)
+
+	for i := 0; i < 10; i++ {
+		if _, err := breaker.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected circuit to stay closed, got %s", breaker.State())
+	}
+}
+
+// cancelledPLDService simula una llamada interrumpida por el llamador
+type cancelledPLDService struct{}
+
+func (cancelledPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestCircuitBreaker_CallerCancellationIsNotAFailure(t *testing.T) {
+	breaker := NewCircuitBreakerPLDService(cancelledPLDService{}, CircuitBreakerConfig{
+		WindowSize:           2,
+		MinRequests:          1,
+		FailureRateThreshold: 0.5,
+		OpenTimeout:          time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := breaker.ValidateUser(ctx, domain.PLDRequest{IDNumber: "1", Name: "Juan", Email: "a@email.com"}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+	}
+
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected circuit to stay closed, got %s", breaker.State())
+	}
+}
@@ -0,0 +1,328 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// AggregationStrategy define cómo se combinan los resultados de varios proveedores PLD
+type AggregationStrategy string
+
+const (
+	// StrategyAnyHit bloquea si cualquier proveedor reporta coincidencia
+	StrategyAnyHit AggregationStrategy = "any_hit"
+	// StrategyMajority decide por mayoría de los proveedores que respondieron
+	StrategyMajority AggregationStrategy = "majority"
+	// StrategyPrimaryFallback usa el primer proveedor que responda, en el orden configurado
+	StrategyPrimaryFallback AggregationStrategy = "primary_fallback"
+)
+
+// CompositeProviderName identifica las respuestas combinadas en los registros de auditoría
+const CompositeProviderName = "composite"
+
+// ErrNoPLDProviderAvailable se retorna cuando ningún proveedor pudo responder
+var ErrNoPLDProviderAvailable = errors.New("ningún proveedor PLD respondió")
+
+// ParseAggregationStrategy interpreta el nombre de una estrategia (any_hit por defecto)
+func ParseAggregationStrategy(value string) (AggregationStrategy, error) {
+	switch strategy := AggregationStrategy(value); strategy {
+	case "":
+		return StrategyAnyHit, nil
+	case StrategyAnyHit, StrategyMajority, StrategyPrimaryFallback:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("estrategia de agregación PLD inválida: %s", value)
+	}
+}
+
+// AggregationStrategyFromEnv lee la estrategia de PLD_AGGREGATION_STRATEGY
+func AggregationStrategyFromEnv() (AggregationStrategy, error) {
+	return ParseAggregationStrategy(os.Getenv("PLD_AGGREGATION_STRATEGY"))
+}
+
+// NamedPLDService asocia un proveedor PLD con el nombre usado en sus veredictos
+type NamedPLDService struct {
+	Name    string
+	Service ports.PLDService
+}
+
+// CompositePLDService combina varios proveedores PLD según una estrategia de agregación
+type CompositePLDService struct {
+	strategy  AggregationStrategy
+	providers []NamedPLDService
+}
+
+// NewCompositePLDService crea un servicio PLD compuesto. En primary_fallback el orden de
+// los proveedores define la prioridad
+func NewCompositePLDService(strategy AggregationStrategy, providers ...NamedPLDService) *CompositePLDService {
+	return &CompositePLDService{
+		strategy:  strategy,
+		providers: providers,
+	}
+}
+
+// providerResult es el resultado de la llamada a un proveedor
+type providerResult struct {
+	name     string
+	response *domain.PLDResponse
+	err      error
+	latency  time.Duration
+}
+
+// compositeRawResponse es el registro de auditoría de una validación combinada
+type compositeRawResponse struct {
+	Strategy AggregationStrategy   `json:"strategy"`
+	Verdicts []compositeRawVerdict `json:"verdicts"`
+}
+
+// compositeRawVerdict agrega al veredicto el intercambio crudo con el proveedor
+type compositeRawVerdict struct {
+	domain.PLDProviderVerdict
+	RequestPayload string `json:"request_payload,omitempty"`
+	RawResponse    string `json:"raw_response,omitempty"`
+}
+
+// ValidateUser valida un usuario contra los proveedores configurados y combina sus resultados.
+// Sin proveedores retorna ErrNoPLDProviderAvailable: ninguna estrategia puede dar por limpio
+// a un usuario que nadie validó
+func (s *CompositePLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	if len(s.providers) == 0 {
+		return nil, ErrNoPLDProviderAvailable
+	}
+
+	var results []providerResult
+	if s.strategy == StrategyPrimaryFallback {
+		results = s.callInOrder(ctx, request)
+	} else {
+		results = s.callAll(ctx, request)
+	}
+
+	// Si el llamador canceló la solicitud los resultados parciales no sirven
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		decision *domain.PLDResponse
+		err      error
+	)
+	switch s.strategy {
+	case StrategyMajority:
+		decision, err = aggregateMajority(results)
+	case StrategyPrimaryFallback:
+		decision, err = aggregatePrimaryFallback(results)
+	default:
+		decision, err = aggregateAnyHit(results)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	payload, _ := json.Marshal(request)
+	raw := compositeRawResponse{Strategy: s.strategy}
+
+	decision.RequestPayload = string(payload)
+	for _, result := range results {
+		verdict := newVerdict(result)
+		decision.Verdicts = append(decision.Verdicts, verdict)
+
+		rawVerdict := compositeRawVerdict{PLDProviderVerdict: verdict}
+		if result.response != nil {
+			rawVerdict.RequestPayload = result.response.RequestPayload
+			rawVerdict.RawResponse = result.response.RawResponse
+		}
+		raw.Verdicts = append(raw.Verdicts, rawVerdict)
+	}
+	rawJSON, _ := json.Marshal(raw)
+	decision.RawResponse = string(rawJSON)
+
+	return decision, nil
+}
+
+// callAll llama a todos los proveedores en paralelo
+func (s *CompositePLDService) callAll(ctx context.Context, request domain.PLDRequest) []providerResult {
+	results := make([]providerResult, len(s.providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range s.providers {
+		wg.Add(1)
+		go func(i int, provider NamedPLDService) {
+			defer wg.Done()
+			results[i] = callProvider(ctx, provider, request)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// callInOrder llama a los proveedores en orden hasta que uno responda
+func (s *CompositePLDService) callInOrder(ctx context.Context, request domain.PLDRequest) []providerResult {
+	var results []providerResult
+	for _, provider := range s.providers {
+		result := callProvider(ctx, provider, request)
+		results = append(results, result)
+		if result.err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	return results
+}
+
+// callProvider llama a un proveedor y mide su latencia
+func callProvider(ctx context.Context, provider NamedPLDService, request domain.PLDRequest) providerResult {
+	start := time.Now()
+	response, err := provider.Service.ValidateUser(ctx, request)
+	if err == nil && response == nil {
+		err = errors.New("respuesta vacía del proveedor PLD")
+	}
+	return providerResult{
+		name:     provider.Name,
+		response: response,
+		err:      err,
+		latency:  time.Since(start),
+	}
+}
+
+// aggregateAnyHit bloquea si algún proveedor reporta coincidencia. Sin coincidencias, un
+// proveedor que no respondió hace fallar la validación para no aprobar con información incompleta
+func aggregateAnyHit(results []providerResult) (*domain.PLDResponse, error) {
+	decision := newCompositeResponse(StrategyAnyHit, results)
+
+	for _, result := range results {
+		if result.err == nil && result.response.IsBlacklisted {
+			decision.IsBlacklisted = true
+			decision.Status = domain.ScreeningStatusBlacklisted
+			decision.Reason = result.name + ": " + result.response.Reason
+			return decision, nil
+		}
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			return nil, fmt.Errorf("proveedor PLD %s no respondió: %w", result.name, result.err)
+		}
+	}
+
+	for _, result := range results {
+		if result.response.Status == domain.ScreeningStatusReview {
+			decision.Status = domain.ScreeningStatusReview
+			decision.Reason = result.name + ": " + result.response.Reason
+			break
+		}
+	}
+
+	return decision, nil
+}
+
+// aggregateMajority decide por mayoría entre los proveedores que respondieron, exigiendo que
+// respondan más de la mitad. Un desacuerdo sin mayoría se envía a revisión manual
+func aggregateMajority(results []providerResult) (*domain.PLDResponse, error) {
+	decision := newCompositeResponse(StrategyMajority, results)
+
+	answered, hits := 0, 0
+	var firstHit, firstReview *providerResult
+	for i, result := range results {
+		if result.err != nil {
+			continue
+		}
+		answered++
+		if result.response.IsBlacklisted {
+			hits++
+			if firstHit == nil {
+				firstHit = &results[i]
+			}
+		} else if result.response.Status == domain.ScreeningStatusReview && firstReview == nil {
+			firstReview = &results[i]
+		}
+	}
+
+	if answered*2 <= len(results) {
+		return nil, fmt.Errorf("%w: respondieron %d de %d", ErrNoPLDProviderAvailable, answered, len(results))
+	}
+
+	switch {
+	case hits*2 > answered:
+		decision.IsBlacklisted = true
+		decision.Status = domain.ScreeningStatusBlacklisted
+		decision.Reason = fmt.Sprintf("%d de %d proveedores reportan coincidencia (%s: %s)", hits, answered, firstHit.name, firstHit.response.Reason)
+	case hits > 0:
+		decision.Status = domain.ScreeningStatusReview
+		decision.Reason = fmt.Sprintf("Proveedores en desacuerdo: %d de %d reportan coincidencia (%s: %s)", hits, answered, firstHit.name, firstHit.response.Reason)
+	case firstReview != nil:
+		decision.Status = domain.ScreeningStatusReview
+		decision.Reason = firstReview.name + ": " + firstReview.response.Reason
+	}
+
+	return decision, nil
+}
+
+// aggregatePrimaryFallback adopta el resultado del primer proveedor que respondió
+func aggregatePrimaryFallback(results []providerResult) (*domain.PLDResponse, error) {
+	if len(results) == 0 {
+		return nil, ErrNoPLDProviderAvailable
+	}
+
+	last := results[len(results)-1]
+	if last.err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoPLDProviderAvailable, last.err)
+	}
+
+	decision := *last.response
+	decision.Provider = last.name
+	decision.Verdicts = nil
+	if decision.Status == "" {
+		decision.Status = domain.ScreeningStatusClean
+	}
+	return &decision, nil
+}
+
+// newCompositeResponse crea la respuesta combinada con la mejor similitud y todas las
+// coincidencias reportadas por los proveedores que respondieron
+func newCompositeResponse(strategy AggregationStrategy, results []providerResult) *domain.PLDResponse {
+	response := &domain.PLDResponse{
+		Status:   domain.ScreeningStatusClean,
+		Provider: CompositeProviderName + ":" + string(strategy),
+	}
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		response.MatchScore = max(response.MatchScore, result.response.MatchScore)
+		response.Matches = append(response.Matches, result.response.Matches...)
+	}
+	return response
+}
+
+// newVerdict resume el resultado de un proveedor
+func newVerdict(result providerResult) domain.PLDProviderVerdict {
+	verdict := domain.PLDProviderVerdict{
+		Provider:  result.name,
+		Status:    domain.ScreeningStatusError,
+		LatencyMs: result.latency.Milliseconds(),
+	}
+	if result.err != nil {
+		verdict.Error = result.err.Error()
+		return verdict
+	}
+
+	verdict.IsBlacklisted = result.response.IsBlacklisted
+	verdict.Reason = result.response.Reason
+	verdict.MatchScore = result.response.MatchScore
+	switch {
+	case result.response.IsBlacklisted:
+		verdict.Status = domain.ScreeningStatusBlacklisted
+	case result.response.Status == domain.ScreeningStatusReview:
+		verdict.Status = domain.ScreeningStatusReview
+	default:
+		verdict.Status = domain.ScreeningStatusClean
+	}
+	return verdict
+}
@@ -0,0 +1,161 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubPLDService retorna siempre el mismo resultado
+type stubPLDService struct {
+	response *domain.PLDResponse
+	err      error
+	calls    int
+}
+
+func (s *stubPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	s.calls++
+	return s.response, s.err
+}
+
+func cleanProvider() *stubPLDService {
+	return &stubPLDService{response: &domain.PLDResponse{Status: domain.ScreeningStatusClean, RawResponse: `{"is_in_blacklist":false}`}}
+}
+
+func hitProvider(score float64) *stubPLDService {
+	return &stubPLDService{response: &domain.PLDResponse{
+		IsBlacklisted: true,
+		Status:        domain.ScreeningStatusBlacklisted,
+		Reason:        "Coincidencia en lista ofac_sdn",
+		MatchScore:    score,
+		Matches:       []domain.PLDMatch{{Source: domain.WatchlistSourceOFAC, ExternalID: "1", Score: score}},
+	}}
+}
+
+func reviewProvider() *stubPLDService {
+	return &stubPLDService{response: &domain.PLDResponse{Status: domain.ScreeningStatusReview, Reason: "Posible coincidencia", MatchScore: 0.8}}
+}
+
+func failingProvider() *stubPLDService {
+	return &stubPLDService{err: errors.New("servicio PLD retornó código 503")}
+}
+
+func named(services ...*stubPLDService) []NamedPLDService {
+	names := []string{"a", "b", "c"}
+	providers := make([]NamedPLDService, len(services))
+	for i, service := range services {
+		providers[i] = NamedPLDService{Name: names[i], Service: service}
+	}
+	return providers
+}
+
+func TestCompositePLDService_Strategies(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  AggregationStrategy
+		providers []NamedPLDService
+		status    string
+		provider  string
+		verdicts  int
+		wantErr   bool
+	}{
+		{"any_hit bloquea con una coincidencia", StrategyAnyHit, named(cleanProvider(), hitProvider(0.9), cleanProvider()), domain.ScreeningStatusBlacklisted, "composite:any_hit", 3, false},
+		{"any_hit bloquea aunque otro proveedor falle", StrategyAnyHit, named(failingProvider(), hitProvider(1)), domain.ScreeningStatusBlacklisted, "composite:any_hit", 2, false},
+		{"any_hit falla si no respondieron todos", StrategyAnyHit, named(cleanProvider(), failingProvider()), "", "", 0, true},
+		{"any_hit envía a revisión", StrategyAnyHit, named(cleanProvider(), reviewProvider()), domain.ScreeningStatusReview, "composite:any_hit", 2, false},
+		{"any_hit limpio", StrategyAnyHit, named(cleanProvider(), cleanProvider()), domain.ScreeningStatusClean, "composite:any_hit", 2, false},
+		{"majority bloquea por mayoría", StrategyMajority, named(hitProvider(0.9), hitProvider(1), cleanProvider()), domain.ScreeningStatusBlacklisted, "composite:majority", 3, false},
+		{"majority envía desacuerdos a revisión", StrategyMajority, named(hitProvider(0.9), cleanProvider(), cleanProvider()), domain.ScreeningStatusReview, "composite:majority", 3, false},
+		{"majority tolera una falla con quórum", StrategyMajority, named(cleanProvider(), failingProvider(), cleanProvider()), domain.ScreeningStatusClean, "composite:majority", 3, false},
+		{"majority sin quórum", StrategyMajority, named(cleanProvider(), failingProvider(), failingProvider()), "", "", 0, true},
+		{"primary_fallback usa el primario", StrategyPrimaryFallback, named(hitProvider(1), cleanProvider()), domain.ScreeningStatusBlacklisted, "a", 1, false},
+		{"primary_fallback usa el respaldo", StrategyPrimaryFallback, named(failingProvider(), cleanProvider()), domain.ScreeningStatusClean, "b", 2, false},
+		{"primary_fallback sin proveedores disponibles", StrategyPrimaryFallback, named(failingProvider(), failingProvider()), "", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewCompositePLDService(tt.strategy, tt.providers...)
+
+			response, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", response)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if response.Status != tt.status || response.IsBlacklisted != (tt.status == domain.ScreeningStatusBlacklisted) {
+				t.Errorf("Expected status %s, got %+v", tt.status, response)
+			}
+			if response.Provider != tt.provider || len(response.Verdicts) != tt.verdicts {
+				t.Errorf("Expected provider %s with %d verdicts, got %s and %+v", tt.provider, tt.verdicts, response.Provider, response.Verdicts)
+			}
+			if !strings.Contains(response.RawResponse, string(tt.strategy)) || response.RequestPayload == "" {
+				t.Errorf("Expected audit data for the combined screening, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestCompositePLDService_KeepsProviderVerdicts(t *testing.T) {
+	service := NewCompositePLDService(StrategyAnyHit, named(cleanProvider(), hitProvider(0.93), failingProvider())...)
+
+	response, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{domain.ScreeningStatusClean, domain.ScreeningStatusBlacklisted, domain.ScreeningStatusError}
+	for i, verdict := range response.Verdicts {
+		if verdict.Status != expected[i] {
+			t.Errorf("Expected verdict %d to be %s, got %+v", i, expected[i], verdict)
+		}
+	}
+	if response.Verdicts[2].Error == "" || response.Reason != "b: Coincidencia en lista ofac_sdn" {
+		t.Errorf("Unexpected verdicts %+v with reason %q", response.Verdicts, response.Reason)
+	}
+	if response.MatchScore != 0.93 || len(response.Matches) != 1 {
+		t.Errorf("Expected best match score 0.93, got %.2f and %+v", response.MatchScore, response.Matches)
+	}
+	if !strings.Contains(response.RawResponse, `{\"is_in_blacklist\":false}`) {
+		t.Errorf("Expected raw provider responses in audit data, got %s", response.RawResponse)
+	}
+}
+
+func TestCompositePLDService_PrimaryFallbackStopsAtFirstAnswer(t *testing.T) {
+	primary, secondary := cleanProvider(), cleanProvider()
+	service := NewCompositePLDService(StrategyPrimaryFallback, named(primary, secondary)...)
+
+	if _, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if primary.calls != 1 || secondary.calls != 0 {
+		t.Errorf("Expected only the primary to be called, got %d and %d calls", primary.calls, secondary.calls)
+	}
+}
+
+func TestCompositePLDService_NoProviders(t *testing.T) {
+	for _, strategy := range []AggregationStrategy{StrategyAnyHit, StrategyMajority, StrategyPrimaryFallback} {
+		service := NewCompositePLDService(strategy)
+		if _, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez"}); !errors.Is(err, ErrNoPLDProviderAvailable) {
+			t.Errorf("%s: expected ErrNoPLDProviderAvailable, got %v", strategy, err)
+		}
+	}
+}
+
+func TestParseAggregationStrategy(t *testing.T) {
+	if strategy, err := ParseAggregationStrategy(""); err != nil || strategy != StrategyAnyHit {
+		t.Errorf("Expected any_hit by default, got %s (%v)", strategy, err)
+	}
+	if strategy, err := ParseAggregationStrategy("majority"); err != nil || strategy != StrategyMajority {
+		t.Errorf("Expected majority, got %s (%v)", strategy, err)
+	}
+	if _, err := ParseAggregationStrategy("unanimous"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
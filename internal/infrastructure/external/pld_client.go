@@ -2,19 +2,37 @@ package external
 
 import (
 	"bytes"
+	"context"
 	"crabi-test/internal/domain"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"os"
-	"strings"
 	"time"
 )
 
+// PLDClientConfig agrupa la configuración del cliente PLD
+type PLDClientConfig struct {
+	BaseURL     string
+	Timeout     time.Duration
+	RetryPolicy RetryPolicy
+
+	// PayloadMapping define los campos enviados al proveedor (nil usa el mapeo por defecto)
+	PayloadMapping PLDPayloadMapping
+}
+
+// PLDProviderName identifica al proveedor PLD remoto en los registros de auditoría
+const PLDProviderName = "pld-http"
+
 // PLDClient implementa el cliente para el servicio externo de PLD
 type PLDClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL        string
+	httpClient     *http.Client
+	retryPolicy    RetryPolicy
+	payloadMapping PLDPayloadMapping
+	sleep          func(ctx context.Context, d time.Duration) error
 }
 
 // NewPLDClient crea una nueva instancia del cliente PLD
@@ -24,32 +42,49 @@ func NewPLDClient() *PLDClient {
 		panic("PLD_SERVICE_URL no está configurado en las variables de entorno")
 	}
 
+	// Timeout por solicitud (configurable para fallar rápido si el proveedor no responde)
+	timeout := 30 * time.Second
+	if v, err := time.ParseDuration(os.Getenv("PLD_TIMEOUT")); err == nil && v > 0 {
+		timeout = v
+	}
+
+	payloadMapping, err := PLDPayloadMappingFromEnv()
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return NewPLDClientWithConfig(PLDClientConfig{
+		BaseURL:        baseURL,
+		Timeout:        timeout,
+		RetryPolicy:    RetryPolicyFromEnv(),
+		PayloadMapping: payloadMapping,
+	})
+}
+
+// NewPLDClientWithConfig crea un cliente PLD con configuración explícita
+func NewPLDClientWithConfig(config PLDClientConfig) *PLDClient {
+	if config.RetryPolicy.MaxAttempts < 1 {
+		config.RetryPolicy.MaxAttempts = 1
+	}
+	if config.PayloadMapping == nil {
+		config.PayloadMapping = DefaultPLDPayloadMapping()
+	}
+
 	return &PLDClient{
-		baseURL: baseURL,
+		baseURL: config.BaseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: config.Timeout,
 		},
+		retryPolicy:    config.RetryPolicy,
+		payloadMapping: config.PayloadMapping,
+		sleep:          sleepContext,
 	}
 }
 
 // ValidateUser valida un usuario contra el servicio PLD
-func (c *PLDClient) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
-	// Separar nombre en first_name y last_name
-	names := strings.Fields(name)
-	firstName := name
-	lastName := ""
-
-	if len(names) > 1 {
-		firstName = names[0]
-		lastName = strings.Join(names[1:], " ")
-	}
-
-	// Crear payload para la solicitud según la documentación real
-	payload := map[string]string{
-		"first_name": firstName,
-		"last_name":  lastName,
-		"email":      email,
-	}
+func (c *PLDClient) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	// Crear payload para la solicitud según el mapeo configurado del proveedor
+	payload := c.payloadMapping.Payload(request)
 
 	// Serializar payload
 	jsonPayload, err := json.Marshal(payload)
@@ -57,10 +92,47 @@ func (c *PLDClient) ValidateUser(idNumber, name, email string) (*domain.PLDRespo
 		return nil, fmt.Errorf("error serializando payload: %w", err)
 	}
 
+	var lastErr error
+	attempt := 1
+	for ; attempt <= c.retryPolicy.MaxAttempts; attempt++ {
+		pldResponse, wait, retryable, err := c.doValidate(ctx, jsonPayload)
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("PLD: validación exitosa en el intento %d/%d", attempt, c.retryPolicy.MaxAttempts)
+			}
+			return pldResponse, nil
+		}
+
+		lastErr = err
+		if !retryable || attempt == c.retryPolicy.MaxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		if wait < 0 {
+			wait = c.retryPolicy.backoff(attempt)
+		}
+		log.Printf("PLD: intento %d/%d fallido (%v), reintentando en %s", attempt, c.retryPolicy.MaxAttempts, err, wait)
+		if err := c.sleep(ctx, wait); err != nil {
+			lastErr = err
+			break
+		}
+	}
+
+	if attempt > 1 {
+		log.Printf("PLD: validación fallida tras %d intentos: %v", attempt, lastErr)
+		return nil, fmt.Errorf("servicio PLD falló tras %d intentos: %w", attempt, lastErr)
+	}
+	return nil, lastErr
+}
+
+// doValidate realiza un único intento contra el servicio PLD. Retorna si el error
+// es reintentable y, si el servicio lo indicó con Retry-After, la espera sugerida
+// (negativa cuando debe usarse el backoff de la política)
+func (c *PLDClient) doValidate(ctx context.Context, jsonPayload []byte) (*domain.PLDResponse, time.Duration, bool, error) {
 	// Crear solicitud HTTP usando el endpoint real
-	req, err := http.NewRequest("POST", c.baseURL+"/check-blacklist", bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/check-blacklist", bytes.NewReader(jsonPayload))
 	if err != nil {
-		return nil, fmt.Errorf("error creando solicitud: %w", err)
+		return nil, -1, false, fmt.Errorf("error creando solicitud: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -68,35 +140,92 @@ func (c *PLDClient) ValidateUser(idNumber, name, email string) (*domain.PLDRespo
 	// Realizar solicitud
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error realizando solicitud: %w", err)
+		return nil, -1, c.retryPolicy.isRetryableError(err), &PLDTransportError{Op: "realizando solicitud", Err: err}
 	}
 	defer resp.Body.Close()
 
 	// Verificar código de respuesta (el servicio real retorna 201)
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("servicio PLD retornó código %d", resp.StatusCode)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Descartar el cuerpo para poder reutilizar la conexión
+		io.Copy(io.Discard, resp.Body)
+
+		wait := time.Duration(-1)
+		if d, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			wait = d
+			if c.retryPolicy.MaxDelay > 0 && wait > c.retryPolicy.MaxDelay {
+				wait = c.retryPolicy.MaxDelay
+			}
+		}
+
+		return nil, wait, c.retryPolicy.isRetryableStatus(resp.StatusCode), &PLDStatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, -1, c.retryPolicy.isRetryableError(err), &PLDTransportError{Op: "leyendo respuesta", Err: err}
 	}
 
-	// Decodificar respuesta según el formato real
-	var pldResponseReal struct {
-		IsInBlacklist bool `json:"is_in_blacklist"`
+	// Un código 2xx distinto de 201 indica un cambio de contrato del proveedor
+	if resp.StatusCode != http.StatusCreated {
+		return nil, -1, false, newPLDSchemaError(resp.StatusCode, "código de respuesta inesperado", body)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&pldResponseReal); err != nil {
-		return nil, fmt.Errorf("error decodificando respuesta: %w", err)
+	isInBlacklist, err := decodeCheckBlacklist(resp.StatusCode, body)
+	if err != nil {
+		return nil, -1, false, err
 	}
 
 	// Convertir a nuestro formato interno
 	pldResponse := &domain.PLDResponse{
-		IsBlacklisted: pldResponseReal.IsInBlacklist,
-		Status:        "clean",
-		Reason:        "",
+		IsBlacklisted:  isInBlacklist,
+		Status:         "clean",
+		Reason:         "",
+		Provider:       PLDProviderName,
+		RequestPayload: string(jsonPayload),
+		RawResponse:    string(body),
 	}
 
-	if pldResponseReal.IsInBlacklist {
+	// El servicio remoto solo informa coincidencias exactas
+	if isInBlacklist {
+		pldResponse.MatchScore = 1
 		pldResponse.Status = "blacklisted"
 		pldResponse.Reason = "Usuario en lista negra"
 	}
 
-	return pldResponse, nil
+	return pldResponse, -1, false, nil
+}
+
+// decodeCheckBlacklist interpreta el cuerpo de /check-blacklist. El contrato exige un
+// objeto JSON con is_in_blacklist booleano; los campos adicionales se toleran porque
+// agregarlos no cambia el significado de la respuesta
+func decodeCheckBlacklist(statusCode int, body []byte) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return false, newPLDSchemaError(statusCode, "el cuerpo no es un objeto JSON", body)
+	}
+
+	raw, ok := fields["is_in_blacklist"]
+	if !ok {
+		return false, newPLDSchemaError(statusCode, "falta el campo is_in_blacklist", body)
+	}
+
+	var isInBlacklist *bool
+	if err := json.Unmarshal(raw, &isInBlacklist); err != nil || isInBlacklist == nil {
+		return false, newPLDSchemaError(statusCode, "is_in_blacklist no es booleano", body)
+	}
+
+	return *isInBlacklist, nil
+}
+
+// sleepContext espera la duración indicada o hasta que el contexto se cancele
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
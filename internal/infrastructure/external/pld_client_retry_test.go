@@ -0,0 +1,329 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestPLDClient crea un cliente apuntando a un servidor de prueba y registra las esperas
+func newTestPLDClient(baseURL string, policy RetryPolicy) (*PLDClient, *[]time.Duration) {
+	client := NewPLDClientWithConfig(PLDClientConfig{
+		BaseURL:     baseURL,
+		Timeout:     2 * time.Second,
+		RetryPolicy: policy,
+	})
+
+	sleeps := &[]time.Duration{}
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		*sleeps = append(*sleeps, d)
+		return nil
+	}
+	return client, sleeps
+}
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = 10 * time.Millisecond
+	policy.MaxDelay = 100 * time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestPLDClient_ValidateUser_RetriesOnServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"is_in_blacklist": false}`))
+	}))
+	defer server.Close()
+
+	client, sleeps := newTestPLDClient(server.URL, testRetryPolicy())
+
+	response, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.IsBlacklisted {
+		t.Error("Expected user not to be blacklisted")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}
+	if len(*sleeps) != len(expected) {
+		t.Fatalf("Expected %d sleeps, got %v", len(expected), *sleeps)
+	}
+	for i, d := range expected {
+		if (*sleeps)[i] != d {
+			t.Errorf("Expected sleep %d to be %s, got %s", i, d, (*sleeps)[i])
+		}
+	}
+}
+
+func TestPLDClient_ValidateUser_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, _ := newTestPLDClient(server.URL, testRetryPolicy())
+
+	_, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestPLDClient_ValidateUser_DoesNotRetryClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, sleeps := newTestPLDClient(server.URL, testRetryPolicy())
+
+	if _, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"}); err == nil {
+		t.Fatal("Expected error for 400 response")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+	if len(*sleeps) != 0 {
+		t.Errorf("Expected no sleeps, got %v", *sleeps)
+	}
+}
+
+func TestPLDClient_ValidateUser_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"is_in_blacklist": true}`))
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxDelay = 5 * time.Second
+	client, sleeps := newTestPLDClient(server.URL, policy)
+
+	response, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !response.IsBlacklisted {
+		t.Error("Expected user to be blacklisted")
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != time.Second {
+		t.Errorf("Expected a single 1s sleep from Retry-After, got %v", *sleeps)
+	}
+}
+
+func TestPLDClient_ValidateUser_RetryAfterCappedByMaxDelay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"is_in_blacklist": false}`))
+	}))
+	defer server.Close()
+
+	client, sleeps := newTestPLDClient(server.URL, testRetryPolicy())
+
+	if _, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != 100*time.Millisecond {
+		t.Errorf("Expected sleep capped at 100ms, got %v", *sleeps)
+	}
+}
+
+func TestPLDClient_ValidateUser_RetriesNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseURL := server.URL
+	server.Close()
+
+	client, sleeps := newTestPLDClient(baseURL, testRetryPolicy())
+
+	if _, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"}); err == nil {
+		t.Fatal("Expected error for closed server")
+	}
+	if len(*sleeps) != 2 {
+		t.Errorf("Expected 2 retries for network errors, got %v", *sleeps)
+	}
+
+	policy := testRetryPolicy()
+	policy.RetryNetworkErrors = false
+	client, sleeps = newTestPLDClient(baseURL, policy)
+
+	if _, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"}); err == nil {
+		t.Fatal("Expected error for closed server")
+	}
+	if len(*sleeps) != 0 {
+		t.Errorf("Expected no retries when network errors are not retryable, got %v", *sleeps)
+	}
+}
+
+func TestRetryPolicy_BackoffWithJitterStaysInBounds(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+		Jitter:    0.5,
+	}
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := 100 * time.Millisecond << (attempt - 1)
+		if base > time.Second {
+			base = time.Second
+		}
+		for i := 0; i < 50; i++ {
+			d := policy.backoff(attempt)
+			if d < base/2 || d > time.Second {
+				t.Fatalf("Attempt %d: backoff %s out of bounds", attempt, d)
+			}
+		}
+	}
+}
+
+func TestPLDClient_ConcurrentRetriesWithJitter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.Jitter = 0.5
+	client, _ := newTestPLDClient(server.URL, policy)
+	client.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	// Las llamadas simultáneas (handlers y lotes) calculan el jitter sin compartir estado
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"}); err == nil {
+				t.Error("Expected an error after exhausting retries")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRetryPolicyFromEnv(t *testing.T) {
+	t.Setenv("PLD_RETRY_MAX_ATTEMPTS", "5")
+	t.Setenv("PLD_RETRY_BASE_DELAY", "50ms")
+	t.Setenv("PLD_RETRY_MAX_DELAY", "2s")
+	t.Setenv("PLD_RETRY_JITTER", "0.1")
+	t.Setenv("PLD_RETRY_STATUS_CODES", "503, 504")
+	t.Setenv("PLD_RETRY_NETWORK_ERRORS", "false")
+
+	policy := RetryPolicyFromEnv()
+
+	if policy.MaxAttempts != 5 || policy.BaseDelay != 50*time.Millisecond || policy.MaxDelay != 2*time.Second {
+		t.Errorf("Unexpected policy %+v", policy)
+	}
+	if policy.Jitter != 0.1 || policy.RetryNetworkErrors {
+		t.Errorf("Unexpected policy %+v", policy)
+	}
+	if !policy.isRetryableStatus(503) || policy.isRetryableStatus(500) {
+		t.Errorf("Unexpected retryable status codes %v", policy.RetryableStatusCodes)
+	}
+}
+
+func TestPLDClient_ValidateUser_CancellationAbortsInFlightRequest(t *testing.T) {
+	var calls int32
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// Consumir el cuerpo para que el servidor detecte el cierre de la conexión
+		io.ReadAll(r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		close(released)
+	}))
+	defer server.Close()
+
+	client, sleeps := newTestPLDClient(server.URL, testRetryPolicy())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := client.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected cancellation to abort the request promptly, took %s", elapsed)
+	}
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Error("Expected the server to observe the cancellation")
+	}
+	if calls != 1 || len(*sleeps) != 0 {
+		t.Errorf("Expected a single call without retries, got %d calls and sleeps %v", calls, *sleeps)
+	}
+}
+
+func TestPLDClient_ValidateUser_DeadlineStopsBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.BaseDelay = 5 * time.Second
+	policy.MaxDelay = 5 * time.Second
+	client := NewPLDClientWithConfig(PLDClientConfig{BaseURL: server.URL, Timeout: time.Second, RetryPolicy: policy})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ValidateUser(ctx, domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the deadline to interrupt the backoff, took %s", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
@@ -1,6 +1,8 @@
 package external
 
 import (
+	"context"
+	"crabi-test/internal/domain"
 	"testing"
 )
 
@@ -10,7 +12,7 @@ import (
 func TestPLDClient_ValidateUser_Success(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("12345678", "Juan Pérez", "juan.perez@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -29,7 +31,7 @@ func TestPLDClient_ValidateUser_Success(t *testing.T) {
 func TestPLDClient_ValidateUser_WithComplexName(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("87654321", "Juan Carlos Pérez González", "juan.carlos@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "87654321", Name: "Juan Carlos Pérez González", Email: "juan.carlos@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -44,7 +46,7 @@ func TestPLDClient_ValidateUser_WithComplexName(t *testing.T) {
 func TestPLDClient_ValidateUser_SingleName(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("11111111", "Ana", "ana@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "11111111", Name: "Ana", Email: "ana@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -59,7 +61,7 @@ func TestPLDClient_ValidateUser_SingleName(t *testing.T) {
 func TestPLDClient_ValidateUser_EmptyName(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("12345678", "", "test@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "", Email: "test@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -74,7 +76,7 @@ func TestPLDClient_ValidateUser_EmptyName(t *testing.T) {
 func TestPLDClient_ValidateUser_EmptyEmail(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("12345678", "Juan Pérez", "")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: ""})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -89,7 +91,7 @@ func TestPLDClient_ValidateUser_EmptyEmail(t *testing.T) {
 func TestPLDClient_ValidateUser_EmptyIDNumber(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("", "Juan Pérez", "juan.perez@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "", Name: "Juan Pérez", Email: "juan.perez@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -104,7 +106,7 @@ func TestPLDClient_ValidateUser_EmptyIDNumber(t *testing.T) {
 func TestPLDClient_ValidateUser_AllEmpty(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("", "", "")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "", Name: "", Email: ""})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -122,7 +124,7 @@ func TestPLDClient_ValidateUser_VeryLongName(t *testing.T) {
 	// Nombre muy largo
 	longName := "Juan Carlos María José Francisco de Paula Juan Nepomuceno María de los Remedios Cipriano de la Santísima Trinidad Ruiz y Picasso"
 
-	response, err := pldClient.ValidateUser("12345678", longName, "picasso@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: longName, Email: "picasso@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -140,7 +142,7 @@ func TestPLDClient_ValidateUser_SpecialCharacters(t *testing.T) {
 	// Nombre con caracteres especiales
 	nameWithSpecialChars := "José María O'Connor-Smith"
 
-	response, err := pldClient.ValidateUser("12345678", nameWithSpecialChars, "jose.maria@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: nameWithSpecialChars, Email: "jose.maria@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -158,7 +160,7 @@ func TestPLDClient_ValidateUser_NumbersInName(t *testing.T) {
 	// Nombre con números
 	nameWithNumbers := "Juan123 Pérez456"
 
-	response, err := pldClient.ValidateUser("12345678", nameWithNumbers, "juan123@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: nameWithNumbers, Email: "juan123@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -176,7 +178,7 @@ func TestPLDClient_ValidateUser_UnicodeCharacters(t *testing.T) {
 	// Nombre con caracteres Unicode
 	unicodeName := "José María Ñoño"
 
-	response, err := pldClient.ValidateUser("12345678", unicodeName, "jose.maria@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: unicodeName, Email: "jose.maria@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -191,7 +193,7 @@ func TestPLDClient_ValidateUser_UnicodeCharacters(t *testing.T) {
 func TestPLDClient_ValidateUser_ResponseStructure(t *testing.T) {
 	pldClient := NewPLDClient()
 
-	response, err := pldClient.ValidateUser("12345678", "Juan Pérez", "juan.perez@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
 	if err != nil {
 		t.Logf("PLD service not available: %v", err)
 		t.Skip("PLD service not available for testing")
@@ -230,7 +232,7 @@ func TestPLDClient_ValidateUser_MultipleCalls(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		response, err := pldClient.ValidateUser(tc.idNumber, tc.name, tc.email)
+		response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: tc.idNumber, Name: tc.name, Email: tc.email})
 		if err != nil {
 			t.Logf("PLD service not available for %s: %v", tc.name, err)
 			continue
@@ -0,0 +1,73 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPLDSchemaDrift agrupa las respuestas del proveedor que no cumplen el contrato
+// (código o cuerpo distintos a los esperados). Indica un cambio en el proveedor, no una
+// falla transitoria, por lo que nunca se reintenta
+var ErrPLDSchemaDrift = errors.New("respuesta del servicio PLD fuera de contrato")
+
+// ErrPLDTransport agrupa las fallas de red o de lectura al comunicarse con el proveedor
+var ErrPLDTransport = errors.New("falla de comunicación con el servicio PLD")
+
+// maxErrorBody limita el cuerpo de respuesta conservado en los errores de contrato
+const maxErrorBody = 512
+
+// PLDSchemaError describe una respuesta que no cumple el contrato del proveedor
+type PLDSchemaError struct {
+	StatusCode int
+	Reason     string
+	Body       string
+}
+
+// Error implementa error
+func (e *PLDSchemaError) Error() string {
+	return fmt.Sprintf("error decodificando respuesta: %s (código %d)", e.Reason, e.StatusCode)
+}
+
+// Is permite identificar el error con errors.Is(err, ErrPLDSchemaDrift)
+func (e *PLDSchemaError) Is(target error) bool {
+	return target == ErrPLDSchemaDrift
+}
+
+// PLDStatusError indica que el proveedor respondió con un código de error HTTP
+type PLDStatusError struct {
+	StatusCode int
+}
+
+// Error implementa error
+func (e *PLDStatusError) Error() string {
+	return fmt.Sprintf("servicio PLD retornó código %d", e.StatusCode)
+}
+
+// PLDTransportError indica una falla de red o de lectura durante la solicitud
+type PLDTransportError struct {
+	Op  string
+	Err error
+}
+
+// Error implementa error
+func (e *PLDTransportError) Error() string {
+	return fmt.Sprintf("error %s: %v", e.Op, e.Err)
+}
+
+// Unwrap expone el error de red original
+func (e *PLDTransportError) Unwrap() error {
+	return e.Err
+}
+
+// Is permite identificar el error con errors.Is(err, ErrPLDTransport)
+func (e *PLDTransportError) Is(target error) bool {
+	return target == ErrPLDTransport
+}
+
+// newPLDSchemaError crea un error de contrato conservando un extracto del cuerpo
+func newPLDSchemaError(statusCode int, reason string, body []byte) *PLDSchemaError {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	return &PLDSchemaError{StatusCode: statusCode, Reason: reason, Body: string(body)}
+}
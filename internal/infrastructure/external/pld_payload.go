@@ -0,0 +1,132 @@
+package external
+
+import (
+	"crabi-test/internal/domain"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Campos de la solicitud PLD que pueden enviarse al proveedor remoto
+const (
+	PLDFieldIDNumber        = "id_number"
+	PLDFieldName            = "name"
+	PLDFieldGivenNames      = "given_names"
+	PLDFieldPaternalSurname = "paternal_surname"
+	PLDFieldMaternalSurname = "maternal_surname"
+	PLDFieldSurnames        = "surnames"
+	PLDFieldDateOfBirth     = "date_of_birth"
+	PLDFieldNationality     = "nationality"
+	PLDFieldEmail           = "email"
+)
+
+// pldFieldValues obtiene el valor de cada campo de la solicitud PLD
+var pldFieldValues = map[string]func(domain.PLDRequest) string{
+	PLDFieldIDNumber:        func(r domain.PLDRequest) string { return r.IDNumber },
+	PLDFieldName:            func(r domain.PLDRequest) string { return r.FullName() },
+	PLDFieldGivenNames:      givenNames,
+	PLDFieldPaternalSurname: func(r domain.PLDRequest) string { return r.PaternalSurname },
+	PLDFieldMaternalSurname: func(r domain.PLDRequest) string { return r.MaternalSurname },
+	PLDFieldSurnames:        surnames,
+	PLDFieldDateOfBirth:     func(r domain.PLDRequest) string { return r.DateOfBirth },
+	PLDFieldNationality:     func(r domain.PLDRequest) string { return strings.ToUpper(r.Nationality) },
+	PLDFieldEmail:           func(r domain.PLDRequest) string { return r.Email },
+}
+
+// PLDPayloadMapping asocia cada campo del payload del proveedor con el campo de la
+// solicitud PLD del que toma su valor
+type PLDPayloadMapping map[string]string
+
+// DefaultPLDPayloadMapping retorna el mapeo usado por el servicio PLD de referencia
+func DefaultPLDPayloadMapping() PLDPayloadMapping {
+	return PLDPayloadMapping{
+		"first_name":       PLDFieldGivenNames,
+		"last_name":        PLDFieldSurnames,
+		"paternal_surname": PLDFieldPaternalSurname,
+		"maternal_surname": PLDFieldMaternalSurname,
+		"date_of_birth":    PLDFieldDateOfBirth,
+		"nationality":      PLDFieldNationality,
+		"id_number":        PLDFieldIDNumber,
+		"email":            PLDFieldEmail,
+	}
+}
+
+// ParsePLDPayloadMapping interpreta un mapeo con el formato
+// "campo_proveedor=campo_solicitud,..."; un valor vacío retorna el mapeo por defecto
+func ParsePLDPayloadMapping(value string) (PLDPayloadMapping, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultPLDPayloadMapping(), nil
+	}
+
+	mapping := PLDPayloadMapping{}
+	for _, entry := range strings.Split(value, ",") {
+		target, source, ok := strings.Cut(entry, "=")
+		target, source = strings.TrimSpace(target), strings.TrimSpace(source)
+		if !ok || target == "" || source == "" {
+			return nil, fmt.Errorf("entrada inválida en el mapeo del payload PLD: %q", entry)
+		}
+		if _, known := pldFieldValues[source]; !known {
+			return nil, fmt.Errorf("campo desconocido en el mapeo del payload PLD: %s (válidos: %s)", source, strings.Join(pldFieldNames(), ", "))
+		}
+		mapping[target] = source
+	}
+
+	return mapping, nil
+}
+
+// PLDPayloadMappingFromEnv lee el mapeo del payload de PLD_PAYLOAD_MAPPING
+func PLDPayloadMappingFromEnv() (PLDPayloadMapping, error) {
+	return ParsePLDPayloadMapping(os.Getenv("PLD_PAYLOAD_MAPPING"))
+}
+
+// Payload construye el payload del proveedor para una solicitud. Los campos sin valor
+// se omiten
+func (m PLDPayloadMapping) Payload(request domain.PLDRequest) map[string]string {
+	payload := make(map[string]string, len(m))
+	for target, source := range m {
+		value, ok := pldFieldValues[source]
+		if !ok {
+			continue
+		}
+		if v := strings.TrimSpace(value(request)); v != "" {
+			payload[target] = v
+		}
+	}
+	return payload
+}
+
+// givenNames retorna los nombres de pila. Sin nombre estructurado se toma la primera
+// palabra del nombre completo, como hacía el cliente antes de recibir los apellidos
+func givenNames(request domain.PLDRequest) string {
+	if request.HasStructuredName() {
+		return request.GivenNames
+	}
+	names := strings.Fields(request.FullName())
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// surnames retorna los apellidos. Sin nombre estructurado se toma el resto del nombre completo
+func surnames(request domain.PLDRequest) string {
+	if request.HasStructuredName() {
+		return request.Surnames()
+	}
+	names := strings.Fields(request.FullName())
+	if len(names) < 2 {
+		return ""
+	}
+	return strings.Join(names[1:], " ")
+}
+
+// pldFieldNames retorna los campos de la solicitud PLD ordenados alfabéticamente
+func pldFieldNames() []string {
+	names := make([]string, 0, len(pldFieldValues))
+	for name := range pldFieldValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
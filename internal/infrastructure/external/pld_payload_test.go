@@ -0,0 +1,114 @@
+package external
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPLDPayloadMapping_StructuredName(t *testing.T) {
+	payload := DefaultPLDPayloadMapping().Payload(domain.PLDRequest{
+		IDNumber:        "GALM850412MDFRPR05",
+		Name:            "María de la Luz García López",
+		GivenNames:      "María de la Luz",
+		PaternalSurname: "García",
+		MaternalSurname: "López",
+		DateOfBirth:     "1985-04-12",
+		Nationality:     "mx",
+		Email:           "maria@email.com",
+	})
+
+	expected := map[string]string{
+		"first_name":       "María de la Luz",
+		"last_name":        "García López",
+		"paternal_surname": "García",
+		"maternal_surname": "López",
+		"date_of_birth":    "1985-04-12",
+		"nationality":      "MX",
+		"id_number":        "GALM850412MDFRPR05",
+		"email":            "maria@email.com",
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("Expected %v, got %v", expected, payload)
+	}
+}
+
+func TestPLDPayloadMapping_FullNameFallback(t *testing.T) {
+	payload := DefaultPLDPayloadMapping().Payload(domain.PLDRequest{
+		IDNumber: "12345678",
+		Name:     "Juan Carlos Pérez",
+		Email:    "juan@email.com",
+	})
+
+	expected := map[string]string{
+		"first_name": "Juan",
+		"last_name":  "Carlos Pérez",
+		"id_number":  "12345678",
+		"email":      "juan@email.com",
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("Expected %v, got %v", expected, payload)
+	}
+}
+
+func TestParsePLDPayloadMapping(t *testing.T) {
+	mapping, err := ParsePLDPayloadMapping(" nombres=given_names, apellido_paterno = paternal_surname,curp=id_number ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := PLDPayloadMapping{"nombres": PLDFieldGivenNames, "apellido_paterno": PLDFieldPaternalSurname, "curp": PLDFieldIDNumber}
+	if !reflect.DeepEqual(mapping, expected) {
+		t.Errorf("Expected %v, got %v", expected, mapping)
+	}
+
+	if mapping, err := ParsePLDPayloadMapping(""); err != nil || !reflect.DeepEqual(mapping, DefaultPLDPayloadMapping()) {
+		t.Errorf("Expected default mapping for empty value, got %v (%v)", mapping, err)
+	}
+
+	for _, value := range []string{"first_name", "first_name=", "=given_names", "first_name=apodo"} {
+		if _, err := ParsePLDPayloadMapping(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestPLDClient_ValidateUser_SendsMappedPayload(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"is_in_blacklist": false}`))
+	}))
+	defer server.Close()
+
+	client := NewPLDClientWithConfig(PLDClientConfig{
+		BaseURL:        server.URL,
+		Timeout:        2 * time.Second,
+		RetryPolicy:    testRetryPolicy(),
+		PayloadMapping: PLDPayloadMapping{"nombres": PLDFieldGivenNames, "apellidos": PLDFieldSurnames, "curp": PLDFieldIDNumber},
+	})
+
+	response, err := client.ValidateUser(context.Background(), domain.PLDRequest{
+		IDNumber:        "GALM850412MDFRPR05",
+		Name:            "María de la Luz García",
+		GivenNames:      "María de la Luz",
+		PaternalSurname: "García",
+		Email:           "maria@email.com",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]string{"nombres": "María de la Luz", "apellidos": "García", "curp": "GALM850412MDFRPR05"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected payload %v, got %v", expected, received)
+	}
+	if response.RequestPayload == "" {
+		t.Error("Expected request payload to be recorded")
+	}
+}
@@ -0,0 +1,153 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy define cómo se reintentan las llamadas al servicio PLD
+type RetryPolicy struct {
+	// MaxAttempts es el número total de intentos (1 = sin reintentos)
+	MaxAttempts int
+	// BaseDelay es la espera antes del primer reintento
+	BaseDelay time.Duration
+	// MaxDelay es el límite superior de espera entre intentos
+	MaxDelay time.Duration
+	// Jitter es la fracción (0..1) de la espera que se aleatoriza
+	Jitter float64
+	// RetryableStatusCodes son los códigos HTTP que se consideran transitorios
+	RetryableStatusCodes []int
+	// RetryNetworkErrors indica si se reintentan errores de red (timeouts, conexión rechazada)
+	RetryNetworkErrors bool
+}
+
+// DefaultRetryPolicy retorna la política de reintentos por defecto
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// RetryPolicyFromEnv construye la política de reintentos a partir de variables de entorno,
+// usando los valores por defecto para las que no estén definidas o sean inválidas
+func RetryPolicyFromEnv() RetryPolicy {
+	policy := DefaultRetryPolicy()
+
+	if v, err := strconv.Atoi(os.Getenv("PLD_RETRY_MAX_ATTEMPTS")); err == nil && v > 0 {
+		policy.MaxAttempts = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("PLD_RETRY_BASE_DELAY")); err == nil && v >= 0 {
+		policy.BaseDelay = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("PLD_RETRY_MAX_DELAY")); err == nil && v >= 0 {
+		policy.MaxDelay = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("PLD_RETRY_JITTER"), 64); err == nil && v >= 0 && v <= 1 {
+		policy.Jitter = v
+	}
+	if raw := os.Getenv("PLD_RETRY_STATUS_CODES"); raw != "" {
+		var codes []int
+		for _, part := range strings.Split(raw, ",") {
+			if code, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+				codes = append(codes, code)
+			}
+		}
+		policy.RetryableStatusCodes = codes
+	}
+	if v, err := strconv.ParseBool(os.Getenv("PLD_RETRY_NETWORK_ERRORS")); err == nil {
+		policy.RetryNetworkErrors = v
+	}
+
+	return policy
+}
+
+// isRetryableStatus indica si un código HTTP debe reintentarse
+func (p RetryPolicy) isRetryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryableError indica si un error de transporte debe reintentarse
+func (p RetryPolicy) isRetryableError(err error) bool {
+	if !p.RetryNetworkErrors || err == nil {
+		return false
+	}
+	// La cancelación o el vencimiento del contexto del llamador no se reintentan
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// backoff calcula la espera antes del reintento número attempt (empezando en 1)
+// usando backoff exponencial con jitter. Usa la fuente global de math/rand, que admite
+// llamadas concurrentes
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		// Aleatorizar en el rango [delay*(1-jitter), delay*(1+jitter)]
+		delay = delay * (1 - p.Jitter + 2*p.Jitter*rand.Float64())
+		if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+			delay = float64(p.MaxDelay)
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+// retryAfter interpreta el header Retry-After (segundos o fecha HTTP)
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,136 @@
+package dto
+
+import "time"
+
+// BatchScreeningRowRequest representa un prospecto a validar dentro de un lote
+// @Description Prospecto a validar contra el servicio PLD
+type BatchScreeningRowRequest struct {
+	// @Description Número de identificación del prospecto
+	// @Example "12345678"
+	IDNumber string `json:"id_number" example:"12345678"`
+
+	// @Description Nombre completo del prospecto
+	// @Example "Juan Pérez"
+	Name string `json:"name" example:"Juan Pérez"`
+
+	// @Description Email del prospecto
+	// @Example "juan.perez@email.com"
+	Email string `json:"email" example:"juan.perez@email.com"`
+}
+
+// BatchScreeningRequest representa un lote de prospectos en formato JSON
+// @Description Lote de prospectos a validar
+type BatchScreeningRequest struct {
+	// @Description Prospectos a validar
+	Rows []BatchScreeningRowRequest `json:"rows" binding:"required"`
+}
+
+// BatchScreeningJobResponse representa el estado de un lote de screening
+// @Description Estado de un lote de screening
+type BatchScreeningJobResponse struct {
+	// @Description ID del lote
+	// @Example "1"
+	ID uint `json:"id" example:"1"`
+
+	// @Description Estado del lote (running, completed)
+	// @Example "running"
+	Status string `json:"status" example:"running"`
+
+	// @Description Formato recibido (json, csv)
+	// @Example "csv"
+	Source string `json:"source" example:"csv"`
+
+	// @Description ID del administrador que envió el lote
+	// @Example "1"
+	RequestedBy uint `json:"requested_by" example:"1"`
+
+	// @Description Filas del lote
+	// @Example "120"
+	Total int `json:"total" example:"120"`
+
+	// @Description Filas procesadas
+	// @Example "80"
+	Processed int `json:"processed" example:"80"`
+
+	// @Description Filas sin coincidencias
+	// @Example "75"
+	Clean int `json:"clean" example:"75"`
+
+	// @Description Filas en lista negra
+	// @Example "1"
+	Blacklisted int `json:"blacklisted" example:"1"`
+
+	// @Description Filas con coincidencia no concluyente
+	// @Example "2"
+	Review int `json:"review" example:"2"`
+
+	// @Description Filas inválidas o con error del servicio PLD
+	// @Example "2"
+	Failed int `json:"failed" example:"2"`
+
+	// @Description Fecha de creación del lote
+	// @Example "2024-01-15T10:30:00Z"
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+
+	// @Description Fecha de la última actualización
+	// @Example "2024-01-15T10:31:00Z"
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-15T10:31:00Z"`
+
+	// @Description Fecha de término (vacía si sigue en curso)
+	// @Example "2024-01-15T10:32:00Z"
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2024-01-15T10:32:00Z"`
+}
+
+// BatchScreeningRowResponse representa el resultado de una fila de un lote
+// @Description Resultado de un prospecto del lote
+type BatchScreeningRowResponse struct {
+	// @Description Posición de la fila en el archivo recibido (desde 1)
+	// @Example "1"
+	Line int `json:"line" example:"1"`
+
+	// @Description Número de identificación del prospecto
+	// @Example "12345678"
+	IDNumber string `json:"id_number" example:"12345678"`
+
+	// @Description Nombre completo del prospecto
+	// @Example "Juan Pérez"
+	Name string `json:"name" example:"Juan Pérez"`
+
+	// @Description Email del prospecto
+	// @Example "juan.perez@email.com"
+	Email string `json:"email" example:"juan.perez@email.com"`
+
+	// @Description Resultado (pending, clean, blacklisted, review, error)
+	// @Example "clean"
+	Status string `json:"status" example:"clean"`
+
+	// @Description Motivo reportado por el proveedor
+	// @Example "Usuario en lista negra"
+	Reason string `json:"reason,omitempty" example:"Usuario en lista negra"`
+
+	// @Description Similitud (0..1) de la mejor coincidencia
+	// @Example "0"
+	MatchScore float64 `json:"match_score" example:"0"`
+
+	// @Description Proveedor que respondió
+	// @Example "pld-http"
+	Provider string `json:"provider,omitempty" example:"pld-http"`
+
+	// @Description ID del screening registrado en el historial
+	// @Example "15"
+	ScreeningID *uint `json:"screening_id,omitempty" example:"15"`
+
+	// @Description Error de la fila, si lo hubo
+	// @Example "error validando usuario con servicio PLD"
+	Error string `json:"error,omitempty" example:"error validando usuario con servicio PLD"`
+}
+
+// BatchScreeningResultResponse representa un lote con el resultado de cada fila
+// @Description Lote de screening y resultados por fila
+type BatchScreeningResultResponse struct {
+	// @Description Estado del lote
+	Job BatchScreeningJobResponse `json:"job"`
+
+	// @Description Resultados en el orden recibido
+	Rows []BatchScreeningRowResponse `json:"rows"`
+}
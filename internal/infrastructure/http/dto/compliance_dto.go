@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// RejectedApplicationResponse representa una solicitud de alta rechazada
+// @Description Solicitud de alta rechazada por el servicio PLD
+type RejectedApplicationResponse struct {
+	// @Description ID del registro
+	// @Example "1"
+	ID uint `json:"id" example:"1"`
+
+	// @Description Nombre del solicitante
+	// @Example "Juan Pérez"
+	Name string `json:"name" example:"Juan Pérez"`
+
+	// @Description Email del solicitante
+	// @Example "juan.perez@email.com"
+	Email string `json:"email" example:"juan.perez@email.com"`
+
+	// @Description Número de identificación del solicitante
+	// @Example "12345678"
+	IDNumber string `json:"id_number" example:"12345678"`
+
+	// @Description Motivo del rechazo
+	// @Example "Usuario en lista negra"
+	Reason string `json:"reason" example:"Usuario en lista negra"`
+
+	// @Description Proveedor que reportó la coincidencia
+	// @Example "pld-http"
+	Provider string `json:"provider" example:"pld-http"`
+
+	// @Description ID del screening asociado
+	// @Example "10"
+	ScreeningID *uint `json:"screening_id,omitempty" example:"10"`
+
+	// @Description Fecha del rechazo
+	// @Example "2024-01-15T10:30:00Z"
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// RejectedApplicationListResponse representa el listado de solicitudes rechazadas
+// @Description Listado de solicitudes de alta rechazadas
+type RejectedApplicationListResponse struct {
+	// @Description Solicitudes rechazadas de la más reciente a la más antigua
+	RejectedApplications []RejectedApplicationResponse `json:"rejected_applications"`
+}
@@ -0,0 +1,161 @@
+package dto
+
+import "time"
+
+// MFAChallengeResponse representa la respuesta de login de un usuario con MFA activado
+// @Description Desafío MFA que se completa en /auth/mfa/verify
+type MFAChallengeResponse struct {
+	// @Description Siempre true: el login requiere un segundo factor
+	// @Example "true"
+	MFARequired bool `json:"mfa_required" example:"true"`
+
+	// @Description Token del desafío MFA (un solo uso, con vencimiento)
+	// @Example "Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE"
+	MFAToken string `json:"mfa_token" example:"Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE"`
+
+	// @Description Segundos de vigencia del desafío
+	// @Example "300"
+	ExpiresIn int64 `json:"expires_in" example:"300"`
+}
+
+// MFAVerifyRequest representa el segundo paso del login
+// @Description Token del desafío MFA y un código TOTP o un código de recuperación
+type MFAVerifyRequest struct {
+	// @Description Token del desafío recibido en /auth/login
+	// @Example "Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE"
+	// @Required
+	MFAToken string `json:"mfa_token" binding:"required" example:"Hq7bN2xT9wL4cV1mP8sK3jR6yD0fA5eZuG2oIiQbWnE"`
+
+	// @Description Código de 6 dígitos de la aplicación autenticadora
+	// @Example "492039"
+	Code string `json:"code" binding:"omitempty,len=6,numeric" example:"492039"`
+
+	// @Description Código de recuperación, si no se envía code (cada uno se puede usar una sola vez)
+	// @Example "k3v9q-x2m7p"
+	RecoveryCode string `json:"recovery_code" example:"k3v9q-x2m7p"`
+}
+
+// MFAStatusResponse representa el estado de MFA del usuario
+// @Description Estado de la autenticación multifactor del usuario autenticado
+type MFAStatusResponse struct {
+	// @Description Indica si MFA está activado
+	// @Example "true"
+	Enabled bool `json:"enabled" example:"true"`
+
+	// @Description Indica si hay una inscripción iniciada sin confirmar
+	// @Example "false"
+	Pending bool `json:"pending" example:"false"`
+
+	// @Description Fecha en que se activó MFA
+	// @Example "2026-10-17T10:30:00Z"
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty" example:"2026-10-17T10:30:00Z"`
+
+	// @Description Códigos de recuperación sin usar
+	// @Example "10"
+	RecoveryCodesRemaining int `json:"recovery_codes_remaining" example:"10"`
+
+	// @Description Indica si el rol del usuario exige MFA
+	// @Example "false"
+	Required bool `json:"required" example:"false"`
+}
+
+// MFAEnrollmentResponse representa una inscripción TOTP iniciada
+// @Description Secreto TOTP para configurar la aplicación autenticadora
+type MFAEnrollmentResponse struct {
+	// @Description Secreto en base32, para ingresarlo manualmente en la aplicación
+	// @Example "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+
+	// @Description URI otpauth:// para mostrar como código QR
+	// @Example "otpauth://totp/Crabi:juan.perez@email.com?algorithm=SHA1&digits=6&issuer=Crabi&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/Crabi:juan.perez@email.com?algorithm=SHA1&digits=6&issuer=Crabi&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// MFACodeRequest representa una operación que se confirma con un código TOTP
+// @Description Código de la aplicación autenticadora
+type MFACodeRequest struct {
+	// @Description Código de 6 dígitos de la aplicación autenticadora
+	// @Example "492039"
+	// @Required
+	Code string `json:"code" binding:"required,len=6,numeric" example:"492039"`
+}
+
+// MFADisableRequest representa la desactivación de MFA
+// @Description Código TOTP o código de recuperación
+type MFADisableRequest struct {
+	// @Description Código de 6 dígitos de la aplicación autenticadora
+	// @Example "492039"
+	Code string `json:"code" binding:"omitempty,len=6,numeric" example:"492039"`
+
+	// @Description Código de recuperación, si no se envía code
+	// @Example "k3v9q-x2m7p"
+	RecoveryCode string `json:"recovery_code" example:"k3v9q-x2m7p"`
+}
+
+// MFAConfirmResponse representa la activación de MFA
+// @Description Códigos de recuperación y tokens de una sesión iniciada con MFA
+type MFAConfirmResponse struct {
+	// @Description Códigos de recuperación de un solo uso; no se pueden volver a consultar
+	// @Example "k3v9q-x2m7p,a7d2w-p4n8c"
+	RecoveryCodes []string `json:"recovery_codes" example:"k3v9q-x2m7p,a7d2w-p4n8c"`
+
+	// @Description Token JWT de la sesión con MFA
+	// @Example "eyJhbGciOiJFUzI1NiIsImtpZCI6..."
+	Token string `json:"token" example:"eyJhbGciOiJFUzI1NiIsImtpZCI6..."`
+
+	// @Description Segundos de vigencia del token JWT
+	// @Example "900"
+	ExpiresIn int64 `json:"expires_in" example:"900"`
+
+	// @Description Refresh token de la sesión con MFA; los refresh tokens anteriores se revocan
+	// @Example "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+	RefreshToken string `json:"refresh_token" example:"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"`
+
+	// @Description Fecha de vencimiento del refresh token
+	// @Example "2026-11-16T10:30:00Z"
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at" example:"2026-11-16T10:30:00Z"`
+}
+
+// MFARecoveryCodesResponse representa códigos de recuperación regenerados
+// @Description Nuevos códigos de recuperación; los anteriores dejan de ser válidos
+type MFARecoveryCodesResponse struct {
+	// @Description Códigos de recuperación de un solo uso; no se pueden volver a consultar
+	// @Example "k3v9q-x2m7p,a7d2w-p4n8c"
+	RecoveryCodes []string `json:"recovery_codes" example:"k3v9q-x2m7p,a7d2w-p4n8c"`
+}
+
+// MFAPolicyRequest representa la política MFA de un rol
+// @Description Indica si el rol exige MFA
+type MFAPolicyRequest struct {
+	// @Description Exigir MFA a los usuarios del rol
+	// @Example "true"
+	// @Required
+	Required *bool `json:"required" binding:"required" example:"true"`
+}
+
+// MFAPolicyResponse representa la política MFA de un rol
+// @Description Política MFA de un rol
+type MFAPolicyResponse struct {
+	// @Description Rol
+	// @Example "admin"
+	Role string `json:"role" example:"admin"`
+
+	// @Description Indica si el rol exige MFA
+	// @Example "true"
+	Required bool `json:"required" example:"true"`
+
+	// @Description ID del administrador que configuró la política
+	// @Example "1"
+	UpdatedBy uint `json:"updated_by,omitempty" example:"1"`
+
+	// @Description Fecha de la última modificación
+	// @Example "2026-10-17T10:30:00Z"
+	UpdatedAt *time.Time `json:"updated_at,omitempty" example:"2026-10-17T10:30:00Z"`
+}
+
+// MFAPolicyListResponse representa las políticas MFA de todos los roles
+// @Description Política MFA de cada rol
+type MFAPolicyListResponse struct {
+	// @Description Políticas por rol; los roles sin política configurada no exigen MFA
+	Policies []MFAPolicyResponse `json:"policies"`
+}
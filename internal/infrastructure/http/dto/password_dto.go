@@ -0,0 +1,38 @@
+package dto
+
+// ChangePasswordRequest representa el cambio de contraseña del usuario autenticado
+// @Description Contraseña actual y nueva contraseña
+type ChangePasswordRequest struct {
+	// @Description Contraseña actual
+	// @Example "password123"
+	// @Required
+	CurrentPassword string `json:"current_password" binding:"required" example:"password123"`
+
+	// @Description Nueva contraseña (mínimo 8 caracteres, distinta de la actual)
+	// @Example "nueva-password456"
+	// @Required
+	NewPassword string `json:"new_password" binding:"required,min=8" example:"nueva-password456"`
+}
+
+// ForgotPasswordRequest representa la solicitud de restablecimiento de contraseña
+// @Description Email de la cuenta a restablecer
+type ForgotPasswordRequest struct {
+	// @Description Email del usuario
+	// @Example "juan.perez@email.com"
+	// @Required
+	Email string `json:"email" binding:"required,email" example:"juan.perez@email.com"`
+}
+
+// ResetPasswordRequest representa el restablecimiento de contraseña con un token
+// @Description Token de restablecimiento recibido y nueva contraseña
+type ResetPasswordRequest struct {
+	// @Description Token de restablecimiento (un solo uso, con vencimiento)
+	// @Example "q3Jx9v0C2m8pY7tR1bW4nK6eH5sD0aLzF8uGiQ2oVcE"
+	// @Required
+	Token string `json:"token" binding:"required" example:"q3Jx9v0C2m8pY7tR1bW4nK6eH5sD0aLzF8uGiQ2oVcE"`
+
+	// @Description Nueva contraseña (mínimo 8 caracteres)
+	// @Example "nueva-password456"
+	// @Required
+	NewPassword string `json:"new_password" binding:"required,min=8" example:"nueva-password456"`
+}
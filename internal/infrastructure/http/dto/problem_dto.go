@@ -0,0 +1,71 @@
+package dto
+
+// ProblemContentType es el tipo de contenido de las respuestas de error (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails representa una respuesta de error con el formato de RFC 7807
+// @Description Respuesta de error (application/problem+json, RFC 7807)
+type ProblemDetails struct {
+	// @Description URI que identifica el tipo de problema
+	// @Example "/api/v1/problems/invalid_input"
+	Type string `json:"type" example:"/api/v1/problems/invalid_input"`
+
+	// @Description Resumen del tipo de problema; no cambia entre ocurrencias
+	// @Example "Solicitud inválida"
+	Title string `json:"title" example:"Solicitud inválida"`
+
+	// @Description Código HTTP de la respuesta
+	// @Example 400
+	Status int `json:"status" example:"400"`
+
+	// @Description Explicación de esta ocurrencia del problema
+	// @Example "La solicitud tiene campos inválidos"
+	Detail string `json:"detail,omitempty" example:"La solicitud tiene campos inválidos"`
+
+	// @Description Ruta de la solicitud que originó el problema
+	// @Example "/api/v1/users"
+	Instance string `json:"instance,omitempty" example:"/api/v1/users"`
+
+	// @Description Código estable del error, pensado para ser interpretado por los clientes
+	// @Example "invalid_input"
+	Code string `json:"code" example:"invalid_input"`
+
+	// @Description Errores de validación por campo
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describe un campo que no cumple las validaciones
+// @Description Error de validación de un campo
+type FieldError struct {
+	// @Description Nombre del campo en el JSON de la solicitud
+	// @Example "email"
+	Field string `json:"field" example:"email"`
+
+	// @Description Regla de validación que no se cumple
+	// @Example "required"
+	Code string `json:"code" example:"required"`
+
+	// @Description Descripción del error
+	// @Example "es obligatorio"
+	Message string `json:"message" example:"es obligatorio"`
+}
+
+// ProblemTypeResponse describe un tipo de problema
+// @Description Tipo de problema referenciado por el campo type de las respuestas de error
+type ProblemTypeResponse struct {
+	// @Description URI que identifica el tipo de problema
+	// @Example "/api/v1/problems/blacklisted"
+	Type string `json:"type" example:"/api/v1/problems/blacklisted"`
+
+	// @Description Código estable del error
+	// @Example "blacklisted"
+	Code string `json:"code" example:"blacklisted"`
+
+	// @Description Resumen del tipo de problema
+	// @Example "Usuario en lista negra"
+	Title string `json:"title" example:"Usuario en lista negra"`
+
+	// @Description Código HTTP con el que se reporta
+	// @Example 409
+	Status int `json:"status" example:"409"`
+}
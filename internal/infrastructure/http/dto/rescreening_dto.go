@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// RescreeningRunResponse representa una ejecución del re-screening de usuarios
+// @Description Ejecución del re-screening periódico de la base de usuarios
+type RescreeningRunResponse struct {
+	// @Description ID de la ejecución
+	// @Example "1"
+	ID uint `json:"id" example:"1"`
+
+	// @Description Origen de la ejecución (scheduled, manual)
+	// @Example "manual"
+	Trigger string `json:"trigger" example:"manual"`
+
+	// @Description Estado de la ejecución (running, completed, failed)
+	// @Example "running"
+	Status string `json:"status" example:"running"`
+
+	// @Description ID del último usuario procesado
+	// @Example "250"
+	LastUserID uint `json:"last_user_id" example:"250"`
+
+	// @Description Usuarios procesados
+	// @Example "250"
+	Processed int `json:"processed" example:"250"`
+
+	// @Description Usuarios marcados en lista negra durante la ejecución
+	// @Example "1"
+	Flagged int `json:"flagged" example:"1"`
+
+	// @Description Usuarios cuya validación falló
+	// @Example "0"
+	Failed int `json:"failed" example:"0"`
+
+	// @Description Error que detuvo la ejecución
+	// @Example "error leyendo usuarios"
+	Error string `json:"error,omitempty" example:"error leyendo usuarios"`
+
+	// @Description Fecha de inicio
+	// @Example "2024-03-01T03:00:00Z"
+	StartedAt time.Time `json:"started_at" example:"2024-03-01T03:00:00Z"`
+
+	// @Description Fecha del último avance registrado
+	// @Example "2024-03-01T03:05:00Z"
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-01T03:05:00Z"`
+
+	// @Description Fecha de finalización
+	// @Example "2024-03-01T03:10:00Z"
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2024-03-01T03:10:00Z"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+// ReviewDecisionRequest representa la decisión de cumplimiento sobre un alta pendiente
+// @Description Justificación de la decisión de revisión
+type ReviewDecisionRequest struct {
+	// @Description Justificación de la decisión (obligatoria, queda en el log de auditoría)
+	// @Example "Fecha de nacimiento y CURP no coinciden con la entrada de la lista"
+	Justification string `json:"justification" binding:"required" example:"Fecha de nacimiento y CURP no coinciden con la entrada de la lista"`
+}
+
+// ReviewDecisionResponse representa una decisión registrada en el log de auditoría
+// @Description Decisión de revisión de cumplimiento
+type ReviewDecisionResponse struct {
+	// @Description ID de la decisión
+	// @Example "1"
+	ID uint `json:"id" example:"1"`
+
+	// @Description ID del usuario revisado
+	// @Example "12"
+	UserID uint `json:"user_id" example:"12"`
+
+	// @Description ID del oficial de cumplimiento que tomó la decisión
+	// @Example "1"
+	ReviewerID uint `json:"reviewer_id" example:"1"`
+
+	// @Description Decisión (approved, rejected)
+	// @Example "approved"
+	Decision string `json:"decision" example:"approved"`
+
+	// @Description Justificación de la decisión
+	// @Example "Fecha de nacimiento y CURP no coinciden con la entrada de la lista"
+	Justification string `json:"justification" example:"Fecha de nacimiento y CURP no coinciden con la entrada de la lista"`
+
+	// @Description Fecha de la decisión
+	// @Example "2024-01-15T10:30:00Z"
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// ReviewDecisionListResponse representa el log de decisiones de un usuario
+// @Description Decisiones de revisión de un usuario
+type ReviewDecisionListResponse struct {
+	// @Description Decisiones de la más reciente a la más antigua
+	Decisions []ReviewDecisionResponse `json:"decisions"`
+}
+
+// ReviewItemResponse representa un alta en la cola de revisión
+// @Description Alta pendiente de revisión de cumplimiento
+type ReviewItemResponse struct {
+	// @Description Usuario pendiente de revisión
+	User UserResponse `json:"user"`
+
+	// @Description Motivo por el que el alta requiere revisión
+	// @Example "Posible coincidencia en lista uif_lpb: María López (Lavado de dinero), similitud 0.81"
+	Reason string `json:"reason" example:"Posible coincidencia en lista uif_lpb: María López (Lavado de dinero), similitud 0.81"`
+
+	// @Description Screening más reciente del usuario
+	Screening *ScreeningResponse `json:"screening,omitempty"`
+}
+
+// ReviewQueueResponse representa la cola de revisión
+// @Description Cola de altas pendientes de revisión
+type ReviewQueueResponse struct {
+	// @Description Altas pendientes de la más antigua a la más reciente
+	Items []ReviewItemResponse `json:"items"`
+}
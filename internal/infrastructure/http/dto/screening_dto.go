@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// ScreeningResponse representa un registro del historial de screenings PLD
+// @Description Registro de auditoría de una validación PLD
+type ScreeningResponse struct {
+	// @Description ID del screening
+	// @Example "1"
+	ID uint `json:"id" example:"1"`
+
+	// @Description ID del usuario vinculado (vacío si el alta no se completó)
+	// @Example "1"
+	UserID *uint `json:"user_id,omitempty" example:"1"`
+
+	// @Description Número de identificación validado
+	// @Example "12345678"
+	IDNumber string `json:"id_number" example:"12345678"`
+
+	// @Description Email validado
+	// @Example "juan.perez@email.com"
+	Email string `json:"email" example:"juan.perez@email.com"`
+
+	// @Description Payload enviado al proveedor
+	// @Example "{\"email\":\"juan.perez@email.com\",\"first_name\":\"Juan\",\"id_number\":\"12345678\",\"last_name\":\"Pérez\"}"
+	RequestPayload string `json:"request_payload" example:"{\"email\":\"juan.perez@email.com\",\"first_name\":\"Juan\",\"id_number\":\"12345678\",\"last_name\":\"Pérez\"}"`
+
+	// @Description Respuesta cruda del proveedor
+	// @Example "{\"is_in_blacklist\":false}"
+	RawResponse string `json:"raw_response,omitempty" example:"{\"is_in_blacklist\":false}"`
+
+	// @Description Estado normalizado (clean, blacklisted, error)
+	// @Example "clean"
+	Status string `json:"status" example:"clean"`
+
+	// @Description Proveedor que realizó la validación
+	// @Example "pld-http"
+	Provider string `json:"provider" example:"pld-http"`
+
+	// @Description Similitud (0..1) de la mejor coincidencia en listas de sanciones
+	// @Example "0.93"
+	MatchScore float64 `json:"match_score" example:"0.93"`
+
+	// @Description Latencia del proveedor en milisegundos
+	// @Example "120"
+	LatencyMs int64 `json:"latency_ms" example:"120"`
+
+	// @Description Error reportado por el proveedor, si lo hubo
+	// @Example "servicio PLD retornó código 503"
+	Error string `json:"error,omitempty" example:"servicio PLD retornó código 503"`
+
+	// @Description Fecha de la validación
+	// @Example "2024-01-15T10:30:00Z"
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// ScreeningListResponse representa el historial de screenings de un usuario
+// @Description Historial de screenings PLD
+type ScreeningListResponse struct {
+	// @Description Screenings del más reciente al más antiguo
+	Screenings []ScreeningResponse `json:"screenings"`
+}
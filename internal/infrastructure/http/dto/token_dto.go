@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// RefreshTokenRequest representa la renovación del token de acceso
+// @Description Refresh token emitido en el login o en la renovación anterior
+type RefreshTokenRequest struct {
+	// @Description Refresh token vigente (cada uno se puede usar una sola vez)
+	// @Example "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+	// @Required
+	RefreshToken string `json:"refresh_token" binding:"required" example:"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"`
+}
+
+// TokenResponse representa la respuesta de renovación de tokens
+// @Description Nuevo token JWT y nuevo refresh token
+type TokenResponse struct {
+	// @Description Token JWT para autenticación
+	// @Example "eyJhbGciOiJFUzI1NiIsImtpZCI6..."
+	Token string `json:"token" example:"eyJhbGciOiJFUzI1NiIsImtpZCI6..."`
+
+	// @Description Segundos de vigencia del token JWT
+	// @Example "900"
+	ExpiresIn int64 `json:"expires_in" example:"900"`
+
+	// @Description Nuevo refresh token; el anterior deja de ser válido
+	// @Example "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+	RefreshToken string `json:"refresh_token" example:"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"`
+
+	// @Description Fecha de vencimiento del nuevo refresh token
+	// @Example "2026-11-16T10:30:00Z"
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at" example:"2026-11-16T10:30:00Z"`
+}
+
+// LogoutRequest representa el cierre de sesión. El cuerpo es opcional
+// @Description Refresh token de la sesión y alcance del cierre
+type LogoutRequest struct {
+	// @Description Refresh token de la sesión, que se revoca junto con el token de acceso
+	// @Example "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+	RefreshToken string `json:"refresh_token" example:"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"`
+
+	// @Description Cierra todas las sesiones del usuario en lugar de solo la actual
+	// @Example "false"
+	AllSessions bool `json:"all_sessions" example:"false"`
+}
@@ -24,6 +24,62 @@ type CreateUserRequest struct {
 	// @Example "12345678"
 	// @Required
 	IDNumber string `json:"id_number" binding:"required,min=8,max=20" example:"12345678"`
+
+	// @Description Nombre(s) de pila; si se indica, el apellido paterno es obligatorio
+	// @Example "María de la Luz"
+	GivenNames string `json:"given_names" binding:"required_with=PaternalSurname MaternalSurname,max=100" example:"María de la Luz"`
+
+	// @Description Apellido paterno
+	// @Example "García"
+	PaternalSurname string `json:"paternal_surname" binding:"required_with=GivenNames,max=60" example:"García"`
+
+	// @Description Apellido materno (opcional)
+	// @Example "López"
+	MaternalSurname string `json:"maternal_surname" binding:"max=60" example:"López"`
+
+	// @Description Fecha de nacimiento en formato AAAA-MM-DD
+	// @Example "1985-04-12"
+	DateOfBirth string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02" example:"1985-04-12"`
+
+	// @Description Nacionalidad (código ISO 3166-1 alfa-2 en mayúsculas)
+	// @Example "MX"
+	Nationality string `json:"nationality" binding:"omitempty,iso3166_1_alpha2" example:"MX"`
+}
+
+// UpdateProfileRequest representa la actualización parcial del perfil de un usuario
+// @Description Actualización parcial del perfil: solo cambian los campos incluidos. Cambiar la identidad (nombre, email, identificación o datos estructurados) valida de nuevo al usuario contra el servicio PLD
+type UpdateProfileRequest struct {
+	// @Description Nombre completo del usuario
+	// @Example "Juan Pérez"
+	Name *string `json:"name" binding:"omitempty,min=2,max=100" example:"Juan Pérez"`
+
+	// @Description Email del usuario (debe ser único)
+	// @Example "juan.perez@email.com"
+	Email *string `json:"email" binding:"omitempty,email" example:"juan.perez@email.com"`
+
+	// @Description Número de identificación personal
+	// @Example "12345678"
+	IDNumber *string `json:"id_number" binding:"omitempty,min=8,max=20" example:"12345678"`
+
+	// @Description Nombre(s) de pila; requiere apellido paterno
+	// @Example "María de la Luz"
+	GivenNames *string `json:"given_names" binding:"omitempty,max=100" example:"María de la Luz"`
+
+	// @Description Apellido paterno
+	// @Example "García"
+	PaternalSurname *string `json:"paternal_surname" binding:"omitempty,max=60" example:"García"`
+
+	// @Description Apellido materno; una cadena vacía lo elimina
+	// @Example "López"
+	MaternalSurname *string `json:"maternal_surname" binding:"omitempty,max=60" example:"López"`
+
+	// @Description Fecha de nacimiento en formato AAAA-MM-DD
+	// @Example "1985-04-12"
+	DateOfBirth *string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02" example:"1985-04-12"`
+
+	// @Description Nacionalidad (código ISO 3166-1 alfa-2 en mayúsculas)
+	// @Example "MX"
+	Nationality *string `json:"nationality" binding:"omitempty,iso3166_1_alpha2" example:"MX"`
 }
 
 // LoginRequest representa la solicitud de login
@@ -59,6 +115,34 @@ type UserResponse struct {
 	// @Example "12345678"
 	IDNumber string `json:"id_number" example:"12345678"`
 
+	// @Description Nombre(s) de pila
+	// @Example "María de la Luz"
+	GivenNames string `json:"given_names,omitempty" example:"María de la Luz"`
+
+	// @Description Apellido paterno
+	// @Example "García"
+	PaternalSurname string `json:"paternal_surname,omitempty" example:"García"`
+
+	// @Description Apellido materno
+	// @Example "López"
+	MaternalSurname string `json:"maternal_surname,omitempty" example:"López"`
+
+	// @Description Fecha de nacimiento en formato AAAA-MM-DD
+	// @Example "1985-04-12"
+	DateOfBirth string `json:"date_of_birth,omitempty" example:"1985-04-12"`
+
+	// @Description Nacionalidad (código ISO 3166-1 alfa-2 en mayúsculas)
+	// @Example "MX"
+	Nationality string `json:"nationality,omitempty" example:"MX"`
+
+	// @Description Rol del usuario (user, admin)
+	// @Example "user"
+	Role string `json:"role" example:"user"`
+
+	// @Description Estado del usuario (active, pending_review, rejected)
+	// @Example "active"
+	Status string `json:"status" example:"active"`
+
 	// @Description Fecha de creación del usuario
 	// @Example "2024-01-15T10:30:00Z"
 	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
@@ -66,29 +150,41 @@ type UserResponse struct {
 	// @Description Fecha de última actualización del usuario
 	// @Example "2024-01-15T10:30:00Z"
 	UpdatedAt time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+
+	// @Description Fecha en que el re-screening detectó al usuario en lista negra
+	// @Example "2024-03-01T03:00:00Z"
+	FlaggedAt *time.Time `json:"flagged_at,omitempty" example:"2024-03-01T03:00:00Z"`
+
+	// @Description Motivo reportado por el servicio PLD al marcar al usuario
+	// @Example "Usuario en lista negra"
+	FlagReason string `json:"flag_reason,omitempty" example:"Usuario en lista negra"`
 }
 
 // LoginResponse representa la respuesta de login
 // @Description Respuesta de autenticación exitosa
 type LoginResponse struct {
 	// @Description Token JWT para autenticación
-	// @Example "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// @Example "eyJhbGciOiJFUzI1NiIsImtpZCI6..."
+	Token string `json:"token" example:"eyJhbGciOiJFUzI1NiIsImtpZCI6..."`
 
-	// @Description Información del usuario autenticado
-	User UserResponse `json:"user"`
-}
+	// @Description Segundos de vigencia del token JWT
+	// @Example "900"
+	ExpiresIn int64 `json:"expires_in" example:"900"`
 
-// ErrorResponse representa una respuesta de error
-// @Description Respuesta de error
-type ErrorResponse struct {
-	// @Description Mensaje de error
-	// @Example "Error de validación"
-	Error string `json:"error" example:"Error de validación"`
+	// @Description Refresh token para obtener un nuevo token JWT en /auth/refresh
+	// @Example "Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"
+	RefreshToken string `json:"refresh_token" example:"Zk3n8Qp1yT6wB0vR4mX9cJ2hL7sD5aEuF1gKiO3qVbN"`
 
-	// @Description Detalles adicionales del error
-	// @Example "El campo email es requerido"
-	Details string `json:"details,omitempty" example:"El campo email es requerido"`
+	// @Description Fecha de vencimiento del refresh token
+	// @Example "2026-11-16T10:30:00Z"
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at" example:"2026-11-16T10:30:00Z"`
+
+	// @Description Indica que el rol del usuario exige MFA y aún no lo activó; hasta hacerlo solo puede usar los endpoints de /users/me/mfa
+	// @Example "false"
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty" example:"false"`
+
+	// @Description Información del usuario autenticado
+	User UserResponse `json:"user"`
 }
 
 // SuccessResponse representa una respuesta exitosa
@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"context"
 	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
 	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"errors"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -11,74 +18,248 @@ import (
 
 // AuthHandler maneja las solicitudes HTTP relacionadas con autenticación
 type AuthHandler struct {
-	authService *services.AuthService
+	authService         *services.AuthService
+	refreshTokenService *services.RefreshTokenService
+	mfaService          *services.MFAService
 }
 
 // NewAuthHandler crea una nueva instancia del handler de autenticación
-func NewAuthHandler(authService *services.AuthService) *AuthHandler {
+func NewAuthHandler(authService *services.AuthService, refreshTokenService *services.RefreshTokenService, mfaService *services.MFAService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:         authService,
+		refreshTokenService: refreshTokenService,
+		mfaService:          mfaService,
 	}
 }
 
 // Login godoc
 // @Summary Autenticar usuario
-// @Description Autentica un usuario con email y contraseña
+// @Description Autentica un usuario con email y contraseña. Retorna un token JWT de corta duración y un refresh token para renovarlo. Si el usuario tiene MFA activado responde 202 con un desafío que se completa en /auth/mfa/verify
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param credentials body dto.LoginRequest true "Credenciales de login"
 // @Success 200 {object} dto.LoginResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Success 202 {object} dto.MFAChallengeResponse "El usuario debe completar el segundo factor"
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails "Usuario pendiente de revisión o rechazado"
+// @Failure 429 {object} dto.ProblemDetails "Demasiados logins con MFA pendientes"
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Datos de entrada inválidos",
-			Details: err.Error(),
-		})
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
 		return
 	}
 
 	// Validación adicional
 	if validate := c.MustGet("validator").(*validator.Validate); validate != nil {
 		if err := validate.Struct(req); err != nil {
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error:   "Validación fallida",
-				Details: err.Error(),
-			})
+			middleware.AbortWithError(c, "Validación fallida", domain.WrapError(domain.ErrInvalidInput, "validación fallida", err))
 			return
 		}
 	}
 
 	// Autenticar usuario
-	user, token, err := h.authService.Login(req.Email, req.Password)
+	ctx := c.Request.Context()
+	user, err := h.authService.Authenticate(ctx, req.Email, req.Password)
+	if err != nil {
+		middleware.AbortWithError(c, "Error de autenticación", err)
+		return
+	}
+
+	// Con MFA activado, los tokens se emiten al completar el segundo factor
+	mfaEnabled, err := h.mfaService.IsEnabled(ctx, user.ID)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Error de autenticación",
-			Details: err.Error(),
+		middleware.AbortWithError(c, "Error de autenticación", err)
+		return
+	}
+	if mfaEnabled {
+		mfaToken, expiresAt, err := h.mfaService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			middleware.AbortWithError(c, "Error de autenticación", err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresIn:   int64(time.Until(expiresAt).Round(time.Second).Seconds()),
 		})
 		return
 	}
 
-	// Convertir a DTO de respuesta
-	userResponse := dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		IDNumber:  user.IDNumber,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	response, err := startSession(ctx, h.authService, h.refreshTokenService, user, false)
+	if err != nil {
+		middleware.AbortWithError(c, "Error de autenticación", err)
+		return
 	}
 
-	response := dto.LoginResponse{
-		Token: token,
-		User:  userResponse,
+	// Avisar al cliente si el rol exige MFA, para que lo active antes de usar el resto de la API
+	response.MFAEnrollmentRequired, err = h.mfaService.IsRequired(ctx, user.Role)
+	if err != nil {
+		middleware.AbortWithError(c, "Error de autenticación", err)
+		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// VerifyMFA godoc
+// @Summary Completar el login con MFA
+// @Description Completa el inicio de sesión de un usuario con MFA activado con un código de la aplicación autenticadora o un código de recuperación. Cada código incorrecto consume un intento del desafío
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param verification body dto.MFAVerifyRequest true "Desafío y código"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "Desafío inválido, vencido o sin intentos, o código incorrecto"
+// @Failure 403 {object} dto.ProblemDetails "Usuario pendiente de revisión o rechazado"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.mfaService.VerifyChallenge(ctx, req.MFAToken, req.Code, req.RecoveryCode)
+	if err != nil {
+		middleware.AbortWithError(c, "Error verificando MFA", err)
+		return
+	}
+
+	response, err := startSession(ctx, h.authService, h.refreshTokenService, user, true)
+	if err != nil {
+		middleware.AbortWithError(c, "Error verificando MFA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Refresh godoc
+// @Summary Renovar el token de acceso
+// @Description Intercambia un refresh token por un nuevo token JWT y un nuevo refresh token. Cada refresh token se puede usar una sola vez; reutilizar uno ya renovado revoca toda la sesión
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "Refresh token inválido, vencido, revocado o reutilizado"
+// @Failure 403 {object} dto.ProblemDetails "Usuario pendiente de revisión o rechazado"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	_, pair, err := h.refreshTokenService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		middleware.AbortWithError(c, "Error renovando token", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TokenResponse{
+		Token:                 pair.AccessToken,
+		ExpiresIn:             int64(pair.AccessTokenExpiresIn.Seconds()),
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: pair.RefreshTokenExpiresAt,
+	})
+}
+
+// Logout godoc
+// @Summary Cerrar sesión
+// @Description Revoca el token de acceso usado en la solicitud y, si se envía, la sesión del refresh token. Con all_sessions revoca todos los tokens de acceso y refresh tokens del usuario
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param logout body dto.LogoutRequest false "Refresh token y alcance del cierre"
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+	userID := user.(*domain.User).ID
+
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if req.AllSessions {
+		if err := h.authService.RevokeAllTokens(ctx, userID); err != nil {
+			middleware.AbortWithError(c, "Error cerrando sesión", err)
+			return
+		}
+		if err := h.refreshTokenService.RevokeAll(ctx, userID); err != nil {
+			middleware.AbortWithError(c, "Error cerrando sesión", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.SuccessResponse{
+			Message: i18n.T(c, "Se cerraron todas las sesiones"),
+		})
+		return
+	}
+
+	if err := h.authService.RevokeToken(ctx, c.GetString("token")); err != nil {
+		middleware.AbortWithError(c, "Error cerrando sesión", err)
+		return
+	}
+	if req.RefreshToken != "" {
+		if err := h.refreshTokenService.RevokeSession(ctx, userID, req.RefreshToken); err != nil {
+			middleware.AbortWithError(c, "Error cerrando sesión", err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: i18n.T(c, "Sesión cerrada correctamente"),
+	})
+}
+
+// startSession emite el token de acceso y el refresh token de un nuevo inicio de sesión. mfa
+// indica si el usuario completó el segundo factor
+func startSession(ctx context.Context, authService *services.AuthService, refreshTokenService *services.RefreshTokenService, user *domain.User, mfa bool) (*dto.LoginResponse, error) {
+	generateToken := authService.GenerateToken
+	if mfa {
+		generateToken = authService.GenerateMFAToken
+	}
+	token, err := generateToken(user)
+	if err != nil {
+		return nil, errors.New("error generando token")
+	}
+
+	refreshToken, refreshExpiresAt, err := refreshTokenService.IssueRefreshToken(ctx, user.ID, mfa)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		Token:                 token,
+		ExpiresIn:             int64(authService.AccessTokenTTL().Seconds()),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+		User:                  toUserResponse(user),
+	}, nil
+}
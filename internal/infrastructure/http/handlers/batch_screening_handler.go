@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchUploadBytes limita el tamaño del lote recibido
+const maxBatchUploadBytes = 10 << 20
+
+// batchResultsCSVHeader son las columnas del CSV de resultados
+var batchResultsCSVHeader = []string{"line", "id_number", "name", "email", "status", "reason", "match_score", "provider", "screening_id", "error"}
+
+// BatchScreeningHandler maneja las solicitudes HTTP de los lotes de screening
+type BatchScreeningHandler struct {
+	batchScreeningService *services.BatchScreeningService
+}
+
+// NewBatchScreeningHandler crea una nueva instancia del handler de lotes de screening
+func NewBatchScreeningHandler(batchScreeningService *services.BatchScreeningService) *BatchScreeningHandler {
+	return &BatchScreeningHandler{
+		batchScreeningService: batchScreeningService,
+	}
+}
+
+// SubmitBatch godoc
+// @Summary Enviar lote de screening
+// @Description Recibe un lote de prospectos (JSON, CSV en el cuerpo con Content-Type text/csv, o archivo CSV en el campo "file" de un formulario multipart) y lo valida contra el servicio PLD en segundo plano. El CSV debe tener encabezado con las columnas id_number, name y email (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param request body dto.BatchScreeningRequest false "Lote en formato JSON"
+// @Security BearerAuth
+// @Success 202 {object} dto.BatchScreeningJobResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /screenings/batch [post]
+func (h *BatchScreeningHandler) SubmitBatch(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchUploadBytes)
+
+	rows, source, err := readBatchRows(c)
+	if err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", batchInputError(err))
+		return
+	}
+
+	requester, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	job, err := h.batchScreeningService.Submit(c.Request.Context(), rows, source, requester.(*domain.User).ID)
+	if err != nil {
+		middleware.AbortWithError(c, "Error registrando lote de screening", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toBatchScreeningJobResponse(job))
+}
+
+// GetBatch godoc
+// @Summary Obtener lote de screening
+// @Description Obtiene el estado de un lote de screening y el resultado de cada fila (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del lote"
+// @Security BearerAuth
+// @Success 200 {object} dto.BatchScreeningResultResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /screenings/batch/{id} [get]
+func (h *BatchScreeningHandler) GetBatch(c *gin.Context) {
+	job, rows, ok := h.getBatch(c)
+	if !ok {
+		return
+	}
+
+	response := dto.BatchScreeningResultResponse{
+		Job:  toBatchScreeningJobResponse(job),
+		Rows: make([]dto.BatchScreeningRowResponse, 0, len(rows)),
+	}
+	for _, row := range rows {
+		response.Rows = append(response.Rows, dto.BatchScreeningRowResponse{
+			Line:        row.Line,
+			IDNumber:    row.IDNumber,
+			Name:        row.Name,
+			Email:       row.Email,
+			Status:      row.Status,
+			Reason:      row.Reason,
+			MatchScore:  row.MatchScore,
+			Provider:    row.Provider,
+			ScreeningID: row.ScreeningID,
+			Error:       row.Error,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DownloadBatchResults godoc
+// @Summary Descargar resultados de un lote
+// @Description Descarga en CSV el resultado de cada fila de un lote de screening (solo administradores)
+// @Tags compliance
+// @Produce text/csv
+// @Param id path int true "ID del lote"
+// @Security BearerAuth
+// @Success 200 {string} string "CSV con columnas line, id_number, name, email, status, reason, match_score, provider, screening_id, error"
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /screenings/batch/{id}/results.csv [get]
+func (h *BatchScreeningHandler) DownloadBatchResults(c *gin.Context) {
+	job, rows, ok := h.getBatch(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="batch-screening-%d.csv"`, job.ID))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	// La respuesta ya comenzó, por lo que un error de escritura solo puede registrarse
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.Write(batchResultsCSVHeader); err != nil {
+		log.Printf("error escribiendo resultados del lote %d: %v", job.ID, err)
+		return
+	}
+	for _, row := range rows {
+		screeningID := ""
+		if row.ScreeningID != nil {
+			screeningID = strconv.FormatUint(uint64(*row.ScreeningID), 10)
+		}
+		err := writer.Write([]string{
+			strconv.Itoa(row.Line),
+			escapeCSVFormula(row.IDNumber),
+			escapeCSVFormula(row.Name),
+			escapeCSVFormula(row.Email),
+			row.Status,
+			row.Reason,
+			strconv.FormatFloat(row.MatchScore, 'f', -1, 64),
+			row.Provider,
+			screeningID,
+			row.Error,
+		})
+		if err != nil {
+			log.Printf("error escribiendo resultados del lote %d: %v", job.ID, err)
+			return
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("error escribiendo resultados del lote %d: %v", job.ID, err)
+	}
+}
+
+// escapeCSVFormula antepone ' a los valores que una hoja de cálculo interpretaría como
+// fórmula (los que empiezan con =, +, -, @, tabulador o retorno de carro), para que los datos
+// enviados en el lote no se ejecuten al abrir los resultados
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// getBatch obtiene el lote indicado en la ruta, respondiendo el error si no es posible
+func (h *BatchScreeningHandler) getBatch(c *gin.Context) (*domain.BatchScreeningJob, []*domain.BatchScreeningRow, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
+		return nil, nil, false
+	}
+
+	job, rows, err := h.batchScreeningService.Get(c.Request.Context(), uint(id))
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo lote de screening", err)
+		return nil, nil, false
+	}
+
+	return job, rows, true
+}
+
+// readBatchRows lee las filas del lote según el Content-Type de la solicitud
+func readBatchRows(c *gin.Context) ([]*domain.BatchScreeningRow, string, error) {
+	switch c.ContentType() {
+	case "text/csv":
+		rows, err := parseBatchCSV(c.Request.Body)
+		return rows, domain.BatchScreeningSourceCSV, err
+	case "multipart/form-data":
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", domain.NewError(domain.ErrInvalidInput, "el formulario debe incluir el archivo CSV en el campo file")
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer file.Close()
+
+		rows, err := parseBatchCSV(file)
+		return rows, domain.BatchScreeningSourceCSV, err
+	default:
+		var req dto.BatchScreeningRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, "", err
+		}
+
+		rows := make([]*domain.BatchScreeningRow, 0, len(req.Rows))
+		for _, row := range req.Rows {
+			rows = append(rows, &domain.BatchScreeningRow{IDNumber: row.IDNumber, Name: row.Name, Email: row.Email})
+		}
+		return rows, domain.BatchScreeningSourceJSON, nil
+	}
+}
+
+// parseBatchCSV lee un CSV con encabezado. Las columnas id_number y name son obligatorias,
+// email es opcional y el resto se ignora
+func parseBatchCSV(r io.Reader) ([]*domain.BatchScreeningRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, domain.NewError(domain.ErrInvalidInput, "el CSV está vacío")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"id_number", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, domain.Errorf(domain.ErrInvalidInput, "el CSV no tiene la columna %s", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []*domain.BatchScreeningRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, &domain.BatchScreeningRow{
+			IDNumber: field(record, "id_number"),
+			Name:     field(record, "name"),
+			Email:    field(record, "email"),
+		})
+	}
+
+	return rows, nil
+}
+
+// batchInputError describe un lote ilegible sin exponer el error técnico del lector
+func batchInputError(err error) error {
+	var parseErr *csv.ParseError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return err
+	case errors.As(err, &parseErr):
+		return domain.WrapErrorf(domain.ErrInvalidInput, err, "el CSV es inválido en la línea %d", parseErr.Line)
+	case errors.As(err, &maxBytesErr):
+		return domain.WrapErrorf(domain.ErrInvalidInput, err, "el lote excede el máximo de %d bytes", maxBytesErr.Limit)
+	default:
+		return domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err)
+	}
+}
+
+// toBatchScreeningJobResponse convierte un lote al DTO de respuesta
+func toBatchScreeningJobResponse(job *domain.BatchScreeningJob) dto.BatchScreeningJobResponse {
+	return dto.BatchScreeningJobResponse{
+		ID:          job.ID,
+		Status:      job.Status,
+		Source:      job.Source,
+		RequestedBy: job.RequestedBy,
+		Total:       job.Total,
+		Processed:   job.Processed,
+		Clean:       job.Clean,
+		Blacklisted: job.Blacklisted,
+		Review:      job.Review,
+		Failed:      job.Failed,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ComplianceHandler maneja las solicitudes HTTP del área de cumplimiento
+type ComplianceHandler struct {
+	complianceService *services.ComplianceService
+}
+
+// NewComplianceHandler crea una nueva instancia del handler de cumplimiento
+func NewComplianceHandler(complianceService *services.ComplianceService) *ComplianceHandler {
+	return &ComplianceHandler{
+		complianceService: complianceService,
+	}
+}
+
+// ListRejectedApplications godoc
+// @Summary Listar solicitudes rechazadas
+// @Description Lista las solicitudes de alta rechazadas por el servicio PLD, filtradas por rango de fechas (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param from query string false "Fecha inicial (YYYY-MM-DD o RFC3339)"
+// @Param to query string false "Fecha final inclusiva (YYYY-MM-DD o RFC3339)"
+// @Security BearerAuth
+// @Success 200 {object} dto.RejectedApplicationListResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/rejected-applications [get]
+func (h *ComplianceHandler) ListRejectedApplications(c *gin.Context) {
+	var filter domain.RejectedApplicationFilter
+	var err error
+
+	if filter.From, err = parseDateParam(c.Query("from"), false); err != nil {
+		middleware.AbortWithError(c, "Fecha inicial inválida", domain.WrapError(domain.ErrInvalidInput, "fecha inicial inválida, use el formato YYYY-MM-DD", err))
+		return
+	}
+	if filter.To, err = parseDateParam(c.Query("to"), true); err != nil {
+		middleware.AbortWithError(c, "Fecha final inválida", domain.WrapError(domain.ErrInvalidInput, "fecha final inválida, use el formato YYYY-MM-DD", err))
+		return
+	}
+
+	applications, err := h.complianceService.ListRejectedApplications(c.Request.Context(), filter)
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo solicitudes rechazadas", err)
+		return
+	}
+
+	// Convertir a DTO de respuesta
+	response := dto.RejectedApplicationListResponse{
+		RejectedApplications: make([]dto.RejectedApplicationResponse, 0, len(applications)),
+	}
+	for _, application := range applications {
+		response.RejectedApplications = append(response.RejectedApplications, dto.RejectedApplicationResponse{
+			ID:          application.ID,
+			Name:        application.Name,
+			Email:       application.Email,
+			IDNumber:    application.IDNumber,
+			Reason:      application.Reason,
+			Provider:    application.Provider,
+			ScreeningID: application.ScreeningID,
+			CreatedAt:   application.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseDateParam interpreta una fecha YYYY-MM-DD o RFC3339. Si endOfDay es verdadero,
+// una fecha sin hora se extiende hasta el final de ese día
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("formato esperado YYYY-MM-DD o RFC3339")
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"crabi-test/internal/infrastructure/external"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CircuitStateProvider expone el estado de un circuit breaker
+type CircuitStateProvider interface {
+	State() external.CircuitState
+}
+
+// CacheStatsProvider expone las métricas de una caché de resultados PLD
+type CacheStatsProvider interface {
+	Stats() external.PLDCacheStats
+}
+
+// HealthHandler maneja el health check de la aplicación
+type HealthHandler struct {
+	pldBreakers map[string]CircuitStateProvider
+	pldCache    CacheStatsProvider
+}
+
+// NewHealthHandler crea una nueva instancia del handler de health check. pldBreakers son los
+// circuit breakers de los proveedores PLD remotos por nombre de proveedor, y pldCache es nil
+// si la caché de resultados PLD está deshabilitada
+func NewHealthHandler(pldBreakers map[string]CircuitStateProvider, pldCache CacheStatsProvider) *HealthHandler {
+	return &HealthHandler{
+		pldBreakers: pldBreakers,
+		pldCache:    pldCache,
+	}
+}
+
+// Health retorna el estado de la API, de los circuit breakers y de la caché del servicio PLD.
+// circuit_breaker resume el peor estado entre los proveedores
+func (h *HealthHandler) Health(c *gin.Context) {
+	pldState := external.CircuitClosed
+	breakers := make(map[string]string, len(h.pldBreakers))
+	for name, breaker := range h.pldBreakers {
+		state := breaker.State()
+		breakers[name] = state.String()
+		if state == external.CircuitOpen || (state == external.CircuitHalfOpen && pldState == external.CircuitClosed) {
+			pldState = state
+		}
+	}
+
+	status := "OK"
+	if pldState != external.CircuitClosed {
+		status = "DEGRADED"
+	}
+
+	pld := gin.H{
+		"circuit_breaker":  pldState.String(),
+		"circuit_breakers": breakers,
+	}
+	if h.pldCache != nil {
+		pld["cache"] = h.pldCache.Stats()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  status,
+		"message": "Crabi API is running",
+		"pld":     pld,
+	})
+}
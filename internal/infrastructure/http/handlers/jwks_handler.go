@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"crabi-test/pkg/jwtkeys"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publica las claves públicas con las que otros servicios verifican los tokens
+type JWKSHandler struct {
+	keyRing *jwtkeys.KeyRing
+}
+
+// NewJWKSHandler crea una nueva instancia del handler de JWKS
+func NewJWKSHandler(keyRing *jwtkeys.KeyRing) *JWKSHandler {
+	return &JWKSHandler{
+		keyRing: keyRing,
+	}
+}
+
+// JWKS retorna el documento JWKS con la clave activa y las anteriores. Se permite cachearlo
+// por poco tiempo para que una rotación se propague pronto
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keyRing.JWKS())
+}
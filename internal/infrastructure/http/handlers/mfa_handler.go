@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MFAHandler maneja la inscripción en MFA del usuario autenticado y las políticas MFA por rol
+type MFAHandler struct {
+	mfaService          *services.MFAService
+	authService         *services.AuthService
+	refreshTokenService *services.RefreshTokenService
+}
+
+// NewMFAHandler crea una nueva instancia del handler de MFA
+func NewMFAHandler(mfaService *services.MFAService, authService *services.AuthService, refreshTokenService *services.RefreshTokenService) *MFAHandler {
+	return &MFAHandler{
+		mfaService:          mfaService,
+		authService:         authService,
+		refreshTokenService: refreshTokenService,
+	}
+}
+
+// GetStatus godoc
+// @Summary Consultar el estado de MFA
+// @Description Indica si el usuario autenticado tiene MFA activado, cuántos códigos de recuperación le quedan y si su rol lo exige
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAStatusResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/me/mfa [get]
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	status, err := h.mfaService.Status(c.Request.Context(), user.(*domain.User))
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo estado de MFA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MFAStatusResponse{
+		Enabled:                status.Enabled,
+		Pending:                status.Pending,
+		ConfirmedAt:            status.ConfirmedAt,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+		Required:               status.Required,
+	})
+}
+
+// StartEnrollment godoc
+// @Summary Iniciar la inscripción en MFA
+// @Description Genera un secreto TOTP y el URI otpauth:// para mostrarlo como código QR. MFA se activa al confirmar un código en /users/me/mfa/confirm; iniciar de nuevo reemplaza el secreto pendiente
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} dto.MFAEnrollmentResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails "MFA ya está activado"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/me/mfa/enroll [post]
+func (h *MFAHandler) StartEnrollment(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	setup, err := h.mfaService.StartEnrollment(c.Request.Context(), user.(*domain.User))
+	if err != nil {
+		middleware.AbortWithError(c, "Error iniciando inscripción MFA", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MFAEnrollmentResponse{
+		Secret:     setup.Secret,
+		OTPAuthURI: setup.URI,
+	})
+}
+
+// ConfirmEnrollment godoc
+// @Summary Activar MFA
+// @Description Activa MFA con un código de la aplicación autenticadora. Retorna los códigos de recuperación, que no se pueden volver a consultar, y tokens de una sesión iniciada con MFA. Los tokens de acceso y refresh tokens anteriores se revocan
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param code body dto.MFACodeRequest true "Código TOTP"
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAConfirmResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "No autenticado o código incorrecto"
+// @Failure 409 {object} dto.ProblemDetails "No hay una inscripción pendiente"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/me/mfa/confirm [post]
+func (h *MFAHandler) ConfirmEnrollment(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+	userDomain := user.(*domain.User)
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	recoveryCodes, err := h.mfaService.ConfirmEnrollment(ctx, userDomain.ID, req.Code)
+	if err != nil {
+		middleware.AbortWithError(c, "Error activando MFA", err)
+		return
+	}
+
+	// ConfirmEnrollment cerró las sesiones abiertas sin segundo factor; esta es la nueva
+	session, err := startSession(ctx, h.authService, h.refreshTokenService, userDomain, true)
+	if err != nil {
+		middleware.AbortWithError(c, "Error activando MFA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MFAConfirmResponse{
+		RecoveryCodes:         recoveryCodes,
+		Token:                 session.Token,
+		ExpiresIn:             session.ExpiresIn,
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: session.RefreshTokenExpiresAt,
+	})
+}
+
+// Disable godoc
+// @Summary Desactivar MFA
+// @Description Desactiva MFA con un código de la aplicación autenticadora o un código de recuperación. No es posible si el rol del usuario exige MFA
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param code body dto.MFADisableRequest true "Código TOTP o de recuperación"
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "No autenticado o código incorrecto"
+// @Failure 403 {object} dto.ProblemDetails "El rol del usuario exige MFA"
+// @Failure 409 {object} dto.ProblemDetails "MFA no está activado"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/me/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), user.(*domain.User), req.Code, req.RecoveryCode); err != nil {
+		middleware.AbortWithError(c, "Error desactivando MFA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: i18n.T(c, "MFA desactivado correctamente"),
+	})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerar los códigos de recuperación
+// @Description Reemplaza los códigos de recuperación con un código de la aplicación autenticadora. Los códigos anteriores dejan de ser válidos
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param code body dto.MFACodeRequest true "Código TOTP"
+// @Security BearerAuth
+// @Success 200 {object} dto.MFARecoveryCodesResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "No autenticado o código incorrecto"
+// @Failure 409 {object} dto.ProblemDetails "MFA no está activado"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/me/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	recoveryCodes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), user.(*domain.User).ID, req.Code)
+	if err != nil {
+		middleware.AbortWithError(c, "Error regenerando códigos de recuperación", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// ListPolicies godoc
+// @Summary Listar políticas MFA
+// @Description Indica para cada rol si exige MFA (requiere rol de administrador)
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAPolicyListResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/mfa/policies [get]
+func (h *MFAHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.mfaService.ListPolicies(c.Request.Context())
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo políticas MFA", err)
+		return
+	}
+
+	response := dto.MFAPolicyListResponse{Policies: make([]dto.MFAPolicyResponse, 0, len(policies))}
+	for i := range policies {
+		response.Policies = append(response.Policies, toMFAPolicyResponse(&policies[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdatePolicy godoc
+// @Summary Configurar la política MFA de un rol
+// @Description Indica si el rol exige MFA (requiere rol de administrador). Los usuarios del rol que no tengan MFA activado solo pueden usar los endpoints de /users/me/mfa hasta activarlo
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param role path string true "Rol" Enums(user, admin)
+// @Param policy body dto.MFAPolicyRequest true "Política"
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAPolicyResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/mfa/policies/{role} [put]
+func (h *MFAHandler) UpdatePolicy(c *gin.Context) {
+	admin, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.MFAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	policy, err := h.mfaService.SetPolicy(c.Request.Context(), c.Param("role"), *req.Required, admin.(*domain.User).ID)
+	if err != nil {
+		middleware.AbortWithError(c, "Error actualizando política MFA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMFAPolicyResponse(policy))
+}
+
+// toMFAPolicyResponse convierte una política MFA al DTO de respuesta
+func toMFAPolicyResponse(policy *domain.MFARolePolicy) dto.MFAPolicyResponse {
+	response := dto.MFAPolicyResponse{
+		Role:      policy.Role,
+		Required:  policy.Required,
+		UpdatedBy: policy.UpdatedBy,
+	}
+	if !policy.UpdatedAt.IsZero() {
+		updatedAt := policy.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
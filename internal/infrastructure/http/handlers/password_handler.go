@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordHandler maneja el cambio y el restablecimiento de contraseñas
+type PasswordHandler struct {
+	passwordService *services.PasswordService
+}
+
+// NewPasswordHandler crea una nueva instancia del handler de contraseñas
+func NewPasswordHandler(passwordService *services.PasswordService) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: passwordService,
+	}
+}
+
+// ChangePassword godoc
+// @Summary Cambiar la contraseña
+// @Description Cambia la contraseña del usuario autenticado; requiere la contraseña actual. Cierra todas las sesiones del usuario, incluida la actual
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param password body dto.ChangePasswordRequest true "Contraseña actual y nueva"
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "No autenticado o contraseña actual incorrecta"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/me/password [post]
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	if err := h.passwordService.ChangePassword(c.Request.Context(), user.(*domain.User).ID, req.CurrentPassword, req.NewPassword); err != nil {
+		middleware.AbortWithError(c, "Error cambiando contraseña", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: i18n.T(c, "Contraseña actualizada correctamente"),
+	})
+}
+
+// ForgotPassword godoc
+// @Summary Solicitar restablecimiento de contraseña
+// @Description Envía al usuario un token de restablecimiento de un solo uso y con vencimiento. La respuesta es la misma esté o no registrado el email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Email de la cuenta"
+// @Success 202 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/password/forgot [post]
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	if err := h.passwordService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		middleware.AbortWithError(c, "Error solicitando restablecimiento de contraseña", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Message: i18n.T(c, "Si el email está registrado recibirás las instrucciones para restablecer la contraseña"),
+	})
+}
+
+// ResetPassword godoc
+// @Summary Restablecer la contraseña
+// @Description Establece una nueva contraseña con el token de restablecimiento recibido. El token se invalida al usarse y se cierran todas las sesiones del usuario
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Token y nueva contraseña"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails "Token inválido, vencido o ya usado"
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /auth/password/reset [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	if err := h.passwordService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		middleware.AbortWithError(c, "Error restableciendo contraseña", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: i18n.T(c, "Contraseña restablecida correctamente"),
+	})
+}
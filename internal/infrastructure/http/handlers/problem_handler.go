@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemHandler describe los tipos de problema de las respuestas de error
+type ProblemHandler struct{}
+
+// NewProblemHandler crea una nueva instancia del handler de tipos de problema
+func NewProblemHandler() *ProblemHandler {
+	return &ProblemHandler{}
+}
+
+// GetProblemType godoc
+// @Summary Describir un tipo de problema
+// @Description Describe el tipo de problema identificado por el campo type de las respuestas de error
+// @Tags errors
+// @Produce json
+// @Param code path string true "Código estable del error" example(blacklisted)
+// @Success 200 {object} dto.ProblemTypeResponse
+// @Failure 404 {object} dto.ProblemDetails
+// @Router /problems/{code} [get]
+func (h *ProblemHandler) GetProblemType(c *gin.Context) {
+	problem, ok := middleware.LookupProblemType(c.Param("code"))
+	if !ok {
+		middleware.AbortWithError(c, "Tipo de problema no encontrado", domain.NewError(domain.ErrNotFound, "tipo de problema no encontrado"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProblemTypeResponse{
+		Type:   problem.URI(),
+		Code:   problem.Code,
+		Title:  i18n.Title(i18n.Language(c), problem.Code),
+		Status: problem.Status,
+	})
+}
+
+// NoRoute responde las rutas inexistentes con el mismo formato de error que el resto de la API
+func (h *ProblemHandler) NoRoute(c *gin.Context) {
+	middleware.AbortWithError(c, "Ruta no encontrada", domain.NewError(domain.ErrNotFound, "ruta no encontrada"))
+}
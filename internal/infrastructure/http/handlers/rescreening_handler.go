@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RescreeningHandler maneja las solicitudes HTTP del re-screening de usuarios
+type RescreeningHandler struct {
+	rescreeningService *services.RescreeningService
+}
+
+// NewRescreeningHandler crea una nueva instancia del handler de re-screening
+func NewRescreeningHandler(rescreeningService *services.RescreeningService) *RescreeningHandler {
+	return &RescreeningHandler{
+		rescreeningService: rescreeningService,
+	}
+}
+
+// TriggerRescreening godoc
+// @Summary Iniciar re-screening
+// @Description Inicia en segundo plano la revalidación de todos los usuarios contra el servicio PLD. Si hay una ejecución interrumpida se reanuda (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} dto.RescreeningRunResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/rescreenings [post]
+func (h *RescreeningHandler) TriggerRescreening(c *gin.Context) {
+	run, err := h.rescreeningService.Trigger(c.Request.Context(), domain.RescreeningTriggerManual)
+	if err != nil {
+		middleware.AbortWithError(c, "Error iniciando re-screening", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toRescreeningRunResponse(run))
+}
+
+// GetLatestRescreening godoc
+// @Summary Obtener último re-screening
+// @Description Obtiene el estado de la ejecución de re-screening más reciente (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.RescreeningRunResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Router /admin/rescreenings/latest [get]
+func (h *RescreeningHandler) GetLatestRescreening(c *gin.Context) {
+	run, err := h.rescreeningService.LatestRun(c.Request.Context())
+	if err != nil {
+		middleware.AbortWithError(c, "Re-screening no encontrado", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRescreeningRunResponse(run))
+}
+
+// toRescreeningRunResponse convierte una ejecución al DTO de respuesta
+func toRescreeningRunResponse(run *domain.RescreeningRun) dto.RescreeningRunResponse {
+	return dto.RescreeningRunResponse{
+		ID:         run.ID,
+		Trigger:    run.Trigger,
+		Status:     run.Status,
+		LastUserID: run.LastUserID,
+		Processed:  run.Processed,
+		Flagged:    run.Flagged,
+		Failed:     run.Failed,
+		Error:      run.Error,
+		StartedAt:  run.StartedAt,
+		UpdatedAt:  run.UpdatedAt,
+		FinishedAt: run.FinishedAt,
+	}
+}
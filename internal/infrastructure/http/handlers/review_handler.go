@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler maneja las solicitudes HTTP de la cola de revisión manual
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+}
+
+// NewReviewHandler crea una nueva instancia del handler de revisión
+func NewReviewHandler(reviewService *services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// ListPendingReviews godoc
+// @Summary Listar cola de revisión
+// @Description Lista las altas con screening no concluyente pendientes de revisión manual, de la más antigua a la más reciente (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ReviewQueueResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/reviews [get]
+func (h *ReviewHandler) ListPendingReviews(c *gin.Context) {
+	items, err := h.reviewService.ListPending(c.Request.Context())
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo cola de revisión", err)
+		return
+	}
+
+	response := dto.ReviewQueueResponse{
+		Items: make([]dto.ReviewItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		reviewItem := dto.ReviewItemResponse{
+			User:   toUserResponse(item.User),
+			Reason: item.User.ReviewReason,
+		}
+		if item.Screening != nil {
+			screening := toScreeningResponse(item.Screening)
+			reviewItem.Screening = &screening
+		}
+		response.Items = append(response.Items, reviewItem)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveReview godoc
+// @Summary Aprobar alta pendiente
+// @Description Aprueba un alta pendiente de revisión. La justificación es obligatoria y queda registrada en el log de auditoría (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del usuario"
+// @Param request body dto.ReviewDecisionRequest true "Justificación de la decisión"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReviewDecisionResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/reviews/{id}/approve [post]
+func (h *ReviewHandler) ApproveReview(c *gin.Context) {
+	h.decide(c, domain.ReviewDecisionApproved)
+}
+
+// RejectReview godoc
+// @Summary Rechazar alta pendiente
+// @Description Rechaza un alta pendiente de revisión. La justificación es obligatoria y queda registrada en el log de auditoría (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del usuario"
+// @Param request body dto.ReviewDecisionRequest true "Justificación de la decisión"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReviewDecisionResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/reviews/{id}/reject [post]
+func (h *ReviewHandler) RejectReview(c *gin.Context) {
+	h.decide(c, domain.ReviewDecisionRejected)
+}
+
+// ListReviewDecisions godoc
+// @Summary Listar decisiones de revisión
+// @Description Obtiene el log de auditoría de decisiones de revisión de un usuario (solo administradores)
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del usuario"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReviewDecisionListResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /admin/reviews/{id}/decisions [get]
+func (h *ReviewHandler) ListReviewDecisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
+		return
+	}
+
+	decisions, err := h.reviewService.ListDecisions(c.Request.Context(), uint(id))
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo decisiones de revisión", err)
+		return
+	}
+
+	response := dto.ReviewDecisionListResponse{
+		Decisions: make([]dto.ReviewDecisionResponse, 0, len(decisions)),
+	}
+	for _, decision := range decisions {
+		response.Decisions = append(response.Decisions, toReviewDecisionResponse(decision))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// decide registra la decisión del administrador autenticado sobre un alta pendiente
+func (h *ReviewHandler) decide(c *gin.Context, decision string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
+		return
+	}
+
+	var req dto.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	reviewer, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	record, err := h.reviewService.Decide(c.Request.Context(), uint(id), reviewer.(*domain.User), decision, req.Justification)
+	if err != nil {
+		middleware.AbortWithError(c, "Error registrando decisión de revisión", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReviewDecisionResponse(record))
+}
+
+// toReviewDecisionResponse convierte una decisión de revisión al DTO de respuesta
+func toReviewDecisionResponse(decision *domain.ReviewDecision) dto.ReviewDecisionResponse {
+	return dto.ReviewDecisionResponse{
+		ID:            decision.ID,
+		UserID:        decision.UserID,
+		ReviewerID:    decision.ReviewerID,
+		Decision:      decision.Decision,
+		Justification: decision.Justification,
+		CreatedAt:     decision.CreatedAt,
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"crabi-test/internal/application/services"
 	"crabi-test/internal/domain"
 	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	"crabi-test/internal/infrastructure/http/middleware"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -27,71 +30,54 @@ func NewUserHandler(userService *services.UserService, authService *services.Aut
 
 // CreateUser godoc
 // @Summary Crear un nuevo usuario
-// @Description Crea un nuevo usuario validando contra el servicio PLD
+// @Description Crea un nuevo usuario validando contra el servicio PLD. Si el screening no es concluyente el usuario se crea con estado pending_review y no puede iniciar sesión hasta que cumplimiento lo apruebe
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param user body dto.CreateUserRequest true "Datos del usuario"
 // @Success 201 {object} dto.UserResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 409 {object} dto.ErrorResponse "Usuario en lista negra"
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails "Email registrado o usuario en lista negra"
+// @Failure 500 {object} dto.ProblemDetails
+// @Failure 503 {object} dto.ProblemDetails "Servicio PLD no disponible"
 // @Router /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req dto.CreateUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Datos de entrada inválidos",
-			Details: err.Error(),
-		})
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
 		return
 	}
 
 	// Validación adicional
 	if validate := c.MustGet("validator").(*validator.Validate); validate != nil {
 		if err := validate.Struct(req); err != nil {
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error:   "Validación fallida",
-				Details: err.Error(),
-			})
+			middleware.AbortWithError(c, "Validación fallida", domain.WrapError(domain.ErrInvalidInput, "validación fallida", err))
 			return
 		}
 	}
 
 	// Crear usuario en el dominio
 	user := &domain.User{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
-		IDNumber: req.IDNumber,
+		Name:            req.Name,
+		Email:           req.Email,
+		Password:        req.Password,
+		IDNumber:        req.IDNumber,
+		GivenNames:      strings.TrimSpace(req.GivenNames),
+		PaternalSurname: strings.TrimSpace(req.PaternalSurname),
+		MaternalSurname: strings.TrimSpace(req.MaternalSurname),
+		DateOfBirth:     req.DateOfBirth,
+		Nationality:     req.Nationality,
 	}
 
 	// Crear usuario usando el servicio
-	if err := h.userService.CreateUser(user); err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "el email ya está registrado" {
-			statusCode = http.StatusConflict
-		} else if err.Error() == "usuario en lista negra" {
-			statusCode = http.StatusConflict
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Error:   "Error creando usuario",
-			Details: err.Error(),
-		})
+	if err := h.userService.CreateUser(c.Request.Context(), user); err != nil {
+		middleware.AbortWithError(c, "Error creando usuario", err)
 		return
 	}
 
 	// Convertir a DTO de respuesta
-	response := dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		IDNumber:  user.IDNumber,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
+	response := toUserResponse(user)
 
 	c.JSON(http.StatusCreated, response)
 }
@@ -104,31 +90,22 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} dto.UserResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /users/me [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
 	// Obtener usuario del contexto (establecido por el middleware de autenticación)
 	user, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error: "Usuario no autenticado",
-		})
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
 		return
 	}
 
 	userDomain := user.(*domain.User)
 
 	// Convertir a DTO de respuesta
-	response := dto.UserResponse{
-		ID:        userDomain.ID,
-		Name:      userDomain.Name,
-		Email:     userDomain.Email,
-		IDNumber:  userDomain.IDNumber,
-		CreatedAt: userDomain.CreatedAt,
-		UpdatedAt: userDomain.UpdatedAt,
-	}
+	response := toUserResponse(userDomain)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -142,50 +119,182 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 // @Param id path int true "ID del usuario"
 // @Security BearerAuth
 // @Success 200 {object} dto.UserResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUserByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "ID inválido",
-			Details: err.Error(),
-		})
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
 		return
 	}
 
-	user, err := h.userService.GetUser(uint(id))
+	user, err := h.userService.GetUser(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Error obteniendo usuario",
-			Details: err.Error(),
-		})
+		middleware.AbortWithError(c, "Error obteniendo usuario", err)
 		return
 	}
 
 	if user == nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Error: "Usuario no encontrado",
-		})
+		middleware.AbortWithError(c, "Usuario no encontrado", domain.NewError(domain.ErrNotFound, "usuario no encontrado"))
 		return
 	}
 
 	// Convertir a DTO de respuesta
-	response := dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		IDNumber:  user.IDNumber,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	response := toUserResponse(user)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUserScreenings godoc
+// @Summary Obtener historial de screenings PLD
+// @Description Obtiene el historial de validaciones PLD de un usuario. Solo lo puede consultar el propio usuario o un administrador
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del usuario"
+// @Security BearerAuth
+// @Success 200 {object} dto.ScreeningListResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
+// @Router /users/{id}/screenings [get]
+func (h *UserHandler) GetUserScreenings(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
+		return
+	}
+
+	// El historial incluye las respuestas del proveedor PLD y datos personales, por lo que
+	// solo lo consultan el propio usuario y los administradores
+	current, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+	currentUser := current.(*domain.User)
+	if currentUser.ID != uint(id) && currentUser.Role != domain.RoleAdmin {
+		middleware.AbortWithError(c, "Permisos insuficientes", domain.ErrForbidden)
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), uint(id))
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo usuario", err)
+		return
+	}
+
+	if user == nil {
+		middleware.AbortWithError(c, "Usuario no encontrado", domain.NewError(domain.ErrNotFound, "usuario no encontrado"))
+		return
+	}
+
+	screenings, err := h.userService.GetUserScreenings(c.Request.Context(), user.ID)
+	if err != nil {
+		middleware.AbortWithError(c, "Error obteniendo screenings", err)
+		return
+	}
+
+	// Convertir a DTO de respuesta
+	response := dto.ScreeningListResponse{
+		Screenings: make([]dto.ScreeningResponse, 0, len(screenings)),
+	}
+	for _, screening := range screenings {
+		response.Screenings = append(response.Screenings, toScreeningResponse(screening))
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// UpdateCurrentUser godoc
+// @Summary Actualizar el perfil del usuario autenticado
+// @Description Actualiza parcialmente el perfil del usuario autenticado; solo cambian los campos incluidos. Si cambia la identidad se valida de nuevo contra el servicio PLD: una identidad en lista negra rechaza el cambio y marca la cuenta, y una coincidencia no concluyente deja al usuario pendiente de revisión
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body dto.UpdateProfileRequest true "Campos a actualizar"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails "Email registrado o usuario en lista negra"
+// @Failure 500 {object} dto.ProblemDetails
+// @Failure 503 {object} dto.ProblemDetails "Servicio PLD no disponible"
+// @Router /users/me [patch]
+func (h *UserHandler) UpdateCurrentUser(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		middleware.AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+		return
+	}
+
+	h.updateProfile(c, user.(*domain.User).ID)
+}
+
+// UpdateUser godoc
+// @Summary Actualizar el perfil de un usuario
+// @Description Actualiza parcialmente el perfil de un usuario por su ID (solo administradores), con las mismas reglas que PATCH /users/me
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del usuario"
+// @Param user body dto.UpdateProfileRequest true "Campos a actualizar"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} dto.ProblemDetails
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 403 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 409 {object} dto.ProblemDetails "Email registrado o usuario en lista negra"
+// @Failure 500 {object} dto.ProblemDetails
+// @Failure 503 {object} dto.ProblemDetails "Servicio PLD no disponible"
+// @Router /users/{id} [patch]
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
+		return
+	}
+
+	h.updateProfile(c, uint(id))
+}
+
+// updateProfile aplica la actualización parcial de la solicitud al usuario indicado
+func (h *UserHandler) updateProfile(c *gin.Context, id uint) {
+	var req dto.UpdateProfileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		return
+	}
+
+	update := domain.UserUpdate{
+		Name:            req.Name,
+		Email:           req.Email,
+		IDNumber:        req.IDNumber,
+		GivenNames:      trimmed(req.GivenNames),
+		PaternalSurname: trimmed(req.PaternalSurname),
+		MaternalSurname: trimmed(req.MaternalSurname),
+		DateOfBirth:     req.DateOfBirth,
+		Nationality:     req.Nationality,
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), id, update)
+	if err != nil {
+		middleware.AbortWithError(c, "Error actualizando usuario", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserResponse(user))
+}
+
 // DeleteUser godoc
 // @Summary Eliminar usuario
 // @Description Elimina un usuario por su ID
@@ -195,36 +304,73 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 // @Param id path int true "ID del usuario"
 // @Security BearerAuth
 // @Success 200 {object} dto.SuccessResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ProblemDetails
+// @Failure 404 {object} dto.ProblemDetails
+// @Failure 500 {object} dto.ProblemDetails
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "ID inválido",
-			Details: err.Error(),
-		})
+		middleware.AbortWithError(c, "ID inválido", domain.WrapError(domain.ErrInvalidInput, "el ID debe ser un número entero positivo", err))
 		return
 	}
 
-	err = h.userService.DeleteUser(uint(id))
+	err = h.userService.DeleteUser(c.Request.Context(), uint(id))
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "usuario no encontrado" {
-			statusCode = http.StatusNotFound
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Error:   "Error eliminando usuario",
-			Details: err.Error(),
-		})
+		middleware.AbortWithError(c, "Error eliminando usuario", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Message: "Usuario eliminado correctamente",
+		Message: i18n.T(c, "Usuario eliminado correctamente"),
 	})
 }
+
+// toUserResponse convierte un usuario del dominio al DTO de respuesta
+func toUserResponse(user *domain.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		IDNumber:        user.IDNumber,
+		GivenNames:      user.GivenNames,
+		PaternalSurname: user.PaternalSurname,
+		MaternalSurname: user.MaternalSurname,
+		DateOfBirth:     user.DateOfBirth,
+		Nationality:     user.Nationality,
+		Role:            user.Role,
+		Status:          user.Status,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		FlaggedAt:       user.FlaggedAt,
+		FlagReason:      user.FlagReason,
+	}
+}
+
+// trimmed elimina los espacios de un campo opcional, si está presente
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*value)
+	return &v
+}
+
+// toScreeningResponse convierte un screening al DTO de respuesta
+func toScreeningResponse(screening *domain.Screening) dto.ScreeningResponse {
+	return dto.ScreeningResponse{
+		ID:             screening.ID,
+		UserID:         screening.UserID,
+		IDNumber:       screening.IDNumber,
+		Email:          screening.Email,
+		RequestPayload: screening.RequestPayload,
+		RawResponse:    screening.RawResponse,
+		Status:         screening.Status,
+		Provider:       screening.Provider,
+		MatchScore:     screening.MatchScore,
+		LatencyMs:      screening.LatencyMs,
+		Error:          screening.Error,
+		CreatedAt:      screening.CreatedAt,
+	}
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// languageKey es la clave del contexto de gin con el idioma negociado
+const languageKey = "language"
+
+// titlePrefix separa en el catálogo los títulos de problema, indexados por código, de los
+// mensajes, indexados por su texto en español
+const titlePrefix = "title."
+
+// DefaultLanguage es el idioma de los mensajes cuando el cliente no indica uno soportado
+var DefaultLanguage = language.Spanish
+
+// supported son los idiomas con catálogo. El primero es el idioma por defecto
+var supported = []language.Tag{language.Spanish, language.English}
+
+var matcher = language.NewMatcher(supported)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// bundle es el contenido de un archivo de locales: títulos por código de problema y
+// traducciones de los mensajes en español
+type bundle struct {
+	Titles   map[string]string `json:"titles"`
+	Messages map[string]string `json:"messages"`
+}
+
+// messages es el catálogo de mensajes de la API. Los mensajes sin traducción se muestran
+// en español
+var messages = mustLoadCatalog()
+
+// mustLoadCatalog construye el catálogo con los archivos de locales embebidos
+func mustLoadCatalog() *catalog.Builder {
+	builder := catalog.NewBuilder(catalog.Fallback(DefaultLanguage))
+	for _, tag := range supported {
+		data, err := locales.ReadFile(path.Join("locales", tag.String()+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s no encontrado: %v", tag, err))
+		}
+
+		var b bundle
+		if err := json.Unmarshal(data, &b); err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s inválido: %v", tag, err))
+		}
+
+		for code, title := range b.Titles {
+			builder.SetString(tag, titlePrefix+code, title)
+		}
+		for key, text := range b.Messages {
+			builder.SetString(tag, key, text)
+		}
+	}
+	return builder
+}
+
+// Supported retorna los idiomas soportados
+func Supported() []language.Tag {
+	return append([]language.Tag(nil), supported...)
+}
+
+// Negotiate elige el idioma soportado que mejor satisface el encabezado Accept-Language
+func Negotiate(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return supported[index]
+}
+
+// SetLanguage guarda el idioma de la solicitud en el contexto
+func SetLanguage(c *gin.Context, tag language.Tag) {
+	c.Set(languageKey, tag)
+}
+
+// Language retorna el idioma de la solicitud o el idioma por defecto si no se negoció
+func Language(c *gin.Context) language.Tag {
+	if tag, ok := c.Get(languageKey); ok {
+		if tag, ok := tag.(language.Tag); ok {
+			return tag
+		}
+	}
+	return DefaultLanguage
+}
+
+// Sprintf traduce un mensaje al idioma indicado y le aplica los argumentos. key es el
+// mensaje en español
+func Sprintf(tag language.Tag, key string, args ...interface{}) string {
+	return message.NewPrinter(tag, message.Catalog(messages)).Sprintf(key, args...)
+}
+
+// Text traduce un mensaje sin argumentos al idioma indicado. A diferencia de Sprintf, un
+// texto que no es un formato (p. ej. el mensaje de un error) se retorna sin cambios
+func Text(tag language.Tag, text string) string {
+	if strings.Contains(text, "%") {
+		return text
+	}
+	return Sprintf(tag, text)
+}
+
+// T traduce un mensaje al idioma de la solicitud
+func T(c *gin.Context, key string, args ...interface{}) string {
+	return Sprintf(Language(c), key, args...)
+}
+
+// Title retorna el título de un tipo de problema en el idioma indicado
+func Title(tag language.Tag, code string) string {
+	return Sprintf(tag, titlePrefix+code)
+}
+
+// HasTitle indica si el catálogo del idioma incluye el título de un tipo de problema
+func HasTitle(tag language.Tag, code string) bool {
+	return Title(tag, code) != titlePrefix+code
+}
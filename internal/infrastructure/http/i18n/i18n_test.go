@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected language.Tag
+	}{
+		{"", language.Spanish},
+		{"en", language.English},
+		{"en-US,en;q=0.9,es;q=0.8", language.English},
+		{"es-MX,es;q=0.9,en;q=0.8", language.Spanish},
+		{"fr-FR,en;q=0.5", language.English},
+		{"fr-FR", language.Spanish},
+		{"no es un encabezado válido;;", language.Spanish},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.expected {
+			t.Errorf("Expected %s for %q, got %s", tt.expected, tt.header, got)
+		}
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	if got := Sprintf(language.English, "el CSV no tiene la columna %s", "email"); got != "the CSV is missing the email column" {
+		t.Errorf("Expected English message, got %q", got)
+	}
+	if got := Sprintf(language.Spanish, "el CSV no tiene la columna %s", "email"); got != "el CSV no tiene la columna email" {
+		t.Errorf("Expected Spanish message, got %q", got)
+	}
+	// Los mensajes sin traducción se muestran en español
+	if got := Sprintf(language.English, "mensaje sin traducción"); got != "mensaje sin traducción" {
+		t.Errorf("Expected untranslated message, got %q", got)
+	}
+	if got := Text(language.English, "progreso 50%"); got != "progreso 50%" {
+		t.Errorf("Expected text without format, got %q", got)
+	}
+}
+
+func TestTitle(t *testing.T) {
+	if got := Title(language.English, "blacklisted"); got != "Blacklisted user" {
+		t.Errorf("Expected English title, got %q", got)
+	}
+	if HasTitle(language.Spanish, "desconocido") {
+		t.Error("Expected unknown code to have no title")
+	}
+}
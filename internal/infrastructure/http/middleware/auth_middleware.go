@@ -2,8 +2,7 @@ package middleware
 
 import (
 	"crabi-test/internal/application/services"
-	"crabi-test/internal/infrastructure/http/dto"
-	"net/http"
+	"crabi-test/internal/domain"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -12,12 +11,14 @@ import (
 // AuthMiddleware middleware para autenticación JWT
 type AuthMiddleware struct {
 	authService *services.AuthService
+	mfaService  *services.MFAService
 }
 
 // NewAuthMiddleware crea una nueva instancia del middleware de autenticación
-func NewAuthMiddleware(authService *services.AuthService) *AuthMiddleware {
+func NewAuthMiddleware(authService *services.AuthService, mfaService *services.MFAService) *AuthMiddleware {
 	return &AuthMiddleware{
 		authService: authService,
+		mfaService:  mfaService,
 	}
 }
 
@@ -27,38 +28,82 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		// Obtener token del header Authorization
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Error: "Token de autorización requerido",
-			})
-			c.Abort()
+			AbortWithError(c, "Token de autorización requerido", domain.NewError(domain.ErrUnauthenticated, "token de autorización requerido"))
 			return
 		}
 
 		// Verificar formato del token
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Error: "Formato de token inválido. Use: Bearer <token>",
-			})
-			c.Abort()
+			AbortWithError(c, "Formato de token inválido. Use: Bearer <token>", domain.NewError(domain.ErrInvalidToken, "formato de token inválido. Use: Bearer <token>"))
 			return
 		}
 
 		token := tokenParts[1]
 
 		// Validar token
-		user, err := m.authService.ValidateToken(token)
+		user, claims, err := m.authService.ValidateTokenClaims(c.Request.Context(), token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Error:   "Token inválido",
-				Details: err.Error(),
-			})
-			c.Abort()
+			AbortWithError(c, "Token inválido", err)
 			return
 		}
 
-		// Establecer usuario en el contexto
+		// Establecer usuario y token en el contexto; el token se usa al cerrar sesión y mfa
+		// indica si la sesión se inició con un segundo factor
 		c.Set("user", user)
+		c.Set("token", token)
+		c.Set("mfa", claims.MFA())
+		c.Next()
+	}
+}
+
+// RequireRole middleware que restringe el acceso a usuarios con alguno de los roles indicados.
+// Debe usarse después de Authenticate
+func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, exists := c.Get("user")
+		if !exists {
+			AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+			return
+		}
+
+		userDomain := user.(*domain.User)
+		for _, role := range roles {
+			if userDomain.Role == role {
+				c.Next()
+				return
+			}
+		}
+
+		AbortWithError(c, "Permisos insuficientes", domain.ErrForbidden)
+	}
+}
+
+// RequireMFA middleware que rechaza las sesiones iniciadas sin segundo factor de los usuarios
+// cuyo rol exige MFA. Debe usarse después de Authenticate
+func (m *AuthMiddleware) RequireMFA() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("mfa") {
+			c.Next()
+			return
+		}
+
+		user, exists := c.Get("user")
+		if !exists {
+			AbortWithError(c, "Usuario no autenticado", domain.ErrUnauthenticated)
+			return
+		}
+
+		required, err := m.mfaService.IsRequired(c.Request.Context(), user.(*domain.User).Role)
+		if err != nil {
+			AbortWithError(c, "Error verificando MFA", err)
+			return
+		}
+		if required {
+			AbortWithError(c, "Se requiere autenticación multifactor", domain.NewError(domain.ErrMFARequired, "el rol del usuario requiere iniciar sesión con MFA"))
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"context"
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// Códigos de error estables incluidos en las respuestas
+const (
+	CodeInvalidInput          = "invalid_input"
+	CodeNotFound              = "not_found"
+	CodeConflict              = "conflict"
+	CodeEmailTaken            = "email_taken"
+	CodeBlacklisted           = "blacklisted"
+	CodeRescreeningInProgress = "rescreening_in_progress"
+	CodeInvalidCredentials    = "invalid_credentials"
+	CodeInvalidToken          = "invalid_token"
+	CodeUnauthenticated       = "unauthenticated"
+	CodeForbidden             = "forbidden"
+	CodeMFARequired           = "mfa_required"
+	CodePendingReview         = "pending_review"
+	CodeRejected              = "rejected"
+	CodeTooManyAttempts       = "too_many_attempts"
+	CodePLDUnavailable        = "pld_unavailable"
+	CodeTimeout               = "timeout"
+	CodeInternal              = "internal_error"
+)
+
+// ProblemTypeBase es el prefijo de la URI que identifica cada tipo de problema. La URI
+// apunta al endpoint que describe el tipo
+const ProblemTypeBase = "/api/v1/problems/"
+
+// ProblemType describe un tipo de problema: su código estable y el código HTTP. El título
+// de cada código está en el catálogo de mensajes (ver i18n.Title)
+type ProblemType struct {
+	Code   string
+	Status int
+}
+
+// URI retorna la URI que identifica el tipo de problema
+func (p ProblemType) URI() string {
+	return ProblemTypeBase + p.Code
+}
+
+// errorMapping asocia un error del dominio con su tipo de problema
+type errorMapping struct {
+	target  error
+	problem ProblemType
+}
+
+// errorMappings se evalúa en orden: los errores específicos van antes que su categoría
+var errorMappings = []errorMapping{
+	{domain.ErrEmailTaken, ProblemType{CodeEmailTaken, http.StatusConflict}},
+	{domain.ErrBlacklisted, ProblemType{CodeBlacklisted, http.StatusConflict}},
+	{services.ErrRescreeningInProgress, ProblemType{CodeRescreeningInProgress, http.StatusConflict}},
+	{domain.ErrInvalidCredentials, ProblemType{CodeInvalidCredentials, http.StatusUnauthorized}},
+	{domain.ErrInvalidToken, ProblemType{CodeInvalidToken, http.StatusUnauthorized}},
+	{domain.ErrUnauthenticated, ProblemType{CodeUnauthenticated, http.StatusUnauthorized}},
+	{domain.ErrForbidden, ProblemType{CodeForbidden, http.StatusForbidden}},
+	{domain.ErrMFARequired, ProblemType{CodeMFARequired, http.StatusForbidden}},
+	{domain.ErrPendingReview, ProblemType{CodePendingReview, http.StatusForbidden}},
+	{domain.ErrRejected, ProblemType{CodeRejected, http.StatusForbidden}},
+	{domain.ErrTooManyAttempts, ProblemType{CodeTooManyAttempts, http.StatusTooManyRequests}},
+	{domain.ErrPLDUnavailable, ProblemType{CodePLDUnavailable, http.StatusServiceUnavailable}},
+	{domain.ErrNotFound, ProblemType{CodeNotFound, http.StatusNotFound}},
+	{domain.ErrInvalidInput, ProblemType{CodeInvalidInput, http.StatusBadRequest}},
+	{domain.ErrConflict, ProblemType{CodeConflict, http.StatusConflict}},
+	{context.DeadlineExceeded, ProblemType{CodeTimeout, http.StatusGatewayTimeout}},
+}
+
+// internalProblem es el tipo de problema de los errores que no pertenecen al dominio
+var internalProblem = ProblemType{CodeInternal, http.StatusInternalServerError}
+
+// MapError obtiene el tipo de problema de un error. Los errores que no pertenecen al
+// dominio se reportan como error interno
+func MapError(err error) ProblemType {
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.target) {
+			return mapping.problem
+		}
+	}
+	return internalProblem
+}
+
+// LookupProblemType busca un tipo de problema por su código estable
+func LookupProblemType(code string) (ProblemType, bool) {
+	if code == internalProblem.Code {
+		return internalProblem, true
+	}
+	for _, mapping := range errorMappings {
+		if mapping.problem.Code == code {
+			return mapping.problem, true
+		}
+	}
+	return ProblemType{}, false
+}
+
+// ErrorHandler traduce el último error registrado con c.Error a una respuesta
+// application/problem+json (RFC 7807) en el idioma de la solicitud. El metadato del error,
+// si es un string, describe la operación que falló y se usa como detalle cuando el error no
+// aporta uno propio. Los errores internos se registran en el log y nunca se exponen al
+// cliente. No hace nada si el handler ya escribió una respuesta
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		last := c.Errors.Last()
+		problem := MapError(last.Err)
+		if problem.Status >= http.StatusInternalServerError {
+			log.Printf("error en %s %s: %v", c.Request.Method, c.FullPath(), last.Err)
+		}
+
+		lang := i18n.Language(c)
+		message, _ := last.Meta.(string)
+		response := dto.ProblemDetails{
+			Type:     problem.URI(),
+			Title:    i18n.Title(lang, problem.Code),
+			Status:   problem.Status,
+			Detail:   problemDetail(lang, last.Err, problem, message),
+			Instance: c.Request.URL.Path,
+			Code:     problem.Code,
+			Errors:   fieldErrors(lang, last.Err),
+		}
+
+		c.Header("Content-Type", dto.ProblemContentType)
+		c.JSON(problem.Status, response)
+	}
+}
+
+// AbortWithError registra el error para que ErrorHandler genere la respuesta y detiene la
+// cadena de handlers. message describe la operación que falló
+func AbortWithError(c *gin.Context, message string, err error) {
+	c.Error(err).SetMeta(message)
+	c.Abort()
+}
+
+// problemDetail describe la ocurrencia del problema sin exponer errores técnicos: usa el
+// mensaje del error del dominio o, en los errores internos y plazos agotados, el mensaje de
+// la operación. El detalle se traduce al idioma indicado
+func problemDetail(lang language.Tag, err error, problem ProblemType, message string) string {
+	if problem == internalProblem || problem.Code == CodeTimeout {
+		return i18n.Text(lang, message)
+	}
+
+	var validationErrs validator.ValidationErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs), errors.As(err, &typeErr):
+		return i18n.Sprintf(lang, "La solicitud tiene campos inválidos")
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return i18n.Sprintf(lang, "El cuerpo de la solicitud no es JSON válido")
+	case errors.Is(err, io.EOF):
+		return i18n.Sprintf(lang, "El cuerpo de la solicitud está vacío")
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return i18n.Sprintf(lang, domainErr.Message, domainErr.Args...)
+	}
+	return i18n.Text(lang, err.Error())
+}
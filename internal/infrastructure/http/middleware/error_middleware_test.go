@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	customvalidator "crabi-test/pkg/validator"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{domain.ErrEmailTaken, http.StatusConflict, CodeEmailTaken},
+		{domain.NewError(domain.ErrBlacklisted, "usuario en lista negra: OFAC"), http.StatusConflict, CodeBlacklisted},
+		{services.ErrRescreeningInProgress, http.StatusConflict, CodeRescreeningInProgress},
+		{domain.NewError(domain.ErrConflict, "el usuario no está pendiente de revisión"), http.StatusConflict, CodeConflict},
+		{domain.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials},
+		{domain.NewError(domain.ErrInvalidToken, "usuario no encontrado"), http.StatusUnauthorized, CodeInvalidToken},
+		{domain.ErrPendingReview, http.StatusForbidden, CodePendingReview},
+		{domain.NewError(domain.ErrMFARequired, "el rol del usuario requiere iniciar sesión con MFA"), http.StatusForbidden, CodeMFARequired},
+		{domain.ErrTooManyAttempts, http.StatusTooManyRequests, CodeTooManyAttempts},
+		{domain.ErrPLDUnavailable, http.StatusServiceUnavailable, CodePLDUnavailable},
+		{fmt.Errorf("lote 3: %w", domain.ErrNotFound), http.StatusNotFound, CodeNotFound},
+		{domain.Errorf(domain.ErrInvalidInput, "el lote excede el máximo de %d filas", 10), http.StatusBadRequest, CodeInvalidInput},
+		{domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", errors.New("EOF")), http.StatusBadRequest, CodeInvalidInput},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout, CodeTimeout},
+		{errors.New("database error"), http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			problem := MapError(tt.err)
+			if problem.Status != tt.status || problem.Code != tt.code {
+				t.Errorf("Expected %d %s for %v, got %+v", tt.status, tt.code, tt.err, problem)
+			}
+		})
+	}
+}
+
+func TestProblemTitles(t *testing.T) {
+	problems := []ProblemType{internalProblem}
+	for _, mapping := range errorMappings {
+		problems = append(problems, mapping.problem)
+	}
+
+	for _, lang := range i18n.Supported() {
+		for _, problem := range problems {
+			if !i18n.HasTitle(lang, problem.Code) {
+				t.Errorf("Expected %s title for %s", lang, problem.Code)
+			}
+		}
+	}
+}
+
+func TestLookupProblemType(t *testing.T) {
+	for _, code := range []string{CodeBlacklisted, CodeInternal} {
+		if problem, ok := LookupProblemType(code); !ok || problem.URI() != ProblemTypeBase+code {
+			t.Errorf("Expected problem type %s, got %+v (%t)", code, problem, ok)
+		}
+	}
+	if _, ok := LookupProblemType("desconocido"); ok {
+		t.Error("Expected unknown code not to be found")
+	}
+}
+
+type testSignup struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+func newErrorTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(customvalidator.CustomValidator())
+	r.Use(Language())
+	r.Use(ErrorHandler())
+
+	r.GET("/blacklisted", func(c *gin.Context) {
+		AbortWithError(c, "Error creando usuario", domain.Errorf(domain.ErrBlacklisted, "usuario en lista negra: %s", "OFAC"))
+	})
+	r.GET("/internal", func(c *gin.Context) {
+		AbortWithError(c, "Error obteniendo usuario", errors.New("sql: database is locked"))
+	})
+	r.GET("/written", func(c *gin.Context) {
+		c.Error(domain.ErrNotFound)
+		c.JSON(http.StatusOK, gin.H{"status": "OK"})
+	})
+	r.POST("/signup", func(c *gin.Context) {
+		var req testSignup
+		if err := c.ShouldBindJSON(&req); err != nil {
+			AbortWithError(c, "Datos de entrada inválidos", domain.WrapError(domain.ErrInvalidInput, "datos de entrada inválidos", err))
+		}
+	})
+	return r
+}
+
+func performProblemRequest(t *testing.T, r *gin.Engine, method, path, body string) (int, dto.ProblemDetails) {
+	t.Helper()
+	return performLocalizedProblemRequest(t, r, method, path, body, "")
+}
+
+func performLocalizedProblemRequest(t *testing.T, r *gin.Engine, method, path, body, acceptLanguage string) (int, dto.ProblemDetails) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	r.ServeHTTP(w, req)
+
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, dto.ProblemContentType) {
+		t.Errorf("Expected %s content type for %s, got %q", dto.ProblemContentType, path, contentType)
+	}
+
+	var problem dto.ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Expected JSON response for %s, got %s", path, w.Body.String())
+	}
+	return w.Code, problem
+}
+
+func TestErrorHandler_DomainError(t *testing.T) {
+	status, problem := performProblemRequest(t, newErrorTestRouter(), http.MethodGet, "/blacklisted", "")
+
+	expected := dto.ProblemDetails{
+		Type:     ProblemTypeBase + CodeBlacklisted,
+		Title:    "Usuario en lista negra",
+		Status:   http.StatusConflict,
+		Detail:   "usuario en lista negra: OFAC",
+		Instance: "/blacklisted",
+		Code:     CodeBlacklisted,
+	}
+	if status != http.StatusConflict || !reflect.DeepEqual(problem, expected) {
+		t.Errorf("Expected %+v, got %d %+v", expected, status, problem)
+	}
+}
+
+func TestErrorHandler_InternalErrorIsNotExposed(t *testing.T) {
+	status, problem := performProblemRequest(t, newErrorTestRouter(), http.MethodGet, "/internal", "")
+
+	if status != http.StatusInternalServerError || problem.Code != CodeInternal || problem.Detail != "Error obteniendo usuario" {
+		t.Errorf("Expected generic internal error, got %d %+v", status, problem)
+	}
+}
+
+func TestErrorHandler_ValidationErrors(t *testing.T) {
+	r := newErrorTestRouter()
+
+	status, problem := performProblemRequest(t, r, http.MethodPost, "/signup", `{"email": "no-es-email", "password": "corta"}`)
+	expected := []dto.FieldError{
+		{Field: "email", Code: "email", Message: "email debe ser una dirección de correo electrónico válida"},
+		{Field: "password", Code: "min", Message: "password debe tener al menos 8 caracteres de longitud"},
+	}
+	if status != http.StatusBadRequest || problem.Code != CodeInvalidInput || !reflect.DeepEqual(problem.Errors, expected) {
+		t.Errorf("Expected field errors %+v, got %d %+v", expected, status, problem)
+	}
+
+	_, problem = performProblemRequest(t, r, http.MethodPost, "/signup", `{"email": 5}`)
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "email" || problem.Errors[0].Code != "type" {
+		t.Errorf("Expected type error for email, got %+v", problem)
+	}
+
+	_, problem = performProblemRequest(t, r, http.MethodPost, "/signup", `{"email": `)
+	if problem.Detail != "El cuerpo de la solicitud no es JSON válido" || len(problem.Errors) != 0 {
+		t.Errorf("Expected invalid JSON detail, got %+v", problem)
+	}
+}
+
+func TestErrorHandler_Localized(t *testing.T) {
+	r := newErrorTestRouter()
+
+	status, problem := performLocalizedProblemRequest(t, r, http.MethodGet, "/blacklisted", "", "en-US,en;q=0.9")
+	if status != http.StatusConflict || problem.Title != "Blacklisted user" || problem.Detail != "user is blacklisted: OFAC" {
+		t.Errorf("Expected English problem, got %d %+v", status, problem)
+	}
+
+	_, problem = performLocalizedProblemRequest(t, r, http.MethodGet, "/internal", "", "en")
+	if problem.Title != "Internal error" || problem.Detail != "Error retrieving user" {
+		t.Errorf("Expected English internal error, got %+v", problem)
+	}
+
+	_, problem = performLocalizedProblemRequest(t, r, http.MethodPost, "/signup", `{"email": "no-es-email", "password": "corta"}`, "en")
+	expected := []dto.FieldError{
+		{Field: "email", Code: "email", Message: "email must be a valid email address"},
+		{Field: "password", Code: "min", Message: "password must be at least 8 characters in length"},
+	}
+	if problem.Detail != "The request has invalid fields" || !reflect.DeepEqual(problem.Errors, expected) {
+		t.Errorf("Expected English field errors %+v, got %+v", expected, problem)
+	}
+
+	_, problem = performLocalizedProblemRequest(t, r, http.MethodGet, "/blacklisted", "", "fr-FR")
+	if problem.Title != "Usuario en lista negra" {
+		t.Errorf("Expected Spanish fallback for unsupported language, got %+v", problem)
+	}
+}
+
+func TestErrorHandler_KeepsWrittenResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	newErrorTestRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/written", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected handler response to be kept, got %d %s", w.Code, w.Body.String())
+	}
+}
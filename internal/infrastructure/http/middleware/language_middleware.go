@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"crabi-test/internal/infrastructure/http/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language negocia el idioma de la respuesta a partir del encabezado Accept-Language. Los
+// idiomas soportados son español (por defecto) e inglés
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		i18n.SetLanguage(c, lang)
+
+		c.Header("Content-Language", lang.String())
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
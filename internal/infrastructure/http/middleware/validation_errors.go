@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"crabi-test/internal/infrastructure/http/dto"
+	"crabi-test/internal/infrastructure/http/i18n"
+	customvalidator "crabi-test/pkg/validator"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// fieldErrors obtiene los errores por campo de un error de binding o de validación, con los
+// mensajes en el idioma indicado. Los nombres de campo son los del JSON de la solicitud
+func fieldErrors(lang language.Tag, err error) []dto.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		trans := customvalidator.Translator(lang.String())
+		fields := make([]dto.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, dto.FieldError{
+				Field:   fieldPath(fe),
+				Code:    fe.Tag(),
+				Message: fe.Translate(trans),
+			})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []dto.FieldError{{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: i18n.Sprintf(lang, "%s debe ser de tipo %s", typeErr.Field, i18n.Sprintf(lang, jsonTypeName(typeErr.Type))),
+		}}
+	}
+
+	return nil
+}
+
+// fieldPath retorna la ruta del campo sin el nombre del struct raíz (p. ej. rows[0].name)
+func fieldPath(fe validator.FieldError) string {
+	if _, path, found := strings.Cut(fe.Namespace(), "."); found {
+		return path
+	}
+	return fe.Field()
+}
+
+// jsonTypeName nombra en español el tipo JSON esperado para un campo de Go
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "desconocido"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "texto"
+	case reflect.Bool:
+		return "booleano"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "número"
+	case reflect.Slice, reflect.Array:
+		return "arreglo"
+	default:
+		return "objeto"
+	}
+}
@@ -1,13 +1,24 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
+	"log"
+	"os"
+	"strings"
+	"time"
 
 	"crabi-test/internal/adapters/repositories"
+	"crabi-test/internal/application/ports"
 	"crabi-test/internal/application/services"
+	"crabi-test/internal/domain"
 	"crabi-test/internal/infrastructure/external"
 	"crabi-test/internal/infrastructure/http/handlers"
 	"crabi-test/internal/infrastructure/http/middleware"
+	"crabi-test/internal/infrastructure/notifier"
+	"crabi-test/internal/infrastructure/revocation"
+	"crabi-test/internal/infrastructure/watchlist"
+	"crabi-test/pkg/jwtkeys"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,20 +27,66 @@ import (
 func SetupRoutes(r *gin.Engine, db *sql.DB) {
 	// Crear instancias de repositorios
 	userRepo := repositories.NewUserRepository(db)
+	screeningRepo := repositories.NewScreeningRepository(db)
+	rejectedRepo := repositories.NewRejectedApplicationRepository(db)
+	rescreeningRunRepo := repositories.NewRescreeningRunRepository(db)
+	reviewDecisionRepo := repositories.NewReviewDecisionRepository(db)
+	batchScreeningRepo := repositories.NewBatchScreeningRepository(db)
+	passwordResetRepo := repositories.NewPasswordResetRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	tokenRevocationRepo := newTokenRevocationRepository(db)
+	mfaRepo := repositories.NewMFARepository(db)
+	mfaChallengeRepo := repositories.NewMFAChallengeRepository(db)
+	mfaPolicyRepo := repositories.NewMFAPolicyRepository(db)
 
 	// Crear instancias de servicios externos
-	pldClient := external.NewPLDClient()
+	pldProvider, watchlistStore, pldBreakers := newPLDProvider(db)
+	pldService, pldCache := newPLDCache(db, pldProvider, watchlistStore)
+
+	// Cargar las claves de firma de los tokens
+	keyRing := newKeyRing()
 
 	// Crear instancias de servicios de aplicación
-	userService := services.NewUserService(userRepo, pldClient)
-	authService := services.NewAuthService(userRepo)
+	userService := services.NewUserService(userRepo, userRepo, pldService, screeningRepo, rejectedRepo)
+	authService := services.NewAuthService(userRepo, tokenRevocationRepo, keyRing)
+	refreshTokenService := services.NewRefreshTokenService(userRepo, refreshTokenRepo, authService, services.RefreshTokenConfigFromEnv())
+	complianceService := services.NewComplianceService(rejectedRepo)
+	rescreeningService := services.NewRescreeningService(userRepo, userRepo, pldService, screeningRepo, rescreeningRunRepo, services.RescreeningConfigFromEnv())
+	reviewService := services.NewReviewService(userRepo, userRepo, screeningRepo, reviewDecisionRepo, rejectedRepo)
+	batchScreeningService := services.NewBatchScreeningService(pldService, screeningRepo, batchScreeningRepo, services.BatchScreeningConfigFromEnv())
+	passwordService := services.NewPasswordService(userRepo, userRepo, passwordResetRepo, newNotifier(), authService, refreshTokenService, services.PasswordResetConfigFromEnv())
+	mfaService := services.NewMFAService(userRepo, mfaRepo, mfaChallengeRepo, mfaPolicyRepo, authService, refreshTokenService, services.MFAConfigFromEnv())
 
 	// Crear instancias de handlers
 	userHandler := handlers.NewUserHandler(userService, authService)
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, refreshTokenService, mfaService)
+	healthHandler := handlers.NewHealthHandler(pldBreakers, pldCache)
+	complianceHandler := handlers.NewComplianceHandler(complianceService)
+	rescreeningHandler := handlers.NewRescreeningHandler(rescreeningService)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	batchScreeningHandler := handlers.NewBatchScreeningHandler(batchScreeningService)
+	problemHandler := handlers.NewProblemHandler()
+	passwordHandler := handlers.NewPasswordHandler(passwordService)
+	jwksHandler := handlers.NewJWKSHandler(keyRing)
+	mfaHandler := handlers.NewMFAHandler(mfaService, authService, refreshTokenService)
+
+	// Reanudar el re-screening pendiente y programar las ejecuciones periódicas
+	rescreeningService.StartScheduler(context.Background())
+
+	// Reanudar los lotes de screening que quedaron en curso
+	batchScreeningService.ResumeUnfinished(context.Background())
 
 	// Crear middleware de autenticación
-	authMiddleware := middleware.NewAuthMiddleware(authService)
+	authMiddleware := middleware.NewAuthMiddleware(authService, mfaService)
+
+	// Ruta de health check
+	r.GET("/health", healthHandler.Health)
+
+	// Claves públicas para verificar los tokens desde otros servicios
+	r.GET("/.well-known/jwks.json", jwksHandler.JWKS)
+
+	// Las rutas inexistentes responden con application/problem+json
+	r.NoRoute(problemHandler.NoRoute)
 
 	// Grupo de rutas de la API
 	api := r.Group("/api/v1")
@@ -38,14 +95,237 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 	{
 		api.POST("/users", userHandler.CreateUser)
 		api.POST("/auth/login", authHandler.Login)
+		api.POST("/auth/refresh", authHandler.Refresh)
+		api.POST("/auth/mfa/verify", authHandler.VerifyMFA)
+		api.POST("/auth/password/forgot", passwordHandler.ForgotPassword)
+		api.POST("/auth/password/reset", passwordHandler.ResetPassword)
+		api.GET("/problems/:code", problemHandler.GetProblemType)
+	}
+
+	// Rutas autenticadas que no exigen MFA, para que los usuarios de un rol que lo exige
+	// puedan activarlo
+	authenticated := api.Group("")
+	authenticated.Use(authMiddleware.Authenticate())
+	{
+		authenticated.POST("/auth/logout", authHandler.Logout)
+		authenticated.GET("/users/me", userHandler.GetUser)
+		authenticated.GET("/users/me/mfa", mfaHandler.GetStatus)
+		authenticated.POST("/users/me/mfa/enroll", mfaHandler.StartEnrollment)
+		authenticated.POST("/users/me/mfa/confirm", mfaHandler.ConfirmEnrollment)
+		authenticated.POST("/users/me/mfa/disable", mfaHandler.Disable)
+		authenticated.POST("/users/me/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
 	}
 
-	// Rutas protegidas (requieren autenticación)
-	protected := api.Group("")
-	protected.Use(authMiddleware.Authenticate())
+	// Rutas protegidas (requieren autenticación, y MFA si el rol del usuario lo exige)
+	protected := authenticated.Group("")
+	protected.Use(authMiddleware.RequireMFA())
 	{
-		protected.GET("/users/me", userHandler.GetUser)
+		protected.PATCH("/users/me", userHandler.UpdateCurrentUser)
+		protected.POST("/users/me/password", passwordHandler.ChangePassword)
 		protected.GET("/users/:id", userHandler.GetUserByID)
+		protected.GET("/users/:id/screenings", userHandler.GetUserScreenings)
+		protected.PATCH("/users/:id", authMiddleware.RequireRole(domain.RoleAdmin), userHandler.UpdateUser)
 		protected.DELETE("/users/:id", userHandler.DeleteUser)
 	}
+
+	// Rutas de administración (requieren rol de administrador)
+	admin := protected.Group("/admin")
+	admin.Use(authMiddleware.RequireRole(domain.RoleAdmin))
+	{
+		admin.GET("/rejected-applications", complianceHandler.ListRejectedApplications)
+		admin.POST("/rescreenings", rescreeningHandler.TriggerRescreening)
+		admin.GET("/rescreenings/latest", rescreeningHandler.GetLatestRescreening)
+		admin.GET("/reviews", reviewHandler.ListPendingReviews)
+		admin.POST("/reviews/:id/approve", reviewHandler.ApproveReview)
+		admin.POST("/reviews/:id/reject", reviewHandler.RejectReview)
+		admin.GET("/reviews/:id/decisions", reviewHandler.ListReviewDecisions)
+		admin.GET("/mfa/policies", mfaHandler.ListPolicies)
+		admin.PUT("/mfa/policies/:role", mfaHandler.UpdatePolicy)
+	}
+
+	// Lotes de screening de prospectos (requieren rol de administrador)
+	screenings := protected.Group("/screenings")
+	screenings.Use(authMiddleware.RequireRole(domain.RoleAdmin))
+	{
+		screenings.POST("/batch", batchScreeningHandler.SubmitBatch)
+		screenings.GET("/batch/:id", batchScreeningHandler.GetBatch)
+		screenings.GET("/batch/:id/results.csv", batchScreeningHandler.DownloadBatchResults)
+	}
+}
+
+// newKeyRing carga la clave activa de JWT_SIGNING_KEY_FILE y las claves anteriores de
+// JWT_PREVIOUS_KEY_FILES (rutas separadas por comas). Sin clave configurada genera una clave
+// temporal, salvo en modo release: los tokens firmados con ella no sobreviven un reinicio
+func newKeyRing() *jwtkeys.KeyRing {
+	var active *jwtkeys.Key
+	var err error
+	if path := os.Getenv("JWT_SIGNING_KEY_FILE"); path != "" {
+		active, err = jwtkeys.LoadKeyFile(path)
+	} else {
+		if os.Getenv("GIN_MODE") == "release" {
+			panic("JWT_SIGNING_KEY_FILE es obligatorio en modo release")
+		}
+		log.Println("JWT_SIGNING_KEY_FILE no configurado, se usa una clave temporal solo para desarrollo")
+		active, err = jwtkeys.GenerateKey(jwtkeys.ES256)
+	}
+	if err != nil {
+		panic("error cargando la clave de firma JWT: " + err.Error())
+	}
+
+	var previous []*jwtkeys.Key
+	for _, path := range strings.Split(os.Getenv("JWT_PREVIOUS_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		key, err := jwtkeys.LoadKeyFile(path)
+		if err != nil {
+			panic("error cargando la clave JWT anterior: " + err.Error())
+		}
+		previous = append(previous, key)
+	}
+
+	keyRing, err := jwtkeys.NewKeyRing(active, previous...)
+	if err != nil {
+		panic("error creando el anillo de claves JWT: " + err.Error())
+	}
+
+	log.Printf("Tokens JWT firmados con %s (kid %s); claves anteriores: %d", active.Algorithm, active.ID, len(previous))
+	return keyRing
+}
+
+// newTokenRevocationRepository crea el almacén de revocaciones de tokens con la caché en
+// memoria delante de SQLite, y programa la depuración de las revocaciones vencidas
+func newTokenRevocationRepository(db *sql.DB) ports.TokenRevocationRepository {
+	cache, err := revocation.NewCachedRepository(context.Background(), repositories.NewTokenRevocationRepository(db), revocation.CacheConfigFromEnv())
+	if err != nil {
+		panic("error cargando revocaciones de tokens: " + err.Error())
+	}
+
+	purgeInterval := 10 * time.Minute
+	if v, err := time.ParseDuration(os.Getenv("TOKEN_REVOCATION_PURGE_INTERVAL")); err == nil && v > 0 {
+		purgeInterval = v
+	}
+
+	cache.StartPurge(context.Background(), purgeInterval)
+	return cache
+}
+
+// newPLDProvider crea el proveedor PLD configurado en PLD_PROVIDER. Acepta un proveedor o una
+// lista separada por comas; con varios se combinan según PLD_AGGREGATION_STRATEGY. También
+// retorna el almacén de listas de sanciones si se usa el proveedor local y los circuit
+// breakers de los proveedores remotos, por nombre de proveedor
+func newPLDProvider(db *sql.DB) (ports.PLDService, *watchlist.Store, map[string]handlers.CircuitStateProvider) {
+	breakers := make(map[string]handlers.CircuitStateProvider)
+
+	names := strings.Split(os.Getenv("PLD_PROVIDER"), ",")
+	if len(names) == 1 {
+		provider, store, breaker := newPLDBackend(db, strings.TrimSpace(names[0]))
+		if breaker != nil {
+			breakers[provider.Name] = breaker
+		}
+		return provider.Service, store, breakers
+	}
+
+	strategy, err := external.AggregationStrategyFromEnv()
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// Cada proveedor tiene su circuit breaker y su veredicto identificado por nombre, por lo que
+	// no puede repetirse
+	var watchlistStore *watchlist.Store
+	providers := make([]external.NamedPLDService, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		provider, store, breaker := newPLDBackend(db, strings.TrimSpace(name))
+		if seen[provider.Name] {
+			panic("PLD_PROVIDER repite el proveedor " + provider.Name + ": " + os.Getenv("PLD_PROVIDER"))
+		}
+		seen[provider.Name] = true
+		if store != nil {
+			watchlistStore = store
+		}
+		if breaker != nil {
+			breakers[provider.Name] = breaker
+		}
+		providers = append(providers, provider)
+	}
+	return external.NewCompositePLDService(strategy, providers...), watchlistStore, breakers
+}
+
+// newPLDBackend crea un proveedor PLD individual: "remote" (por defecto) usa el servicio HTTP
+// externo detrás de su propio circuit breaker, para que un proveedor caído falle rápido sin
+// bloquear a los demás, y "local" las listas de sanciones importadas en la base de datos
+func newPLDBackend(db *sql.DB, name string) (external.NamedPLDService, *watchlist.Store, *external.CircuitBreakerPLDService) {
+	switch name {
+	case "", "remote":
+		breaker := external.NewCircuitBreakerPLDService(external.NewPLDClient(), external.CircuitBreakerConfigFromEnv())
+		return external.NamedPLDService{Name: external.PLDProviderName, Service: breaker}, nil, breaker
+	case "local":
+		watchlistRepo := repositories.NewWatchlistRepository(db)
+		store := watchlist.NewStore()
+		if err := store.Reload(context.Background(), watchlistRepo); err != nil {
+			log.Printf("error cargando listas de sanciones: %v", err)
+		}
+
+		reloadInterval := 5 * time.Minute
+		if v, err := time.ParseDuration(os.Getenv("PLD_WATCHLIST_RELOAD_INTERVAL")); err == nil && v > 0 {
+			reloadInterval = v
+		}
+		go store.Watch(context.Background(), watchlistRepo, reloadInterval)
+
+		service := watchlist.NewLocalPLDService(store, watchlist.MatchThresholdFromEnv(), watchlist.ReviewThresholdFromEnv())
+		return external.NamedPLDService{Name: watchlist.LocalProviderName, Service: service}, store, nil
+	default:
+		panic("PLD_PROVIDER inválido: " + name)
+	}
+}
+
+// newNotifier crea el notificador configurado en NOTIFIER: "log" (por defecto) escribe los
+// avisos en el log y "file" los agrega como líneas JSON al archivo NOTIFIER_FILE. Ambos son
+// para uso local: los avisos incluyen tokens en claro
+func newNotifier() ports.Notifier {
+	switch os.Getenv("NOTIFIER") {
+	case "", "log":
+		return notifier.NewLogNotifier()
+	case "file":
+		path := os.Getenv("NOTIFIER_FILE")
+		if path == "" {
+			path = "./notifications.log"
+		}
+		return notifier.NewFileNotifier(path)
+	default:
+		panic("NOTIFIER inválido: " + os.Getenv("NOTIFIER"))
+	}
+}
+
+// newPLDCache envuelve el servicio PLD con la caché indicada en PLD_CACHE: "off" (por defecto),
+// "memory" o "sqlite" (sobrevive a reinicios). Con listas locales, cargar una versión nueva
+// invalida los resultados cacheados
+func newPLDCache(db *sql.DB, pldService ports.PLDService, watchlistStore *watchlist.Store) (ports.PLDService, handlers.CacheStatsProvider) {
+	var repo ports.PLDCacheRepository
+	switch os.Getenv("PLD_CACHE") {
+	case "", "off":
+		return pldService, nil
+	case "memory":
+		repo = external.NewMemoryPLDCacheRepository()
+	case "sqlite":
+		repo = repositories.NewPLDCacheRepository(db)
+	default:
+		panic("PLD_CACHE inválido: " + os.Getenv("PLD_CACHE"))
+	}
+
+	var listVersion func() string
+	if watchlistStore != nil {
+		listVersion = watchlistStore.Fingerprint
+	}
+
+	purgeInterval := 10 * time.Minute
+	if v, err := time.ParseDuration(os.Getenv("PLD_CACHE_PURGE_INTERVAL")); err == nil && v > 0 {
+		purgeInterval = v
+	}
+
+	cache := external.NewCachedPLDService(pldService, repo, external.PLDCacheConfigFromEnv(), listVersion)
+	cache.StartPurge(context.Background(), purgeInterval)
+	return cache, cache
 }
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Tipos de aviso registrados por FileNotifier
+const (
+	NoticeTypePasswordReset = "password_reset"
+)
+
+// FileNotifier agrega cada aviso como una línea JSON a un archivo, a modo de bandeja de
+// salida local. Permite consultar los avisos enviados durante el desarrollo y las pruebas
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// FileNotice es la línea que FileNotifier escribe por cada aviso
+type FileNotice struct {
+	Type          string                      `json:"type"`
+	SentAt        time.Time                   `json:"sent_at"`
+	PasswordReset *domain.PasswordResetNotice `json:"password_reset,omitempty"`
+}
+
+// NewFileNotifier crea un notificador que escribe en el archivo indicado
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+// NotifyPasswordReset agrega el aviso de restablecimiento de contraseña al archivo
+func (n *FileNotifier) NotifyPasswordReset(ctx context.Context, notice domain.PasswordResetNotice) error {
+	return n.write(FileNotice{Type: NoticeTypePasswordReset, SentAt: time.Now(), PasswordReset: &notice})
+}
+
+// write agrega una línea al archivo. El archivo solo es legible por el usuario del proceso
+// porque contiene tokens en claro
+func (n *FileNotifier) write(notice FileNotice) error {
+	line, err := json.Marshal(notice)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"crabi-test/internal/domain"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileNotifier_AppendsNotices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	notifier := NewFileNotifier(path)
+
+	for _, email := range []string{"juan@email.com", "maria@email.com"} {
+		notice := domain.PasswordResetNotice{UserID: 1, Email: email, Token: "token-" + email, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := notifier.NotifyPasswordReset(context.Background(), notice); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected notifications file, got %v", err)
+	}
+	defer file.Close()
+
+	var notices []FileNotice
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var notice FileNotice
+		if err := json.Unmarshal(scanner.Bytes(), &notice); err != nil {
+			t.Fatalf("Expected JSON line, got %s", scanner.Text())
+		}
+		notices = append(notices, notice)
+	}
+
+	if len(notices) != 2 || notices[1].Type != NoticeTypePasswordReset || notices[1].PasswordReset.Token != "token-maria@email.com" {
+		t.Errorf("Expected two password reset notices, got %+v", notices)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected file readable only by the owner, got %v", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"log"
+	"time"
+)
+
+// LogNotifier escribe los avisos en el log del servidor. Solo para desarrollo local: el log
+// incluye el token de restablecimiento en claro
+type LogNotifier struct{}
+
+// NewLogNotifier crea un notificador que escribe en el log
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// NotifyPasswordReset registra en el log el token de restablecimiento de contraseña
+func (n *LogNotifier) NotifyPasswordReset(ctx context.Context, notice domain.PasswordResetNotice) error {
+	log.Printf("restablecimiento de contraseña para %s (usuario %d): token %s, vence %s",
+		notice.Email, notice.UserID, notice.Token, notice.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
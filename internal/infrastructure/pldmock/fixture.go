@@ -0,0 +1,155 @@
+// Package pldmock implementa un servicio PLD simulado con el mismo contrato que el
+// proveedor remoto (POST /check-blacklist), para desarrollo local y pruebas sin red
+package pldmock
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"crabi-test/pkg/namematch"
+)
+
+//go:embed fixture.json
+var defaultFixture []byte
+
+// Identity describe una identidad del fixture. Los campos vacíos no se comparan, por lo
+// que una entrada puede identificar a la persona solo por su número de identificación
+type Identity struct {
+	IDNumber  string `json:"id_number,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+
+	// Reason documenta por qué la identidad está en la lista; no se envía al cliente
+	Reason string `json:"reason,omitempty"`
+}
+
+// Duration es una duración que se lee desde JSON en formato "250ms" o "2s"
+type Duration time.Duration
+
+// UnmarshalJSON interpreta la duración con time.ParseDuration
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duración inválida: %s", data)
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("duración inválida: %s", value)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON serializa la duración en el mismo formato que acepta UnmarshalJSON
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Fault describe una falla simulada del proveedor
+type Fault struct {
+	// Latency retrasa la respuesta
+	Latency Duration `json:"latency,omitempty"`
+
+	// StatusCode responde con ese código en lugar de 201
+	StatusCode int `json:"status_code,omitempty"`
+
+	// RetryAfter se envía en el encabezado Retry-After junto con StatusCode
+	RetryAfter string `json:"retry_after,omitempty"`
+
+	// Malformed responde 201 con un cuerpo que no es JSON válido
+	Malformed bool `json:"malformed,omitempty"`
+
+	// Times limita la falla a las siguientes N solicitudes (0 la mantiene hasta eliminarla)
+	Times int `json:"times,omitempty"`
+}
+
+// FaultRule aplica una falla solo a las solicitudes de una identidad
+type FaultRule struct {
+	Match Identity `json:"match"`
+	Fault
+}
+
+// Fixture contiene las identidades en lista negra y las fallas por identidad
+type Fixture struct {
+	Blacklist []Identity  `json:"blacklist"`
+	Faults    []FaultRule `json:"faults,omitempty"`
+}
+
+// DefaultFixture retorna el fixture incluido en el binario
+func DefaultFixture() *Fixture {
+	fixture, err := ParseFixture(defaultFixture)
+	if err != nil {
+		panic("fixture por defecto del mock PLD inválido: " + err.Error())
+	}
+	return fixture
+}
+
+// LoadFixture lee un fixture desde un archivo JSON
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo fixture: %w", err)
+	}
+	return ParseFixture(data)
+}
+
+// ParseFixture interpreta un fixture en formato JSON
+func ParseFixture(data []byte) (*Fixture, error) {
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("fixture inválido: %w", err)
+	}
+	return &fixture, nil
+}
+
+// matches indica si la identidad coincide con el payload recibido. Se ignoran
+// mayúsculas, acentos y separadores; una identidad sin campos no coincide con nada
+func (i Identity) matches(payload map[string]string) bool {
+	fields := []struct {
+		expected, actual string
+		normalize        func(string) string
+	}{
+		{i.IDNumber, payload["id_number"], normalizeIDNumber},
+		{i.FirstName, payload["first_name"], normalizeName},
+		{i.LastName, payload["last_name"], normalizeName},
+		{i.Email, payload["email"], normalizeEmail},
+	}
+
+	compared := false
+	for _, field := range fields {
+		if field.expected == "" {
+			continue
+		}
+		if field.normalize(field.expected) != field.normalize(field.actual) {
+			return false
+		}
+		compared = true
+	}
+	return compared
+}
+
+// normalizeIDNumber conserva solo letras y dígitos en mayúsculas
+func normalizeIDNumber(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, s)
+}
+
+// normalizeName elimina acentos, mayúsculas y espacios repetidos
+func normalizeName(s string) string {
+	return strings.Join(strings.Fields(namematch.Fold(s)), " ")
+}
+
+// normalizeEmail compara emails sin distinguir mayúsculas
+func normalizeEmail(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,217 @@
+package pldmock
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Server simula al proveedor PLD remoto. Además de /check-blacklist expone endpoints de
+// control bajo /__mock para inyectar fallas y consultar las solicitudes recibidas
+type Server struct {
+	mu       sync.Mutex
+	fixture  *Fixture
+	fault    *Fault
+	requests []map[string]string
+	mux      *http.ServeMux
+
+	// Logger registra cada solicitud atendida (nil desactiva el registro)
+	Logger *log.Logger
+}
+
+// NewServer crea un servidor simulado con el fixture indicado (nil usa el fixture por defecto)
+func NewServer(fixture *Fixture) *Server {
+	if fixture == nil {
+		fixture = DefaultFixture()
+	}
+
+	s := &Server{fixture: fixture, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /check-blacklist", s.checkBlacklist)
+	s.mux.HandleFunc("GET /health", s.health)
+	s.mux.HandleFunc("GET /__mock/requests", s.listRequests)
+	s.mux.HandleFunc("DELETE /__mock/requests", s.clearRequests)
+	s.mux.HandleFunc("PUT /__mock/fault", s.putFault)
+	s.mux.HandleFunc("DELETE /__mock/fault", s.deleteFault)
+	return s
+}
+
+// ServeHTTP implementa http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetFixture reemplaza las identidades en lista negra y las fallas por identidad
+func (s *Server) SetFixture(fixture *Fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixture = fixture
+}
+
+// SetFault aplica una falla a las siguientes solicitudes, sin importar la identidad
+func (s *Server) SetFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fault = &fault
+}
+
+// ClearFault elimina la falla global
+func (s *Server) ClearFault() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fault = nil
+}
+
+// Requests retorna los payloads recibidos en /check-blacklist, en orden de llegada
+func (s *Server) Requests() []map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]string(nil), s.requests...)
+}
+
+// checkBlacklist atiende la validación con el contrato del proveedor real: 201 y
+// {"is_in_blacklist": bool}
+func (s *Server) checkBlacklist(w http.ResponseWriter, r *http.Request) {
+	var payload map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "payload inválido"})
+		return
+	}
+
+	blacklisted, fault := s.evaluate(payload)
+	s.logf("check-blacklist id_number=%q blacklisted=%t fault=%+v", payload["id_number"], blacklisted, fault)
+
+	if fault != nil && fault.Latency > 0 {
+		if err := sleepContext(r.Context(), time.Duration(fault.Latency)); err != nil {
+			return
+		}
+	}
+
+	switch {
+	case fault != nil && fault.StatusCode != 0:
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeJSON(w, fault.StatusCode, map[string]string{"error": "falla simulada"})
+	case fault != nil && fault.Malformed:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"is_in_blacklist": tr`))
+	default:
+		writeJSON(w, http.StatusCreated, map[string]bool{"is_in_blacklist": blacklisted})
+	}
+}
+
+// evaluate registra la solicitud y determina si la identidad está en lista negra y qué
+// falla aplicar. Las fallas por identidad tienen prioridad sobre la falla global
+func (s *Server) evaluate(payload map[string]string) (bool, *Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, payload)
+
+	blacklisted := false
+	for _, identity := range s.fixture.Blacklist {
+		if identity.matches(payload) {
+			blacklisted = true
+			break
+		}
+	}
+
+	for i := range s.fixture.Faults {
+		if rule := &s.fixture.Faults[i]; rule.Match.matches(payload) {
+			return blacklisted, consume(&rule.Fault)
+		}
+	}
+
+	if s.fault == nil {
+		return blacklisted, nil
+	}
+	fault := consume(s.fault)
+	if s.fault.Times < 0 {
+		s.fault = nil
+	}
+	return blacklisted, fault
+}
+
+// consume retorna una copia de la falla y descuenta un uso si está limitada. Una falla
+// agotada queda con Times negativo
+func consume(fault *Fault) *Fault {
+	if fault.Times < 0 {
+		return nil
+	}
+	applied := *fault
+	if fault.Times > 0 {
+		fault.Times--
+		if fault.Times == 0 {
+			fault.Times = -1
+		}
+	}
+	return &applied
+}
+
+// health reporta que el mock está disponible
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+}
+
+// listRequests retorna los payloads recibidos
+func (s *Server) listRequests(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Requests())
+}
+
+// clearRequests descarta los payloads recibidos
+func (s *Server) clearRequests(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = nil
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// putFault configura la falla global
+func (s *Server) putFault(w http.ResponseWriter, r *http.Request) {
+	var fault Fault
+	if err := json.NewDecoder(r.Body).Decode(&fault); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	s.SetFault(fault)
+	s.logf("falla global configurada: %+v", fault)
+	writeJSON(w, http.StatusOK, fault)
+}
+
+// deleteFault elimina la falla global
+func (s *Server) deleteFault(w http.ResponseWriter, r *http.Request) {
+	s.ClearFault()
+	s.logf("falla global eliminada")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// logf registra un mensaje si el servidor tiene logger
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.Logger != nil {
+		s.Logger.Printf(format, args...)
+	}
+}
+
+// writeJSON escribe una respuesta JSON con el código indicado
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sleepContext espera la duración indicada o hasta que el cliente cancele la solicitud
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
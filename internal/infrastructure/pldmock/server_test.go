@@ -0,0 +1,135 @@
+package pldmock
+
+import (
+	"bytes"
+	"context"
+	"crabi-test/internal/domain"
+	"crabi-test/internal/infrastructure/external"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, server *Server, maxAttempts int) *external.PLDClient {
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	policy := external.DefaultRetryPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+
+	return external.NewPLDClientWithConfig(external.PLDClientConfig{
+		BaseURL:     httpServer.URL,
+		Timeout:     time.Second,
+		RetryPolicy: policy,
+	})
+}
+
+func TestServer_DefaultFixture(t *testing.T) {
+	server := NewServer(nil)
+	client := newTestClient(t, server, 1)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		request     domain.PLDRequest
+		blacklisted bool
+	}{
+		{"clean", domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"}, false},
+		{"id number", domain.PLDRequest{IDNumber: "0000-0001", Name: "Ana Ruiz", Email: "ana@email.com"}, true},
+		{"email", domain.PLDRequest{IDNumber: "12345678", Name: "Ana Ruiz", Email: "Blacklisted@Example.com"}, true},
+		{"full identity", domain.PLDRequest{IDNumber: "PARP700101HJCRMD01", GivenNames: "PEDRO", PaternalSurname: "Paramo", MaternalSurname: "Ruiz"}, true},
+		{"partial identity", domain.PLDRequest{IDNumber: "12345678", GivenNames: "Pedro", PaternalSurname: "Páramo", MaternalSurname: "Ruiz"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.ValidateUser(ctx, tt.request)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if response.IsBlacklisted != tt.blacklisted {
+				t.Errorf("Expected blacklisted=%t, got %t", tt.blacklisted, response.IsBlacklisted)
+			}
+		})
+	}
+
+	if requests := server.Requests(); len(requests) != len(tests) || requests[0]["first_name"] != "Juan" {
+		t.Errorf("Expected %d recorded requests, got %v", len(tests), requests)
+	}
+}
+
+func TestServer_FaultRulesByIdentity(t *testing.T) {
+	client := newTestClient(t, NewServer(nil), 1)
+	ctx := context.Background()
+
+	for _, idNumber := range []string{"00000503", "00000429"} {
+		if _, err := client.ValidateUser(ctx, domain.PLDRequest{IDNumber: idNumber, Name: "Juan Pérez"}); err == nil || !strings.Contains(err.Error(), idNumber[5:]) {
+			t.Errorf("Expected status error for %s, got %v", idNumber, err)
+		}
+	}
+
+	if _, err := client.ValidateUser(ctx, domain.PLDRequest{IDNumber: "00000422", Name: "Juan Pérez"}); err == nil || !strings.Contains(err.Error(), "decodificando") {
+		t.Errorf("Expected decoding error, got %v", err)
+	}
+
+	if _, err := client.ValidateUser(ctx, domain.PLDRequest{IDNumber: "00000408", Name: "Juan Pérez"}); err == nil {
+		t.Error("Expected timeout error")
+	}
+}
+
+func TestServer_GlobalFaultWithTimes(t *testing.T) {
+	server := NewServer(&Fixture{})
+	server.SetFault(Fault{StatusCode: http.StatusServiceUnavailable, Times: 2})
+	client := newTestClient(t, server, 3)
+
+	response, err := client.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez"})
+	if err != nil {
+		t.Fatalf("Expected retries to recover, got %v", err)
+	}
+	if response.IsBlacklisted {
+		t.Error("Expected clean response")
+	}
+	if len(server.Requests()) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(server.Requests()))
+	}
+}
+
+func TestServer_ControlEndpoints(t *testing.T) {
+	server := NewServer(&Fixture{})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	request, _ := http.NewRequest(http.MethodPut, httpServer.URL+"/__mock/fault", strings.NewReader(`{"latency": "1ms", "status_code": 500}`))
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected fault to be configured, got %v (%v)", resp, err)
+	}
+
+	resp, _ = http.Post(httpServer.URL+"/check-blacklist", "application/json", bytes.NewBufferString(`{"id_number": "12345678"}`))
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected injected 500, got %d", resp.StatusCode)
+	}
+
+	request, _ = http.NewRequest(http.MethodDelete, httpServer.URL+"/__mock/fault", nil)
+	http.DefaultClient.Do(request)
+
+	resp, _ = http.Post(httpServer.URL+"/check-blacklist", "application/json", bytes.NewBufferString(`{"id_number": "12345678"}`))
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Expected 201 after clearing fault, got %d", resp.StatusCode)
+	}
+
+	resp, _ = http.Post(httpServer.URL+"/check-blacklist", "application/json", bytes.NewBufferString(`no es json`))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid payload, got %d", resp.StatusCode)
+	}
+}
+
+func TestParseFixture_InvalidLatency(t *testing.T) {
+	if _, err := ParseFixture([]byte(`{"faults": [{"match": {"id_number": "1"}, "latency": "pronto"}]}`)); err == nil {
+		t.Error("Expected error for invalid latency")
+	}
+}
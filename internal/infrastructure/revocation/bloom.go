@@ -0,0 +1,63 @@
+package revocation
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// bloomFilter responde si una clave puede estar en el conjunto. Nunca da falsos negativos,
+// por lo que un "no" evita consultar la base de datos
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// newBloomFilter dimensiona el filtro para capacity claves con la tasa de falsos positivos indicada
+func newBloomFilter(capacity int, falsePositiveRate float64) *bloomFilter {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	n := float64(capacity)
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Round(float64(size) / n * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// add agrega una clave al filtro
+func (b *bloomFilter) add(key string) {
+	h1, h2 := bloomHashes(key)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// mayContain indica si la clave pudo agregarse al filtro
+func (b *bloomFilter) mayContain(key string) bool {
+	h1, h2 := bloomHashes(key)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes obtiene los dos hashes base del doble hashing
+func bloomHashes(key string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	return sum, (sum >> 32) | 1
+}
@@ -0,0 +1,237 @@
+package revocation
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// bloomFalsePositiveRate es la proporción de tokens vigentes que igual se consultan en la base
+const bloomFalsePositiveRate = 0.01
+
+// CacheConfig define el tamaño de la caché en memoria de revocaciones
+type CacheConfig struct {
+	// Size es la cantidad de resultados por token y por usuario que se mantienen en memoria
+	Size int
+	// BloomCapacity es la cantidad de revocaciones vigentes para la que se dimensiona el filtro
+	BloomCapacity int
+}
+
+// DefaultCacheConfig retorna la configuración por defecto de la caché
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		Size:          10000,
+		BloomCapacity: 100000,
+	}
+}
+
+// CacheConfigFromEnv construye la configuración de la caché a partir de variables de entorno
+func CacheConfigFromEnv() CacheConfig {
+	config := DefaultCacheConfig()
+
+	if v, err := strconv.Atoi(os.Getenv("TOKEN_REVOCATION_CACHE_SIZE")); err == nil && v >= 0 {
+		config.Size = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("TOKEN_REVOCATION_BLOOM_CAPACITY")); err == nil && v > 0 {
+		config.BloomCapacity = v
+	}
+
+	return config
+}
+
+// CachedRepository envuelve un ports.TokenRevocationRepository para que validar un token no
+// consulte la base en cada solicitud. Un filtro de Bloom con los jti revocados descarta sin
+// consultas los tokens que no fueron revocados, y una caché LRU guarda el resultado de los
+// demás y las revocaciones por usuario. Asume que todas las revocaciones pasan por esta
+// instancia, como ocurre con una sola base SQLite por servidor
+type CachedRepository struct {
+	next   ports.TokenRevocationRepository
+	config CacheConfig
+	now    func() time.Time
+
+	mu     sync.Mutex
+	bloom  *bloomFilter
+	tokens *lruCache[string, bool]
+	users  *lruCache[uint, *domain.UserTokenRevocation]
+	// generation cambia con cada revocación para no cachear lecturas previas a ella
+	generation uint64
+	// pending guarda los jti revocados mientras se reconstruye el filtro
+	rebuilding bool
+	pending    []string
+}
+
+// NewCachedRepository crea la caché y carga en el filtro las revocaciones vigentes
+func NewCachedRepository(ctx context.Context, next ports.TokenRevocationRepository, config CacheConfig) (*CachedRepository, error) {
+	c := &CachedRepository{
+		next:   next,
+		config: config,
+		now:    time.Now,
+		tokens: newLRUCache[string, bool](config.Size),
+		users:  newLRUCache[uint, *domain.UserTokenRevocation](config.Size),
+	}
+
+	if err := c.rebuildBloom(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// RevokeToken registra la revocación y la agrega a la caché
+func (c *CachedRepository) RevokeToken(ctx context.Context, revocation *domain.TokenRevocation) error {
+	if err := c.next.RevokeToken(ctx, revocation); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.bloom.add(revocation.JTI)
+	c.tokens.put(revocation.JTI, true)
+	if c.rebuilding {
+		c.pending = append(c.pending, revocation.JTI)
+	}
+	return nil
+}
+
+// IsTokenRevoked responde desde el filtro o la caché, y consulta la base solo si no puede
+func (c *CachedRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	c.mu.Lock()
+	if !c.bloom.mayContain(jti) {
+		c.mu.Unlock()
+		return false, nil
+	}
+	if revoked, ok := c.tokens.get(jti); ok {
+		c.mu.Unlock()
+		return revoked, nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	revoked, err := c.next.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.tokens.put(jti, revoked)
+	}
+	c.mu.Unlock()
+	return revoked, nil
+}
+
+// ListRevokedTokenIDs consulta directamente el repositorio
+func (c *CachedRepository) ListRevokedTokenIDs(ctx context.Context, now time.Time) ([]string, error) {
+	return c.next.ListRevokedTokenIDs(ctx, now)
+}
+
+// RevokeUserTokens registra la revocación del usuario y la agrega a la caché
+func (c *CachedRepository) RevokeUserTokens(ctx context.Context, revocation *domain.UserTokenRevocation) error {
+	if err := c.next.RevokeUserTokens(ctx, revocation); err != nil {
+		return err
+	}
+
+	cached := *revocation
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.users.put(revocation.UserID, &cached)
+	return nil
+}
+
+// GetUserTokenRevocation responde desde la caché, incluyendo a los usuarios sin revocación
+func (c *CachedRepository) GetUserTokenRevocation(ctx context.Context, userID uint) (*domain.UserTokenRevocation, error) {
+	c.mu.Lock()
+	if revocation, ok := c.users.get(userID); ok {
+		c.mu.Unlock()
+		return revocation, nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	revocation, err := c.next.GetUserTokenRevocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.users.put(userID, revocation)
+	}
+	c.mu.Unlock()
+	return revocation, nil
+}
+
+// DeleteExpired elimina las revocaciones vencidas y reconstruye el filtro sin ellas
+func (c *CachedRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := c.next.DeleteExpired(ctx, now)
+	if err != nil {
+		return deleted, err
+	}
+	return deleted, c.rebuildBloom(ctx)
+}
+
+// StartPurge elimina periódicamente las revocaciones vencidas hasta que se cancele ctx
+func (c *CachedRepository) StartPurge(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := c.DeleteExpired(ctx, c.now())
+				if err != nil {
+					log.Printf("error depurando revocaciones de tokens: %v", err)
+				} else if deleted > 0 {
+					log.Printf("revocaciones de tokens vencidas eliminadas: %d", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// rebuildBloom carga en un filtro nuevo los jti de las revocaciones vigentes. El filtro se
+// dimensiona con holgura sobre las revocaciones actuales para las que se agreguen después
+func (c *CachedRepository) rebuildBloom(ctx context.Context) error {
+	c.mu.Lock()
+	c.rebuilding = true
+	c.pending = nil
+	c.mu.Unlock()
+
+	ids, err := c.next.ListRevokedTokenIDs(ctx, c.now())
+	if err != nil {
+		c.mu.Lock()
+		c.rebuilding = false
+		c.pending = nil
+		c.mu.Unlock()
+		return err
+	}
+
+	capacity := c.config.BloomCapacity
+	if 2*len(ids) > capacity {
+		capacity = 2 * len(ids)
+	}
+	bloom := newBloomFilter(capacity, bloomFalsePositiveRate)
+	for _, jti := range ids {
+		bloom.add(jti)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Las revocaciones registradas durante la consulta pueden no estar en la lista
+	for _, jti := range c.pending {
+		bloom.add(jti)
+	}
+	c.bloom = bloom
+	c.rebuilding = false
+	c.pending = nil
+	return nil
+}
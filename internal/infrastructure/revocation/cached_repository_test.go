@@ -0,0 +1,190 @@
+package revocation
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingRepository guarda las revocaciones en memoria y cuenta las consultas
+type countingRepository struct {
+	mu      sync.Mutex
+	tokens  map[string]domain.TokenRevocation
+	users   map[uint]domain.UserTokenRevocation
+	lookups int
+}
+
+func newCountingRepository() *countingRepository {
+	return &countingRepository{
+		tokens: make(map[string]domain.TokenRevocation),
+		users:  make(map[uint]domain.UserTokenRevocation),
+	}
+}
+
+func (r *countingRepository) RevokeToken(ctx context.Context, revocation *domain.TokenRevocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[revocation.JTI] = *revocation
+	return nil
+}
+
+func (r *countingRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	_, ok := r.tokens[jti]
+	return ok, nil
+}
+
+func (r *countingRepository) ListRevokedTokenIDs(ctx context.Context, now time.Time) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []string
+	for jti, revocation := range r.tokens {
+		if revocation.ExpiresAt.After(now) {
+			ids = append(ids, jti)
+		}
+	}
+	return ids, nil
+}
+
+func (r *countingRepository) RevokeUserTokens(ctx context.Context, revocation *domain.UserTokenRevocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[revocation.UserID] = *revocation
+	return nil
+}
+
+func (r *countingRepository) GetUserTokenRevocation(ctx context.Context, userID uint) (*domain.UserTokenRevocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	revocation, ok := r.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &revocation, nil
+}
+
+func (r *countingRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for jti, revocation := range r.tokens {
+		if !revocation.ExpiresAt.After(now) {
+			delete(r.tokens, jti)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestCachedRepository_TokenRevocations(t *testing.T) {
+	ctx := context.Background()
+	repo := newCountingRepository()
+	now := time.Now()
+	repo.tokens["previo"] = domain.TokenRevocation{JTI: "previo", ExpiresAt: now.Add(time.Hour)}
+
+	cache, err := NewCachedRepository(ctx, repo, DefaultCacheConfig())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Las revocaciones existentes se cargan al crear la caché
+	if revoked, _ := cache.IsTokenRevoked(ctx, "previo"); !revoked {
+		t.Error("Expected the stored revocation to be loaded")
+	}
+
+	// Los tokens no revocados se descartan con el filtro, sin consultar el repositorio
+	repo.lookups = 0
+	for i := 0; i < 100; i++ {
+		if revoked, _ := cache.IsTokenRevoked(ctx, fmt.Sprintf("vigente-%d", i)); revoked {
+			t.Fatalf("Expected token %d not to be revoked", i)
+		}
+	}
+	if repo.lookups > 5 {
+		t.Errorf("Expected the bloom filter to avoid most lookups, got %d", repo.lookups)
+	}
+
+	if err := cache.RevokeToken(ctx, &domain.TokenRevocation{JTI: "nuevo", ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if revoked, _ := cache.IsTokenRevoked(ctx, "nuevo"); !revoked {
+		t.Error("Expected the new revocation to be cached")
+	}
+}
+
+func TestCachedRepository_UserRevocations(t *testing.T) {
+	ctx := context.Background()
+	repo := newCountingRepository()
+	cache, err := NewCachedRepository(ctx, repo, DefaultCacheConfig())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// La ausencia de revocación también se cachea
+	for i := 0; i < 3; i++ {
+		if revocation, _ := cache.GetUserTokenRevocation(ctx, 1); revocation != nil {
+			t.Fatalf("Expected no revocation, got %+v", revocation)
+		}
+	}
+	if repo.lookups != 1 {
+		t.Errorf("Expected a single lookup, got %d", repo.lookups)
+	}
+
+	now := time.Now()
+	if err := cache.RevokeUserTokens(ctx, &domain.UserTokenRevocation{UserID: 1, RevokedBefore: now, ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if revocation, _ := cache.GetUserTokenRevocation(ctx, 1); revocation == nil || !revocation.RevokedBefore.Equal(now) {
+		t.Errorf("Expected the new revocation, got %+v", revocation)
+	}
+}
+
+func TestCachedRepository_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := newCountingRepository()
+	cache, err := NewCachedRepository(ctx, repo, DefaultCacheConfig())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	now := time.Now()
+	cache.RevokeToken(ctx, &domain.TokenRevocation{JTI: "vencido", ExpiresAt: now.Add(-time.Minute)})
+	cache.RevokeToken(ctx, &domain.TokenRevocation{JTI: "vigente", ExpiresAt: now.Add(time.Hour)})
+
+	if deleted, err := cache.DeleteExpired(ctx, now); err != nil || deleted != 1 {
+		t.Fatalf("Expected 1 expired revocation deleted, got %d (%v)", deleted, err)
+	}
+	if revoked, _ := cache.IsTokenRevoked(ctx, "vigente"); !revoked {
+		t.Error("Expected the active revocation to survive the rebuild")
+	}
+	if cache.bloom.mayContain("vencido") {
+		t.Error("Expected the expired revocation to leave the filter")
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	bloom := newBloomFilter(1000, bloomFalsePositiveRate)
+	for i := 0; i < 1000; i++ {
+		bloom.add(fmt.Sprintf("jti-%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !bloom.mayContain(fmt.Sprintf("jti-%d", i)) {
+			t.Fatalf("Expected jti-%d to be in the filter", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if bloom.mayContain(fmt.Sprintf("otro-%d", i)) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 300 {
+		t.Errorf("Expected about 1%% false positives, got %d of 10000", falsePositives)
+	}
+}
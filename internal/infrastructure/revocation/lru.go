@@ -0,0 +1,54 @@
+package revocation
+
+import "container/list"
+
+// lruCache guarda hasta capacity entradas y descarta la usada hace más tiempo. No es seguro
+// para uso concurrente; CachedRepository lo protege con su mutex
+type lruCache[K comparable, V any] struct {
+	capacity int
+	order    *list.List
+	entries  map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](capacity int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[K]*list.Element),
+	}
+}
+
+// get retorna el valor de la clave y la marca como usada recientemente
+func (c *lruCache[K, V]) get(key K) (V, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// put guarda el valor de la clave, descartando la entrada más antigua si se excede la capacidad
+func (c *lruCache[K, V]) put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
@@ -0,0 +1,77 @@
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrListUnchanged se retorna cuando el archivo es idéntico a la versión vigente de la lista
+var ErrListUnchanged = errors.New("la lista ya está importada con el mismo contenido")
+
+// Importer carga archivos de listas de sanciones en el repositorio, versionando cada importación
+type Importer struct {
+	repo ports.WatchlistRepository
+	now  func() time.Time
+}
+
+// NewImporter crea una nueva instancia del importador de listas
+func NewImporter(repo ports.WatchlistRepository) *Importer {
+	return &Importer{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Import interpreta el archivo de la lista indicada y lo registra como nueva versión.
+// Si version está vacío se usa la fecha de publicación declarada en el archivo o, en su
+// defecto, la fecha de importación
+func (i *Importer) Import(ctx context.Context, source, version string, data []byte) (*domain.WatchlistVersion, error) {
+	entries, published, err := Parse(source, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error interpretando lista %s: %w", source, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("la lista %s no contiene entradas", source)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	active, err := i.repo.ListActiveVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, current := range active {
+		if current.Source == source && current.Checksum == checksum {
+			return current, ErrListUnchanged
+		}
+	}
+
+	now := i.now()
+	if version == "" {
+		version = published
+	}
+	if version == "" {
+		version = now.UTC().Format("2006-01-02")
+	}
+
+	imported := &domain.WatchlistVersion{
+		Source:     source,
+		Version:    version,
+		Checksum:   checksum,
+		EntryCount: len(entries),
+		ImportedAt: now,
+	}
+	if err := i.repo.ReplaceList(ctx, imported, entries); err != nil {
+		return nil, err
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,133 @@
+package watchlist
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LocalProviderName identifica al proveedor de listas locales en el historial de screenings
+const LocalProviderName = "local-watchlist"
+
+// Umbrales de similitud por defecto: desde DefaultMatchThreshold la coincidencia es
+// concluyente y desde DefaultReviewThreshold requiere revisión manual
+const (
+	DefaultMatchThreshold  = 0.88
+	DefaultReviewThreshold = 0.75
+)
+
+// ErrNoWatchlists se retorna cuando no hay listas de sanciones cargadas
+var ErrNoWatchlists = errors.New("no hay listas de sanciones cargadas")
+
+// MatchThresholdFromEnv obtiene el umbral de similitud de PLD_MATCH_THRESHOLD
+func MatchThresholdFromEnv() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("PLD_MATCH_THRESHOLD"), 64); err == nil && v > 0 && v <= 1 {
+		return v
+	}
+	return DefaultMatchThreshold
+}
+
+// ReviewThresholdFromEnv obtiene el umbral de revisión manual de PLD_REVIEW_THRESHOLD
+func ReviewThresholdFromEnv() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("PLD_REVIEW_THRESHOLD"), 64); err == nil && v > 0 && v <= 1 {
+		return v
+	}
+	return DefaultReviewThreshold
+}
+
+// LocalPLDService implementa ports.PLDService contra las listas de sanciones cargadas en memoria
+type LocalPLDService struct {
+	store           *Store
+	threshold       float64
+	reviewThreshold float64
+}
+
+// NewLocalPLDService crea un proveedor PLD sobre el almacén de listas indicado. Un usuario
+// se considera en lista negra cuando la mejor coincidencia alcanza threshold y requiere
+// revisión manual cuando queda entre reviewThreshold y threshold
+func NewLocalPLDService(store *Store, threshold, reviewThreshold float64) *LocalPLDService {
+	if reviewThreshold <= 0 || reviewThreshold > threshold {
+		reviewThreshold = threshold
+	}
+
+	return &LocalPLDService{
+		store:           store,
+		threshold:       threshold,
+		reviewThreshold: reviewThreshold,
+	}
+}
+
+// localResponse es el detalle que se registra como respuesta cruda del proveedor
+type localResponse struct {
+	IsBlacklisted   bool                       `json:"is_in_blacklist"`
+	Threshold       float64                    `json:"threshold"`
+	ReviewThreshold float64                    `json:"review_threshold"`
+	Matches         []Match                    `json:"matches"`
+	Versions        []*domain.WatchlistVersion `json:"versions"`
+}
+
+// ValidateUser valida un usuario contra las listas de sanciones locales
+func (s *LocalPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !s.store.Loaded() {
+		return nil, ErrNoWatchlists
+	}
+
+	matches := s.store.Match(request.IDNumber, request.FullName(), s.reviewThreshold)
+	blacklisted := len(matches) > 0 && matches[0].Score >= s.threshold
+
+	payload, _ := json.Marshal(request)
+	raw, _ := json.Marshal(localResponse{
+		IsBlacklisted:   blacklisted,
+		Threshold:       s.threshold,
+		ReviewThreshold: s.reviewThreshold,
+		Matches:         matches,
+		Versions:        s.store.Versions(),
+	})
+
+	response := &domain.PLDResponse{
+		Status:         domain.ScreeningStatusClean,
+		Provider:       LocalProviderName,
+		RequestPayload: string(payload),
+		RawResponse:    string(raw),
+	}
+
+	for _, match := range matches {
+		response.Matches = append(response.Matches, domain.PLDMatch{
+			Source:      match.Entry.Source,
+			ExternalID:  match.Entry.ExternalID,
+			Name:        match.Entry.Name,
+			MatchedName: match.MatchedValue,
+			MatchedOn:   match.MatchedOn,
+			Score:       match.Score,
+			Program:     match.Entry.Program,
+		})
+	}
+
+	if len(matches) > 0 {
+		best := matches[0]
+		detail := fmt.Sprintf("lista %s: %s", best.Entry.Source, best.Entry.Name)
+		if best.Entry.Program != "" {
+			detail += " (" + best.Entry.Program + ")"
+		}
+		response.MatchScore = best.Score
+
+		if blacklisted {
+			response.IsBlacklisted = true
+			response.Status = domain.ScreeningStatusBlacklisted
+			response.Reason = "Coincidencia en " + detail
+		} else {
+			// Una coincidencia bajo el umbral no es concluyente y se envía a revisión manual
+			response.Status = domain.ScreeningStatusReview
+			response.Reason = fmt.Sprintf("Posible coincidencia en %s, similitud %.2f", detail, best.Score)
+		}
+	}
+
+	return response, nil
+}
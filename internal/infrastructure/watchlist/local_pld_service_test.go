@@ -0,0 +1,199 @@
+package watchlist
+
+import (
+	"context"
+	"crabi-test/internal/domain"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// MockWatchlistRepository para testing
+type MockWatchlistRepository struct {
+	versions []*domain.WatchlistVersion
+	entries  map[string][]*domain.WatchlistEntry
+}
+
+func NewMockWatchlistRepository() *MockWatchlistRepository {
+	return &MockWatchlistRepository{entries: make(map[string][]*domain.WatchlistEntry)}
+}
+
+func (m *MockWatchlistRepository) ReplaceList(ctx context.Context, version *domain.WatchlistVersion, entries []*domain.WatchlistEntry) error {
+	version.ID = uint(len(m.versions) + 1)
+	m.versions = append(m.versions, version)
+	for _, entry := range entries {
+		entry.Source = version.Source
+		entry.VersionID = version.ID
+	}
+	m.entries[version.Source] = entries
+	return nil
+}
+
+func (m *MockWatchlistRepository) ListActiveVersions(ctx context.Context) ([]*domain.WatchlistVersion, error) {
+	active := make(map[string]*domain.WatchlistVersion)
+	for _, version := range m.versions {
+		active[version.Source] = version
+	}
+	var result []*domain.WatchlistVersion
+	for _, version := range active {
+		result = append(result, version)
+	}
+	return result, nil
+}
+
+func (m *MockWatchlistRepository) ListEntries(ctx context.Context) ([]*domain.WatchlistEntry, error) {
+	var result []*domain.WatchlistEntry
+	for _, entries := range m.entries {
+		result = append(result, entries...)
+	}
+	return result, nil
+}
+
+func importTestFile(t *testing.T, importer *Importer, source, path string) *domain.WatchlistVersion {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error leyendo %s: %v", path, err)
+	}
+	version, err := importer.Import(context.Background(), source, "", data)
+	if err != nil {
+		t.Fatalf("Expected no error importing %s, got %v", path, err)
+	}
+	return version
+}
+
+func TestImporter_VersionsAndSkipsUnchangedFiles(t *testing.T) {
+	repo := NewMockWatchlistRepository()
+	importer := NewImporter(repo)
+
+	version := importTestFile(t, importer, domain.WatchlistSourceUN, "testdata/consolidated.xml")
+	if version.Version != "2024-03-01T00:00:00.000Z" || version.EntryCount != 2 || len(version.Checksum) != 64 {
+		t.Errorf("Unexpected version %+v", version)
+	}
+
+	data, _ := os.ReadFile("testdata/consolidated.xml")
+	if _, err := importer.Import(context.Background(), domain.WatchlistSourceUN, "", data); !errors.Is(err, ErrListUnchanged) {
+		t.Errorf("Expected ErrListUnchanged, got %v", err)
+	}
+
+	if _, err := importer.Import(context.Background(), domain.WatchlistSourceUIF, "", []byte("id,nombre\n")); err == nil {
+		t.Error("Expected error for empty list")
+	}
+}
+
+func TestLocalPLDService_ValidateUser(t *testing.T) {
+	repo := NewMockWatchlistRepository()
+	importer := NewImporter(repo)
+	importTestFile(t, importer, domain.WatchlistSourceOFAC, "testdata/sdn.csv")
+	importTestFile(t, importer, domain.WatchlistSourceUIF, "testdata/lpb.csv")
+
+	store := NewStore()
+	service := NewLocalPLDService(store, DefaultMatchThreshold, DefaultMatchThreshold)
+
+	if _, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"}); !errors.Is(err, ErrNoWatchlists) {
+		t.Fatalf("Expected ErrNoWatchlists before loading, got %v", err)
+	}
+
+	if err := store.Reload(context.Background(), repo); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		idNumber    string
+		fullName    string
+		blacklisted bool
+		source      string
+		minScore    float64
+	}{
+		{"coincidencia por nombre sin acentos ni orden", "00000000", "Joaquín Guzmán Loera", true, domain.WatchlistSourceOFAC, 1},
+		{"coincidencia con error de captura", "00000000", "Joaqin Guzman Lohera", true, domain.WatchlistSourceOFAC, DefaultMatchThreshold},
+		{"coincidencia sin segundo apellido", "00000000", "Maria Fernanda Lopez", true, domain.WatchlistSourceUIF, DefaultMatchThreshold},
+		{"coincidencia por alias", "00000000", "la guera", true, domain.WatchlistSourceUIF, 1},
+		{"coincidencia por CURP", "lonm800101mdfpxr09", "Otra Persona", true, domain.WatchlistSourceUIF, 1},
+		{"solo un apellido no alcanza el umbral", "00000000", "Guzmán", false, "", 0},
+		{"sin coincidencia", "12345678", "Juan Pérez", false, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: tt.idNumber, Name: tt.fullName, Email: "test@email.com"})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if response.IsBlacklisted != tt.blacklisted {
+				t.Errorf("Expected blacklisted=%v, got %+v", tt.blacklisted, response)
+			}
+			if tt.blacklisted && !strings.Contains(response.Reason, tt.source) {
+				t.Errorf("Expected reason to mention %s, got %q", tt.source, response.Reason)
+			}
+			if tt.blacklisted && (response.MatchScore < tt.minScore || len(response.Matches) == 0 || response.Matches[0].Score != response.MatchScore) {
+				t.Errorf("Expected best match with score >= %.2f, got %.3f and %+v", tt.minScore, response.MatchScore, response.Matches)
+			}
+			if !tt.blacklisted && (response.MatchScore != 0 || len(response.Matches) != 0) {
+				t.Errorf("Expected no matches, got %+v", response.Matches)
+			}
+			if response.Provider != LocalProviderName || response.RawResponse == "" || response.RequestPayload == "" {
+				t.Errorf("Expected audit data from the local provider, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestStore_MatchThreshold(t *testing.T) {
+	store := NewStore()
+	store.Load([]*domain.WatchlistVersion{{ID: 1}}, []*domain.WatchlistEntry{
+		{ExternalID: "1", Name: "Jorge Vásquez Jiménez"},
+		{ExternalID: "2", Name: "Jorge Vargas"},
+	})
+
+	matches := store.Match("", "Jorge Basques Ximenes", 0.5)
+	if len(matches) != 2 || matches[0].Entry.ExternalID != "1" || matches[0].Score <= matches[1].Score {
+		t.Fatalf("Expected both candidates ordered by score, got %+v", matches)
+	}
+
+	if matches := store.Match("", "Jorge Basques Ximenes", 0.95); len(matches) != 1 {
+		t.Errorf("Expected only the transliterated name above 0.95, got %+v", matches)
+	}
+}
+
+func TestLocalPLDService_ValidateUser_ReviewBand(t *testing.T) {
+	store := NewStore()
+	store.Load([]*domain.WatchlistVersion{{ID: 1, Source: domain.WatchlistSourceUIF}}, []*domain.WatchlistEntry{
+		{Source: domain.WatchlistSourceUIF, ExternalID: "1", Name: "Jorge Vargas"},
+	})
+	service := NewLocalPLDService(store, 0.95, 0.5)
+
+	response, err := service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "00000000", Name: "Jorge Basques Ximenes", Email: "jorge@email.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.IsBlacklisted || response.Status != domain.ScreeningStatusReview {
+		t.Fatalf("Expected an inconclusive match to be sent to review, got %+v", response)
+	}
+	if response.MatchScore < 0.5 || response.MatchScore >= 0.95 || !strings.Contains(response.Reason, "Posible coincidencia") {
+		t.Errorf("Unexpected review response %+v", response)
+	}
+
+	// Un umbral de revisión inválido deshabilita la banda de revisión
+	service = NewLocalPLDService(store, 0.95, 2)
+	response, _ = service.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "00000000", Name: "Jorge Basques Ximenes", Email: "jorge@email.com"})
+	if response.Status != domain.ScreeningStatusClean {
+		t.Errorf("Expected clean status without review band, got %+v", response)
+	}
+}
+
+func TestStore_FingerprintChangesWithVersions(t *testing.T) {
+	store := NewStore()
+	store.Load([]*domain.WatchlistVersion{{ID: 3}, {ID: 1}}, nil)
+	if fingerprint := store.Fingerprint(); fingerprint != "1,3" {
+		t.Errorf("Expected fingerprint 1,3, got %q", fingerprint)
+	}
+
+	store.Load([]*domain.WatchlistVersion{{ID: 1}, {ID: 4}}, nil)
+	if fingerprint := store.Fingerprint(); fingerprint != "1,4" {
+		t.Errorf("Expected fingerprint 1,4, got %q", fingerprint)
+	}
+}
@@ -0,0 +1,17 @@
+package watchlist
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeIDNumber convierte un número de identificación a mayúsculas y sin separadores
+func NormalizeIDNumber(idNumber string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(idNumber) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
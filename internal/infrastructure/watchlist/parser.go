@@ -0,0 +1,276 @@
+package watchlist
+
+import (
+	"crabi-test/internal/domain"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ofacNull es el marcador de valor vacío del archivo SDN de OFAC
+const ofacNull = "-0-"
+
+var (
+	// ofacAKA extrae los alias declarados en las observaciones del SDN
+	ofacAKA = regexp.MustCompile(`a\.k\.a\. '([^']+)'`)
+	// ofacIDNumber extrae identificaciones mexicanas y pasaportes de las observaciones del SDN
+	ofacIDNumber = regexp.MustCompile(`(?:R\.F\.C\.|C\.U\.R\.P\.|Passport|National ID No\.|Cedula No\.)\s+([A-Za-z0-9-]+)`)
+)
+
+// Parse interpreta el archivo de la lista indicada. Retorna las entradas y, si el archivo
+// la declara, la fecha de publicación de la lista
+func Parse(source string, r io.Reader) ([]*domain.WatchlistEntry, string, error) {
+	switch source {
+	case domain.WatchlistSourceOFAC:
+		entries, err := ParseOFACSDN(r)
+		return entries, "", err
+	case domain.WatchlistSourceUN:
+		return ParseUNConsolidated(r)
+	case domain.WatchlistSourceUIF:
+		entries, err := ParseUIF(r)
+		return entries, "", err
+	default:
+		return nil, "", fmt.Errorf("lista de sanciones no soportada: %s", source)
+	}
+}
+
+// ParseOFACSDN interpreta el archivo sdn.csv de OFAC (sin encabezado):
+// ent_num, SDN_Name, SDN_Type, Program, Title, Call_Sign, Vess_type, Tonnage, GRT,
+// Vess_flag, Vess_owner, Remarks
+func ParseOFACSDN(r io.Reader) ([]*domain.WatchlistEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	entries := []*domain.WatchlistEntry{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: %w", line, err)
+		}
+		// El archivo oficial termina con un carácter de control en la última línea
+		if len(record) == 1 && strings.TrimSpace(strings.Trim(record[0], "\x1a")) == "" {
+			continue
+		}
+		if len(record) < 4 {
+			return nil, fmt.Errorf("línea %d: se esperaban al menos 4 columnas", line)
+		}
+
+		entry := &domain.WatchlistEntry{
+			ExternalID: ofacValue(record[0]),
+			Name:       ofacValue(record[1]),
+			Program:    ofacValue(record[3]),
+		}
+		if entry.Name == "" {
+			return nil, fmt.Errorf("línea %d: nombre vacío", line)
+		}
+
+		if len(record) > 11 {
+			remarks := ofacValue(record[11])
+			for _, match := range ofacAKA.FindAllStringSubmatch(remarks, -1) {
+				entry.Aliases = append(entry.Aliases, match[1])
+			}
+			for _, match := range ofacIDNumber.FindAllStringSubmatch(remarks, -1) {
+				entry.IDNumbers = append(entry.IDNumbers, match[1])
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ofacValue limpia un campo del SDN
+func ofacValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == ofacNull {
+		return ""
+	}
+	return value
+}
+
+// unConsolidatedList refleja la estructura XML de la lista consolidada del Consejo de Seguridad de la ONU
+type unConsolidatedList struct {
+	DateGenerated string         `xml:"dateGenerated,attr"`
+	Individuals   []unIndividual `xml:"INDIVIDUALS>INDIVIDUAL"`
+	Entities      []unEntity     `xml:"ENTITIES>ENTITY"`
+}
+
+type unIndividual struct {
+	DataID          string       `xml:"DATAID"`
+	FirstName       string       `xml:"FIRST_NAME"`
+	SecondName      string       `xml:"SECOND_NAME"`
+	ThirdName       string       `xml:"THIRD_NAME"`
+	FourthName      string       `xml:"FOURTH_NAME"`
+	ListType        string       `xml:"UN_LIST_TYPE"`
+	ReferenceNumber string       `xml:"REFERENCE_NUMBER"`
+	Aliases         []unAlias    `xml:"INDIVIDUAL_ALIAS"`
+	Documents       []unDocument `xml:"INDIVIDUAL_DOCUMENT"`
+}
+
+type unEntity struct {
+	DataID          string    `xml:"DATAID"`
+	FirstName       string    `xml:"FIRST_NAME"`
+	ListType        string    `xml:"UN_LIST_TYPE"`
+	ReferenceNumber string    `xml:"REFERENCE_NUMBER"`
+	Aliases         []unAlias `xml:"ENTITY_ALIAS"`
+}
+
+type unAlias struct {
+	Name string `xml:"ALIAS_NAME"`
+}
+
+type unDocument struct {
+	Number string `xml:"NUMBER"`
+}
+
+// ParseUNConsolidated interpreta la lista consolidada de la ONU en formato XML
+func ParseUNConsolidated(r io.Reader) ([]*domain.WatchlistEntry, string, error) {
+	var list unConsolidatedList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, "", fmt.Errorf("XML inválido: %w", err)
+	}
+
+	entries := []*domain.WatchlistEntry{}
+	for _, individual := range list.Individuals {
+		entry := &domain.WatchlistEntry{
+			ExternalID: strings.TrimSpace(individual.DataID),
+			Name:       joinNonEmpty(individual.FirstName, individual.SecondName, individual.ThirdName, individual.FourthName),
+			Program:    unProgram(individual.ListType, individual.ReferenceNumber),
+			Aliases:    unAliasNames(individual.Aliases),
+		}
+		for _, document := range individual.Documents {
+			if number := strings.TrimSpace(document.Number); number != "" {
+				entry.IDNumbers = append(entry.IDNumbers, number)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	for _, entity := range list.Entities {
+		entries = append(entries, &domain.WatchlistEntry{
+			ExternalID: strings.TrimSpace(entity.DataID),
+			Name:       strings.TrimSpace(entity.FirstName),
+			Program:    unProgram(entity.ListType, entity.ReferenceNumber),
+			Aliases:    unAliasNames(entity.Aliases),
+		})
+	}
+
+	for _, entry := range entries {
+		if entry.Name == "" {
+			return nil, "", fmt.Errorf("registro %s sin nombre", entry.ExternalID)
+		}
+	}
+
+	return entries, strings.TrimSpace(list.DateGenerated), nil
+}
+
+// unProgram combina el tipo de lista y la referencia de la ONU
+func unProgram(listType, reference string) string {
+	return joinNonEmpty(listType, reference)
+}
+
+// unAliasNames obtiene los nombres no vacíos de los alias
+func unAliasNames(aliases []unAlias) []string {
+	var names []string
+	for _, alias := range aliases {
+		if name := strings.TrimSpace(alias.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Columnas del CSV documentado de la Lista de Personas Bloqueadas de la UIF
+const (
+	uifColumnID      = "id"
+	uifColumnName    = "nombre"
+	uifColumnAliases = "alias"
+	uifColumnRFC     = "rfc"
+	uifColumnCURP    = "curp"
+	uifColumnReason  = "motivo"
+)
+
+// ParseUIF interpreta la Lista de Personas Bloqueadas de la UIF en el CSV documentado:
+// encabezado obligatorio con las columnas id, nombre, alias, rfc, curp y motivo (solo
+// nombre es obligatoria, el orden es libre) y los alias separados por punto y coma
+func ParseUIF(r io.Reader) ([]*domain.WatchlistEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("el archivo de la UIF no tiene encabezado")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns[uifColumnName]; !ok {
+		return nil, errors.New("el archivo de la UIF no tiene la columna nombre")
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	entries := []*domain.WatchlistEntry{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: %w", line, err)
+		}
+
+		entry := &domain.WatchlistEntry{
+			ExternalID: field(record, uifColumnID),
+			Name:       field(record, uifColumnName),
+			Program:    field(record, uifColumnReason),
+		}
+		if entry.Name == "" {
+			return nil, fmt.Errorf("línea %d: nombre vacío", line)
+		}
+		if entry.ExternalID == "" {
+			entry.ExternalID = fmt.Sprintf("%d", line-1)
+		}
+		for _, alias := range strings.Split(field(record, uifColumnAliases), ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		for _, idNumber := range []string{field(record, uifColumnRFC), field(record, uifColumnCURP)} {
+			if idNumber != "" {
+				entry.IDNumbers = append(entry.IDNumbers, idNumber)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// joinNonEmpty une con espacios los valores no vacíos
+func joinNonEmpty(values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
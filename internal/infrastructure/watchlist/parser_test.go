@@ -0,0 +1,103 @@
+package watchlist
+
+import (
+	"crabi-test/internal/domain"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parseTestFile(t *testing.T, source, path string) ([]*domain.WatchlistEntry, string) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Error abriendo %s: %v", path, err)
+	}
+	defer file.Close()
+
+	entries, published, err := Parse(source, file)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return entries, published
+}
+
+func TestParseOFACSDN(t *testing.T) {
+	entries, _ := parseTestFile(t, domain.WatchlistSourceOFAC, "testdata/sdn.csv")
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	entry := entries[1]
+	if entry.ExternalID != "7535" || entry.Name != "GUZMAN LOERA, Joaquin" || entry.Program != "SDNTK" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if !reflect.DeepEqual(entry.Aliases, []string{"EL CHAPO"}) {
+		t.Errorf("Expected alias EL CHAPO, got %v", entry.Aliases)
+	}
+	if !reflect.DeepEqual(entry.IDNumbers, []string{"GULJ570404", "GULJ570404HSLZRQ05"}) {
+		t.Errorf("Expected RFC and CURP, got %v", entry.IDNumbers)
+	}
+}
+
+func TestParseUNConsolidated(t *testing.T) {
+	entries, published := parseTestFile(t, domain.WatchlistSourceUN, "testdata/consolidated.xml")
+
+	if published != "2024-03-01T00:00:00.000Z" {
+		t.Errorf("Expected publication date from the file, got %q", published)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	individual := entries[0]
+	if individual.Name != "RI WON HO" || individual.Program != "DPRK KPi.066" {
+		t.Errorf("Unexpected individual %+v", individual)
+	}
+	if !reflect.DeepEqual(individual.IDNumbers, []string{"381310014"}) || !reflect.DeepEqual(individual.Aliases, []string{"RI WON-HO"}) {
+		t.Errorf("Unexpected documents or aliases %+v", individual)
+	}
+	if entries[1].Name != "AL-AKHTAR TRUST INTERNATIONAL" {
+		t.Errorf("Unexpected entity %+v", entries[1])
+	}
+}
+
+func TestParseUIF(t *testing.T) {
+	entries, _ := parseTestFile(t, domain.WatchlistSourceUIF, "testdata/lpb.csv")
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.ExternalID != "LPB-001" || entry.Program != "Lavado de dinero" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if !reflect.DeepEqual(entry.Aliases, []string{"La Güera", "Fer López"}) {
+		t.Errorf("Unexpected aliases %v", entry.Aliases)
+	}
+	if !reflect.DeepEqual(entry.IDNumbers, []string{"LONM800101ABC", "LONM800101MDFPXR09"}) {
+		t.Errorf("Unexpected id numbers %v", entry.IDNumbers)
+	}
+}
+
+func TestParse_InvalidInput(t *testing.T) {
+	if _, _, err := Parse("desconocida", strings.NewReader("")); err == nil {
+		t.Error("Expected error for unknown source")
+	}
+	if _, err := ParseUIF(strings.NewReader("id,rfc\n1,ABC\n")); err == nil {
+		t.Error("Expected error for missing nombre column")
+	}
+	if _, _, err := ParseUNConsolidated(strings.NewReader("<CONSOLIDATED_LIST>")); err == nil {
+		t.Error("Expected error for truncated XML")
+	}
+}
+
+func TestNormalizeIDNumber(t *testing.T) {
+	if NormalizeIDNumber("gulj-570404") != "GULJ570404" {
+		t.Errorf("Unexpected id number %q", NormalizeIDNumber("gulj-570404"))
+	}
+}
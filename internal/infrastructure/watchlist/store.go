@@ -0,0 +1,228 @@
+package watchlist
+
+import (
+	"context"
+	"crabi-test/internal/application/ports"
+	"crabi-test/internal/domain"
+	"crabi-test/pkg/namematch"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Campos por los que puede coincidir una entrada
+const (
+	MatchedOnIDNumber = "id_number"
+	MatchedOnName     = "name"
+	MatchedOnAlias    = "alias"
+)
+
+// Match representa una coincidencia contra una entrada de una lista de sanciones
+type Match struct {
+	Entry        *domain.WatchlistEntry `json:"entry"`
+	MatchedOn    string                 `json:"matched_on"`
+	MatchedValue string                 `json:"matched_value"`
+	Score        float64                `json:"score"`
+}
+
+// Store mantiene en memoria las listas de sanciones vigentes, indexadas por clave
+// fonética de cada palabra del nombre y por número de identificación
+type Store struct {
+	mu         sync.RWMutex
+	versions   []*domain.WatchlistVersion
+	byPhonetic map[string][]*nameRef
+	byIDNumber map[string][]*domain.WatchlistEntry
+}
+
+// nameRef vincula un nombre o alias con su entrada
+type nameRef struct {
+	entry *domain.WatchlistEntry
+	name  string
+	alias bool
+}
+
+// NewStore crea un almacén vacío
+func NewStore() *Store {
+	return &Store{
+		byPhonetic: make(map[string][]*nameRef),
+		byIDNumber: make(map[string][]*domain.WatchlistEntry),
+	}
+}
+
+// Load reemplaza el contenido del almacén
+func (s *Store) Load(versions []*domain.WatchlistVersion, entries []*domain.WatchlistEntry) {
+	byPhonetic := make(map[string][]*nameRef)
+	byIDNumber := make(map[string][]*domain.WatchlistEntry)
+
+	index := func(ref *nameRef) {
+		seen := make(map[string]bool)
+		for _, token := range namematch.Tokens(ref.name) {
+			key := namematch.Phonetic(token)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			byPhonetic[key] = append(byPhonetic[key], ref)
+		}
+	}
+
+	for _, entry := range entries {
+		index(&nameRef{entry: entry, name: entry.Name})
+		for _, alias := range entry.Aliases {
+			index(&nameRef{entry: entry, name: alias, alias: true})
+		}
+		for _, idNumber := range entry.IDNumbers {
+			if key := NormalizeIDNumber(idNumber); key != "" {
+				byIDNumber[key] = append(byIDNumber[key], entry)
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.versions = versions
+	s.byPhonetic = byPhonetic
+	s.byIDNumber = byIDNumber
+}
+
+// Reload carga desde el repositorio las listas vigentes
+func (s *Store) Reload(ctx context.Context, repo ports.WatchlistRepository) error {
+	versions, err := repo.ListActiveVersions(ctx)
+	if err != nil {
+		return err
+	}
+	entries, err := repo.ListEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.Load(versions, entries)
+	log.Printf("Listas de sanciones cargadas: %d listas, %d entradas", len(versions), len(entries))
+	return nil
+}
+
+// Watch recarga el almacén cada vez que cambian las versiones vigentes en el repositorio,
+// comprobándolo en el intervalo indicado hasta que se cancele ctx
+func (s *Store) Watch(ctx context.Context, repo ports.WatchlistRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			versions, err := repo.ListActiveVersions(ctx)
+			if err != nil {
+				log.Printf("error consultando versiones de listas de sanciones: %v", err)
+				continue
+			}
+			if sameVersions(versions, s.Versions()) {
+				continue
+			}
+			if err := s.Reload(ctx, repo); err != nil {
+				log.Printf("error recargando listas de sanciones: %v", err)
+			}
+		}
+	}
+}
+
+// Versions retorna las versiones cargadas
+func (s *Store) Versions() []*domain.WatchlistVersion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.versions
+}
+
+// Fingerprint identifica el conjunto de versiones cargadas; cambia cada vez que se carga
+// una versión nueva de alguna lista
+func (s *Store) Fingerprint() string {
+	versions := s.Versions()
+
+	ids := make([]string, 0, len(versions))
+	for _, version := range versions {
+		ids = append(ids, strconv.FormatUint(uint64(version.ID), 10))
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// Loaded indica si hay al menos una lista cargada
+func (s *Store) Loaded() bool {
+	return len(s.Versions()) > 0
+}
+
+// Match busca coincidencias exactas por número de identificación y aproximadas por
+// nombre o alias. Retorna las coincidencias con similitud mayor o igual a minScore,
+// de la más a la menos parecida, con a lo sumo una coincidencia por entrada
+func (s *Store) Match(idNumber, name string, minScore float64) []Match {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	best := make(map[*domain.WatchlistEntry]Match)
+
+	if key := NormalizeIDNumber(idNumber); key != "" {
+		for _, entry := range s.byIDNumber[key] {
+			best[entry] = Match{Entry: entry, MatchedOn: MatchedOnIDNumber, MatchedValue: idNumber, Score: 1}
+		}
+	}
+
+	// Candidatos: nombres que comparten al menos una palabra fonéticamente igual
+	candidates := make(map[*nameRef]bool)
+	for _, token := range namematch.Tokens(name) {
+		for _, ref := range s.byPhonetic[namematch.Phonetic(token)] {
+			candidates[ref] = true
+		}
+	}
+
+	for ref := range candidates {
+		score := namematch.Score(name, ref.name)
+		if score < minScore {
+			continue
+		}
+		if current, ok := best[ref.entry]; ok && current.Score >= score {
+			continue
+		}
+
+		matchedOn := MatchedOnName
+		if ref.alias {
+			matchedOn = MatchedOnAlias
+		}
+		best[ref.entry] = Match{Entry: ref.entry, MatchedOn: matchedOn, MatchedValue: ref.name, Score: score}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Entry.ExternalID < matches[j].Entry.ExternalID
+	})
+
+	return matches
+}
+
+// sameVersions compara dos conjuntos de versiones por ID
+func sameVersions(a, b []*domain.WatchlistVersion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[uint]bool, len(a))
+	for _, version := range a {
+		ids[version.ID] = true
+	}
+	for _, version := range b {
+		if !ids[version.ID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// JWK es la representación pública de una clave (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet es el documento que publica /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS retorna las claves públicas del anillo, empezando por la activa
+func (r *KeyRing) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(r.ordered))}
+	for _, key := range r.ordered {
+		jwk := publicJWK(key.public)
+		jwk.Use = "sig"
+		jwk.Kid = key.ID
+		jwk.Alg = key.Algorithm
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// publicJWK obtiene los parámetros públicos de una clave RSA o EC P-256
+func publicJWK(key crypto.PublicKey) JWK {
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   encode(public.N.Bytes()),
+			E:   encode(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		return JWK{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   encode(public.X.FillBytes(make([]byte, 32))),
+			Y:   encode(public.Y.FillBytes(make([]byte, 32))),
+		}
+	}
+	return JWK{}
+}
+
+// thumbprint calcula el thumbprint RFC 7638 de una clave pública, que se usa como kid para
+// que cualquier servicio obtenga el mismo identificador de la misma clave
+func thumbprint(key crypto.PublicKey) (string, error) {
+	jwk := publicJWK(key)
+
+	// Los miembros requeridos en orden lexicográfico, sin espacios
+	var members interface{}
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	default:
+		return "", fmt.Errorf("tipo de clave no soportado: %T", key)
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return encode(sum[:]), nil
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,171 @@
+// Package jwtkeys firma y verifica tokens JWT con claves asimétricas (RS256 y ES256). El
+// anillo de claves tiene una clave activa para firmar y claves anteriores que solo verifican,
+// lo que permite rotar la clave sin invalidar los tokens vigentes
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algoritmos soportados. HS256 y "none" no se aceptan
+const (
+	RS256 = "RS256"
+	ES256 = "ES256"
+)
+
+// minRSABits es el tamaño mínimo aceptado para las claves RSA
+const minRSABits = 2048
+
+// Key es una clave del anillo. Las claves anteriores pueden tener solo la parte pública
+type Key struct {
+	// ID es el kid de la clave: el thumbprint RFC 7638 de su parte pública
+	ID        string
+	Algorithm string
+
+	signer crypto.Signer
+	public crypto.PublicKey
+}
+
+// NewKey crea una clave a partir de una clave privada o pública RSA o EC P-256
+func NewKey(key interface{}) (*Key, error) {
+	k := &Key{}
+	if signer, ok := key.(crypto.Signer); ok {
+		k.signer = signer
+		key = signer.Public()
+	}
+
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("clave RSA de %d bits, el mínimo es %d", public.N.BitLen(), minRSABits)
+		}
+		k.Algorithm = RS256
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, errors.New("solo se aceptan claves EC de la curva P-256")
+		}
+		k.Algorithm = ES256
+	default:
+		return nil, fmt.Errorf("tipo de clave no soportado: %T", key)
+	}
+	k.public = key
+
+	id, err := thumbprint(k.public)
+	if err != nil {
+		return nil, err
+	}
+	k.ID = id
+	return k, nil
+}
+
+// GenerateKey genera una clave nueva del algoritmo indicado
+func GenerateKey(algorithm string) (*Key, error) {
+	switch algorithm {
+	case RS256:
+		private, err := rsa.GenerateKey(rand.Reader, minRSABits)
+		if err != nil {
+			return nil, err
+		}
+		return NewKey(private)
+	case ES256:
+		private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewKey(private)
+	}
+	return nil, fmt.Errorf("algoritmo no soportado: %s", algorithm)
+}
+
+// CanSign indica si la clave tiene su parte privada
+func (k *Key) CanSign() bool {
+	return k.signer != nil
+}
+
+// method retorna el método de firma de la clave
+func (k *Key) method() jwt.SigningMethod {
+	if k.Algorithm == RS256 {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodES256
+}
+
+// KeyRing firma con la clave activa y verifica con cualquiera de sus claves
+type KeyRing struct {
+	active  *Key
+	keys    map[string]*Key
+	ordered []*Key
+	methods []string
+}
+
+// NewKeyRing crea un anillo con la clave activa, que debe tener su parte privada, y las
+// claves anteriores que se siguen aceptando al verificar
+func NewKeyRing(active *Key, previous ...*Key) (*KeyRing, error) {
+	if active == nil || !active.CanSign() {
+		return nil, errors.New("la clave activa debe incluir la clave privada")
+	}
+
+	r := &KeyRing{active: active, keys: make(map[string]*Key)}
+	for _, key := range append([]*Key{active}, previous...) {
+		if _, exists := r.keys[key.ID]; exists {
+			return nil, fmt.Errorf("clave duplicada: %s", key.ID)
+		}
+		r.keys[key.ID] = key
+		r.ordered = append(r.ordered, key)
+
+		if !contains(r.methods, key.Algorithm) {
+			r.methods = append(r.methods, key.Algorithm)
+		}
+	}
+	return r, nil
+}
+
+// ActiveKey retorna la clave con la que se firman los tokens
+func (r *KeyRing) ActiveKey() *Key {
+	return r.active
+}
+
+// Sign firma los claims con la clave activa e incluye su kid en el encabezado
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.active.method(), claims)
+	token.Header["kid"] = r.active.ID
+	return token.SignedString(r.active.signer)
+}
+
+// Parse verifica la firma y la vigencia de un token y carga sus claims. Solo acepta los
+// algoritmos de las claves del anillo, y cada token debe usar el algoritmo de la clave
+// indicada en su kid. options agrega validaciones de claims, como el issuer o la audiencia
+func (r *KeyRing) Parse(tokenString string, claims jwt.Claims, options ...jwt.ParserOption) (*jwt.Token, error) {
+	options = append([]jwt.ParserOption{jwt.WithValidMethods(r.methods)}, options...)
+	return jwt.ParseWithClaims(tokenString, claims, r.keyFunc, options...)
+}
+
+// keyFunc obtiene la clave pública con la que se verifica un token
+func (r *KeyRing) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("clave desconocida: %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("el algoritmo %s no corresponde a la clave %s", token.Method.Alg(), kid)
+	}
+	return key.public, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
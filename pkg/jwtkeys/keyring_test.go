@@ -0,0 +1,141 @@
+package jwtkeys
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func mustGenerateKey(t *testing.T, algorithm string) *Key {
+	t.Helper()
+	key, err := GenerateKey(algorithm)
+	if err != nil {
+		t.Fatalf("Expected no error generating key, got %v", err)
+	}
+	return key
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"sub": "1", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestKeyRing_SignAndParse(t *testing.T) {
+	for _, algorithm := range []string{ES256, RS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			ring, err := NewKeyRing(mustGenerateKey(t, algorithm))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			signed, err := ring.Sign(testClaims())
+			if err != nil {
+				t.Fatalf("Expected no error signing, got %v", err)
+			}
+
+			claims := jwt.MapClaims{}
+			token, err := ring.Parse(signed, claims)
+			if err != nil || !token.Valid {
+				t.Fatalf("Expected a valid token, got %v", err)
+			}
+			if token.Header["kid"] != ring.ActiveKey().ID || token.Method.Alg() != algorithm || claims["sub"] != "1" {
+				t.Errorf("Unexpected token %+v with claims %v", token.Header, claims)
+			}
+		})
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	previous := mustGenerateKey(t, RS256)
+	oldRing, _ := NewKeyRing(previous)
+	oldToken, _ := oldRing.Sign(testClaims())
+
+	// El anillo nuevo solo tiene la parte pública de la clave anterior
+	publicPrevious, err := NewKey(previous.public)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ring, err := NewKeyRing(mustGenerateKey(t, ES256), publicPrevious)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := ring.Parse(oldToken, jwt.MapClaims{}); err != nil {
+		t.Errorf("Expected a token signed with the previous key to be accepted, got %v", err)
+	}
+
+	// Sin la clave anterior el token ya no se acepta
+	withoutPrevious, _ := NewKeyRing(ring.ActiveKey())
+	if _, err := withoutPrevious.Parse(oldToken, jwt.MapClaims{}); err == nil {
+		t.Error("Expected a token signed with a removed key to be rejected")
+	}
+
+	if _, err := NewKeyRing(publicPrevious); err == nil {
+		t.Error("Expected an error for an active key without private key")
+	}
+	if jwks := ring.JWKS(); len(jwks.Keys) != 2 || jwks.Keys[0].Kid != ring.ActiveKey().ID || jwks.Keys[1].Kty != "RSA" {
+		t.Errorf("Expected the active and previous keys in the JWKS, got %+v", jwks)
+	}
+}
+
+func TestKeyRing_RejectsOtherAlgorithms(t *testing.T) {
+	key := mustGenerateKey(t, RS256)
+	ring, _ := NewKeyRing(key)
+
+	// HS256 firmado con la clave pública como secreto (confusión de algoritmos)
+	publicDER, _ := x509.MarshalPKIXPublicKey(key.public)
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	hmac.Header["kid"] = key.ID
+	hmacToken, _ := hmac.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, testClaims())
+	none.Header["kid"] = key.ID
+	noneToken, _ := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	// ES256 con el kid de una clave RSA
+	other, _ := NewKeyRing(mustGenerateKey(t, ES256))
+	es := jwt.NewWithClaims(jwt.SigningMethodES256, testClaims())
+	es.Header["kid"] = key.ID
+	esToken, _ := es.SignedString(other.ActiveKey().signer)
+
+	for name, token := range map[string]string{"HS256": hmacToken, "none": noneToken, "ES256": esToken} {
+		if _, err := ring.Parse(token, jwt.MapClaims{}); err == nil {
+			t.Errorf("Expected a %s token to be rejected", name)
+		}
+	}
+}
+
+func TestParsePEM(t *testing.T) {
+	key := mustGenerateKey(t, ES256)
+	der, _ := x509.MarshalPKCS8PrivateKey(key.signer)
+	parsed, err := ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil || parsed.ID != key.ID || !parsed.CanSign() {
+		t.Fatalf("Expected the private key to be parsed, got %+v (%v)", parsed, err)
+	}
+
+	publicDER, _ := x509.MarshalPKIXPublicKey(key.public)
+	parsed, err = ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil || parsed.ID != key.ID || parsed.CanSign() {
+		t.Fatalf("Expected the public key to be parsed, got %+v (%v)", parsed, err)
+	}
+
+	if _, err := ParsePEM([]byte("no es PEM")); err == nil {
+		t.Error("Expected an error for invalid PEM")
+	}
+}
+
+func TestThumbprint_RFC7638(t *testing.T) {
+	// Ejemplo de la sección 3.1 del RFC 7638
+	n, _ := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	key, err := NewKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if key.ID != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("Unexpected thumbprint %s", key.ID)
+	}
+}
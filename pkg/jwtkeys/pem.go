@@ -0,0 +1,53 @@
+package jwtkeys
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// LoadKeyFile carga una clave desde un archivo PEM. Acepta claves privadas PKCS#8, PKCS#1 y
+// SEC 1, y claves públicas PKIX, que sirven como claves anteriores
+func LoadKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParsePEM obtiene la clave del primer bloque PEM de data
+func ParsePEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no contiene un bloque PEM")
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("tipo de bloque PEM no soportado: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(key)
+}
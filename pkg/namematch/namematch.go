@@ -0,0 +1,292 @@
+// Package namematch compara nombres de personas y entidades tolerando acentos,
+// transliteraciones, apellidos en otro orden y errores de captura
+package namematch
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// particles son las partículas de nombres que no aportan a la comparación
+var particles = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "los": true, "y": true,
+	"da": true, "das": true, "do": true, "dos": true, "van": true, "von": true,
+}
+
+// Fold elimina acentos y convierte a minúsculas ("Núñez" -> "nunez")
+func Fold(s string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+	return strings.ToLower(folded)
+}
+
+// Tokens separa un nombre en palabras normalizadas, descartando puntuación y partículas
+// ("de", "la", ...) salvo que el nombre solo contenga partículas
+func Tokens(name string) []string {
+	all := strings.FieldsFunc(Fold(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(all))
+	for _, token := range all {
+		if !particles[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return all
+	}
+	return tokens
+}
+
+// Key retorna una clave exacta independiente de acentos, puntuación y orden de palabras,
+// de modo que "PÉREZ, Juan" y "Juan Perez" producen la misma clave
+func Key(name string) string {
+	tokens := Tokens(name)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// Score calcula la similitud (0..1) entre dos nombres. Cada palabra del nombre más corto
+// se empareja con la palabra más parecida del otro sin importar el orden, y se penaliza
+// la diferencia en número de palabras (por ejemplo, un segundo apellido omitido)
+func Score(a, b string) float64 {
+	short, long := Tokens(a), Tokens(b)
+	if len(short) == 0 || len(long) == 0 {
+		return 0
+	}
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	// Emparejamiento voraz de las palabras más parecidas
+	type pair struct {
+		i, j       int
+		similarity float64
+	}
+	pairs := make([]pair, 0, len(short)*len(long))
+	for i, s := range short {
+		for j, l := range long {
+			pairs = append(pairs, pair{i, j, TokenSimilarity(s, l)})
+		}
+	}
+	sort.SliceStable(pairs, func(x, y int) bool { return pairs[x].similarity > pairs[y].similarity })
+
+	usedShort := make([]bool, len(short))
+	usedLong := make([]bool, len(long))
+	total := 0.0
+	for _, p := range pairs {
+		if usedShort[p.i] || usedLong[p.j] {
+			continue
+		}
+		usedShort[p.i], usedLong[p.j] = true, true
+		total += p.similarity
+	}
+
+	coverage := math.Pow(float64(len(short))/float64(len(long)), 0.25)
+	return total / float64(len(short)) * coverage
+}
+
+// TokenSimilarity calcula la similitud (0..1) entre dos palabras normalizadas usando
+// Jaro-Winkler, Levenshtein y claves fonéticas
+func TokenSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	similarity := JaroWinkler(a, b)
+
+	// Levenshtein es más justo que Jaro-Winkler con errores al inicio de palabras largas
+	if maxLen := math.Max(float64(len([]rune(a))), float64(len([]rune(b)))); maxLen > 0 {
+		similarity = math.Max(similarity, 1-float64(Levenshtein(a, b))/maxLen)
+	}
+
+	// Palabras que suenan igual ("Vásquez" / "Basques") se consideran casi idénticas
+	if Phonetic(a) == Phonetic(b) {
+		similarity = math.Max(similarity, 0.95)
+	}
+
+	return similarity
+}
+
+// Levenshtein calcula la distancia de edición entre dos cadenas
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// JaroWinkler calcula la similitud de Jaro-Winkler (0..1), que favorece prefijos comunes
+func JaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		start := max(0, i-window)
+		end := min(len(rb), i+window+1)
+		for j := start; j < end; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// Phonetic calcula una clave fonética para el español de México: unifica b/v, s/z/c
+// suave, j/g suave/x, y/ll, k/qu/c fuerte, elimina la h muda y las letras repetidas
+func Phonetic(token string) string {
+	r := []rune(Fold(token))
+	var b strings.Builder
+
+	next := func(i int) rune {
+		if i+1 < len(r) {
+			return r[i+1]
+		}
+		return 0
+	}
+	soft := func(c rune) bool { return c == 'e' || c == 'i' }
+
+	var last rune
+	emit := func(c rune) {
+		if c != last {
+			b.WriteRune(c)
+			last = c
+		}
+	}
+
+	for i := 0; i < len(r); i++ {
+		c := r[i]
+		switch c {
+		case 'h':
+			// Muda salvo en "ch", que se procesa con la c
+		case 'c':
+			switch {
+			case next(i) == 'h':
+				emit('x')
+				i++
+			case soft(next(i)):
+				emit('s')
+			default:
+				emit('k')
+			}
+		case 'q':
+			emit('k')
+			if next(i) == 'u' {
+				i++
+			}
+		case 'g':
+			switch {
+			case soft(next(i)):
+				emit('j')
+			case next(i) == 'u' && i+2 < len(r) && soft(r[i+2]):
+				emit('g')
+				i++
+			default:
+				emit('g')
+			}
+		case 'l':
+			if next(i) == 'l' {
+				emit('y')
+				i++
+			} else {
+				emit('l')
+			}
+		case 'v', 'w':
+			emit('b')
+		case 'z':
+			emit('s')
+		case 'x':
+			emit('j')
+		case 'k':
+			emit('k')
+		case 'y':
+			if i == len(r)-1 {
+				emit('i')
+			} else {
+				emit('y')
+			}
+		default:
+			if unicode.IsLetter(c) || unicode.IsDigit(c) {
+				emit(c)
+			}
+		}
+	}
+
+	return b.String()
+}
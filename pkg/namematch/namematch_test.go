@@ -0,0 +1,105 @@
+package namematch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	// Valores de referencia del artículo original de Winkler
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.840},
+		{"dixon", "dicksonx", 0.813},
+		{"abc", "abc", 1},
+		{"abc", "xyz", 0},
+	}
+
+	for _, tt := range tests {
+		if got := JaroWinkler(tt.a, tt.b); math.Abs(got-tt.expected) > 0.001 {
+			t.Errorf("JaroWinkler(%q, %q) = %.3f, expected %.3f", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"kitten", "sitting", 3},
+		{"perez", "peres", 1},
+		{"", "abc", 3},
+		{"núñez", "nunez", 2},
+	}
+
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Levenshtein(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestPhonetic(t *testing.T) {
+	equivalent := [][2]string{
+		{"Vásquez", "Basques"},
+		{"Jiménez", "Ximenes"},
+		{"Hernández", "Ernandes"},
+		{"Guillermo", "Guiyermo"},
+		{"Gerardo", "Jerardo"},
+		{"Cecilia", "Sesilia"},
+		{"Quintero", "Kintero"},
+	}
+	for _, pair := range equivalent {
+		if Phonetic(pair[0]) != Phonetic(pair[1]) {
+			t.Errorf("Expected %q and %q to share a phonetic key, got %q and %q", pair[0], pair[1], Phonetic(pair[0]), Phonetic(pair[1]))
+		}
+	}
+
+	if Phonetic("Castro") == Phonetic("Cháves") {
+		t.Error("Expected different phonetic keys for different names")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if Key("PÉREZ DE LA O, Juan") != Key("juan perez o") {
+		t.Errorf("Expected equivalent keys, got %q and %q", Key("PÉREZ DE LA O, Juan"), Key("juan perez o"))
+	}
+	if Key("de la") != "de la" {
+		t.Errorf("Expected particles to be kept when they are the whole name, got %q", Key("de la"))
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		minScore float64
+		maxScore float64
+	}{
+		{"idénticos", "Juan Pérez López", "Juan Pérez López", 1, 1},
+		{"acentos", "Juan Pérez", "JUAN PEREZ", 1, 1},
+		{"apellidos invertidos", "Joaquín Guzmán Loera", "GUZMAN LOERA, Joaquin", 1, 1},
+		{"error de captura", "Joaqin Guzman Loera", "Joaquin Guzman Loera", 0.95, 0.999},
+		{"transliteración", "Jorge Vásquez Jiménez", "Jorge Basques Ximenes", 0.95, 0.999},
+		{"segundo apellido omitido", "Joaquín Guzmán", "Joaquin Guzman Loera", 0.88, 0.95},
+		{"solo un apellido", "Guzmán", "Joaquin Guzman Loera", 0, 0.8},
+		{"personas distintas", "María Fernanda López", "Pedro Gómez Ruiz", 0, 0.6},
+		{"vacío", "", "Juan", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := Score(tt.a, tt.b)
+			if score < tt.minScore || score > tt.maxScore {
+				t.Errorf("Score(%q, %q) = %.3f, expected between %.2f and %.2f", tt.a, tt.b, score, tt.minScore, tt.maxScore)
+			}
+			if reverse := Score(tt.b, tt.a); math.Abs(reverse-score) > 1e-9 {
+				t.Errorf("Expected symmetric score, got %.3f and %.3f", score, reverse)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package totp genera y verifica códigos de un solo uso basados en tiempo (RFC 6238) con los
+// parámetros que soportan las aplicaciones autenticadoras: HMAC-SHA1, pasos de 30 segundos y
+// códigos de 6 dígitos
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parámetros de los códigos
+const (
+	Period = 30 * time.Second
+	Digits = 6
+
+	// secretSize es el tamaño del secreto en bytes, el recomendado por RFC 4226
+	secretSize = 20
+	// skew es la cantidad de pasos anteriores y posteriores que se aceptan por desfase de reloj
+	skew = 1
+)
+
+// encoding es la codificación base32 sin relleno que esperan las aplicaciones autenticadoras
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ErrInvalidSecret indica un secreto que no es base32 válido
+var ErrInvalidSecret = errors.New("secreto TOTP inválido")
+
+// GenerateSecret genera un secreto aleatorio codificado en base32
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(buf), nil
+}
+
+// Step retorna el paso de tiempo al que pertenece t
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code retorna el código del paso de tiempo al que pertenece t
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return generate(key, Step(t), Digits), nil
+}
+
+// Verify verifica un código contra el paso actual y los pasos vecinos, y retorna el paso con
+// el que coincidió. Quien lo llama debe rechazar los pasos ya usados para que un código no se
+// pueda reutilizar
+func Verify(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := Step(now)
+	for step := current - skew; step <= current+skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(generate(key, step, Digits)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URI construye el URI otpauth:// que las aplicaciones autenticadoras leen desde un código QR
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// decodeSecret decodifica un secreto base32, con o sin relleno y en cualquier capitalización
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "=")
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// generate calcula el código HOTP (RFC 4226) de un contador con la cantidad de dígitos indicada
+func generate(key []byte, counter int64, digits int) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Truncamiento dinámico
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
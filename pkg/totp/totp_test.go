@@ -0,0 +1,88 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret es el secreto SHA1 de los vectores de prueba de RFC 6238
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerate_RFC6238Vectors(t *testing.T) {
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	key, err := decodeSecret(rfcSecret)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, v := range vectors {
+		if code := generate(key, Step(time.Unix(v.unix, 0)), 8); code != v.code {
+			t.Errorf("Expected %s at %d, got %s", v.code, v.unix, code)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, err := Code(secret, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(code) != Digits {
+		t.Fatalf("Expected %d digits, got %q", Digits, code)
+	}
+
+	if step, ok := Verify(secret, code, now); !ok || step != Step(now) {
+		t.Errorf("Expected code to match the current step, got %d (%t)", step, ok)
+	}
+
+	// Se tolera un paso de desfase de reloj, pero no más
+	if _, ok := Verify(secret, code, now.Add(Period)); !ok {
+		t.Error("Expected code to match from the next step")
+	}
+	if _, ok := Verify(secret, code, now.Add(3*Period)); ok {
+		t.Error("Expected code not to match three steps later")
+	}
+
+	for _, invalid := range []string{"", "12345", "abcdef", code + "0"} {
+		if _, ok := Verify(secret, invalid, now); ok {
+			t.Errorf("Expected %q not to match", invalid)
+		}
+	}
+	if _, ok := Verify("no es base32!", code, now); ok {
+		t.Error("Expected invalid secret not to match")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("Crabi", "juan@example.com", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("Expected valid URI, got %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Crabi:juan@example.com" {
+		t.Errorf("Unexpected URI %s", uri)
+	}
+
+	query := uri.Query()
+	if query.Get("secret") != "JBSWY3DPEHPK3PXP" || query.Get("issuer") != "Crabi" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("Unexpected URI parameters %v", query)
+	}
+}
@@ -1,20 +1,61 @@
 package validator
 
 import (
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-// CustomValidator middleware para validación personalizada
+var (
+	setupOnce sync.Once
+	shared    *validator.Validate
+)
+
+// CustomValidator middleware para validación personalizada. Los handlers reciben el mismo
+// validador que usa el binding de gin, con las validaciones personalizadas y los mensajes
+// traducidos registrados
 func CustomValidator() gin.HandlerFunc {
+	setupOnce.Do(setupValidator)
+
 	return func(c *gin.Context) {
-		validate := validator.New()
+		c.Set("validator", shared)
+		c.Next()
+	}
+}
 
-		// Registrar validaciones personalizadas
-		validate.RegisterValidation("id_number", validateIDNumber)
+// setupValidator configura una única vez el validador compartido
+func setupValidator() {
+	var ok bool
+	if shared, ok = binding.Validator.Engine().(*validator.Validate); !ok {
+		shared = validator.New()
+	}
 
-		c.Set("validator", validate)
-		c.Next()
+	// Los errores de validación reportan el nombre del campo en el JSON
+	shared.RegisterTagNameFunc(JSONFieldName)
+
+	// Registrar validaciones personalizadas
+	shared.RegisterValidation("id_number", validateIDNumber)
+
+	if err := registerTranslations(shared); err != nil {
+		log.Printf("Error registrando traducciones de validación: %v", err)
+	}
+}
+
+// JSONFieldName obtiene el nombre del campo en el JSON para los errores de validación
+func JSONFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
 	}
 }
 
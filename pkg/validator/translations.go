@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+)
+
+// universal contiene los traductores de los mensajes de validación. El español es el
+// idioma por defecto
+var universal = ut.New(es.New(), es.New(), en.New())
+
+// customTranslation define el mensaje de una regla que las traducciones de
+// go-playground no incluyen o que se redacta de otra forma
+type customTranslation struct {
+	tag string
+	es  string
+	en  string
+}
+
+// customTranslations se registran sobre las traducciones por defecto. {0} es el campo y
+// {1} el parámetro de la regla
+var customTranslations = []customTranslation{
+	{"required_with", "{0} es obligatorio cuando se indican los campos relacionados", "{0} is required when the related fields are present"},
+	{"datetime", "{0} debe tener el formato {1}", "{0} must use the {1} format"},
+	{"iso3166_1_alpha2", "{0} debe ser un código de país ISO 3166-1 alfa-2 en mayúsculas", "{0} must be an uppercase ISO 3166-1 alpha-2 country code"},
+	{"id_number", "{0} debe tener al menos 8 caracteres", "{0} must be at least 8 characters long"},
+}
+
+// Translator retorna el traductor de mensajes de validación del idioma indicado. Si el
+// idioma no está soportado retorna el de español
+func Translator(lang string) ut.Translator {
+	trans, _ := universal.GetTranslator(lang)
+	return trans
+}
+
+// registerTranslations registra los mensajes de validación en español e inglés
+func registerTranslations(v *validator.Validate) error {
+	esTrans := Translator("es")
+	enTrans := Translator("en")
+	if err := es_translations.RegisterDefaultTranslations(v, esTrans); err != nil {
+		return err
+	}
+	if err := en_translations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		return err
+	}
+
+	for _, custom := range customTranslations {
+		if err := registerTranslation(v, esTrans, custom.tag, custom.es); err != nil {
+			return err
+		}
+		if err := registerTranslation(v, enTrans, custom.tag, custom.en); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registerTranslation registra el mensaje de una regla, reemplazando el existente
+func registerTranslation(v *validator.Validate, trans ut.Translator, tag, text string) error {
+	return v.RegisterTranslation(tag, trans,
+		func(trans ut.Translator) error {
+			return trans.Add(tag, text, true)
+		},
+		func(trans ut.Translator, fe validator.FieldError) string {
+			message, err := trans.T(tag, fe.Field(), datetimeLayout(fe.Param()))
+			if err != nil {
+				return fe.Error()
+			}
+			return message
+		},
+	)
+}
+
+// datetimeLayout muestra el layout de fecha de Go en la notación habitual
+func datetimeLayout(param string) string {
+	if param == "2006-01-02" {
+		return "YYYY-MM-DD"
+	}
+	return param
+}
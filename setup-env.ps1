@@ -25,7 +25,7 @@ Write-Host "Archivo .env creado exitosamente desde env.example" -ForegroundColor
 Write-Host "`nVariables de entorno configuradas:" -ForegroundColor Cyan
 Write-Host "- PORT=8080" -ForegroundColor White
 Write-Host "- GIN_MODE=debug" -ForegroundColor White
-Write-Host "- JWT_SECRET=crabi-jwt-secret-key-for-development-only" -ForegroundColor White
+Write-Host "- JWT_SIGNING_KEY_FILE= (vacío: clave temporal de desarrollo)" -ForegroundColor White
 Write-Host "- DB_PATH=./data/crabi.db" -ForegroundColor White
 Write-Host "- PLD_SERVICE_URL=http://98.81.235.22" -ForegroundColor White
 
@@ -1,18 +1,35 @@
 package tests
 
 import (
+	"context"
 	"crabi-test/internal/application/services"
 	"crabi-test/internal/domain"
+	"crabi-test/pkg/jwtkeys"
 	"testing"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// testKeyRing firma los tokens de las pruebas
+var testKeyRing = newTestKeyRing()
+
+func newTestKeyRing() *jwtkeys.KeyRing {
+	key, err := jwtkeys.GenerateKey(jwtkeys.ES256)
+	if err != nil {
+		panic(err)
+	}
+	keyRing, err := jwtkeys.NewKeyRing(key)
+	if err != nil {
+		panic(err)
+	}
+	return keyRing
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario con contraseña encriptada
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -25,10 +42,10 @@ func TestAuthService_Login_Success(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Act
-	resultUser, token, err := authService.Login("juan.perez@email.com", "password123")
+	resultUser, token, err := authService.Login(context.Background(), "juan.perez@email.com", "password123")
 
 	// Assert
 	if err != nil {
@@ -51,10 +68,10 @@ func TestAuthService_Login_Success(t *testing.T) {
 func TestAuthService_Login_InvalidCredentials(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Act
-	user, token, err := authService.Login("nonexistent@email.com", "wrongpassword")
+	user, token, err := authService.Login(context.Background(), "nonexistent@email.com", "wrongpassword")
 
 	// Assert
 	if err == nil {
@@ -77,7 +94,7 @@ func TestAuthService_Login_InvalidCredentials(t *testing.T) {
 func TestAuthService_Login_WrongPassword(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario con contraseña encriptada
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -90,10 +107,10 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Act
-	resultUser, token, err := authService.Login("juan.perez@email.com", "wrongpassword")
+	resultUser, token, err := authService.Login(context.Background(), "juan.perez@email.com", "wrongpassword")
 
 	// Assert
 	if err == nil {
@@ -116,7 +133,7 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 func TestAuthService_GenerateToken(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	user := &domain.User{
 		ID:        1,
@@ -144,7 +161,7 @@ func TestAuthService_GenerateToken(t *testing.T) {
 func TestAuthService_ValidateToken_Success(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	user := &domain.User{
 		ID:        1,
@@ -155,13 +172,13 @@ func TestAuthService_ValidateToken_Success(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Generar token
 	token, _ := authService.GenerateToken(user)
 
 	// Act
-	validatedUser, err := authService.ValidateToken(token)
+	validatedUser, err := authService.ValidateToken(context.Background(), token)
 
 	// Assert
 	if err != nil {
@@ -180,10 +197,10 @@ func TestAuthService_ValidateToken_Success(t *testing.T) {
 func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Act
-	user, err := authService.ValidateToken("invalid.token.here")
+	user, err := authService.ValidateToken(context.Background(), "invalid.token.here")
 
 	// Assert
 	if err == nil {
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"crabi-test/internal/application/services"
 	"crabi-test/internal/domain"
 	"crabi-test/internal/infrastructure/external"
@@ -14,7 +15,7 @@ import (
 func TestUserServiceCoverage(t *testing.T) {
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := services.NewUserService(userRepo, pldService)
+	userService := services.NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Test CreateUser - Success
 	user := &domain.User{
@@ -23,13 +24,13 @@ func TestUserServiceCoverage(t *testing.T) {
 		Password: "password123",
 		IDNumber: "12345678",
 	}
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
 	// Test GetUser
-	retrievedUser, err := userService.GetUser(1)
+	retrievedUser, err := userService.GetUser(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -38,7 +39,7 @@ func TestUserServiceCoverage(t *testing.T) {
 	}
 
 	// Test GetUserByEmail
-	userByEmail, err := userService.GetUserByEmail("juan.perez@email.com")
+	userByEmail, err := userService.GetUserByEmail(context.Background(), "juan.perez@email.com")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -48,13 +49,13 @@ func TestUserServiceCoverage(t *testing.T) {
 
 	// Test UpdateUser
 	user.Name = "Juan Carlos Pérez"
-	err = userService.UpdateUser(user)
+	err = userService.UpdateUser(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
 	// Test DeleteUser
-	err = userService.DeleteUser(1)
+	err = userService.DeleteUser(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -63,7 +64,7 @@ func TestUserServiceCoverage(t *testing.T) {
 // TestAuthServiceCoverage cubre todas las funciones del AuthService
 func TestAuthServiceCoverage(t *testing.T) {
 	userRepo := NewMockUserRepository()
-	authService := services.NewAuthService(userRepo)
+	authService := services.NewAuthService(userRepo, NewMockTokenRevocationRepository(), testKeyRing)
 
 	// Crear usuario para testing
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -76,10 +77,10 @@ func TestAuthServiceCoverage(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	userRepo.Create(user)
+	userRepo.Create(context.Background(), user)
 
 	// Test Login - Success
-	resultUser, token, err := authService.Login("juan.perez@email.com", "password123")
+	resultUser, token, err := authService.Login(context.Background(), "juan.perez@email.com", "password123")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -100,7 +101,7 @@ func TestAuthServiceCoverage(t *testing.T) {
 	}
 
 	// Test ValidateToken - Success
-	validatedUser, err := authService.ValidateToken(token)
+	validatedUser, err := authService.ValidateToken(context.Background(), token)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -109,7 +110,7 @@ func TestAuthServiceCoverage(t *testing.T) {
 	}
 
 	// Test ValidateToken - Invalid
-	_, err = authService.ValidateToken("invalid.token.here")
+	_, err = authService.ValidateToken(context.Background(), "invalid.token.here")
 	if err == nil {
 		t.Error("Expected error for invalid token")
 	}
@@ -131,7 +132,7 @@ func TestPLDClientCoverage(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		response, err := pldClient.ValidateUser(tc.idNumber, tc.name, tc.email)
+		response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: tc.idNumber, Name: tc.name, Email: tc.email})
 		if err != nil {
 			t.Logf("PLD service not available for %s: %v", tc.name, err)
 			continue
@@ -155,13 +156,13 @@ func TestMockRepositoryCoverage(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
 	// Test GetByID
-	retrievedUser, err := repo.GetByID(1)
+	retrievedUser, err := repo.GetByID(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -170,7 +171,7 @@ func TestMockRepositoryCoverage(t *testing.T) {
 	}
 
 	// Test GetByEmail
-	userByEmail, err := repo.GetByEmail("test@email.com")
+	userByEmail, err := repo.GetByEmail(context.Background(), "test@email.com")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -180,19 +181,19 @@ func TestMockRepositoryCoverage(t *testing.T) {
 
 	// Test Update
 	user.Name = "Updated User"
-	err = repo.Update(user)
+	err = repo.Update(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
 	// Test Delete
-	err = repo.Delete(1)
+	err = repo.Delete(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
 	// Test GetByID after delete
-	deletedUser, err := repo.GetByID(1)
+	deletedUser, err := repo.GetByID(context.Background(), 1)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -205,7 +206,7 @@ func TestMockRepositoryCoverage(t *testing.T) {
 func TestMockPLDServiceCoverage(t *testing.T) {
 	// Test clean user
 	cleanService := NewMockPLDService(false)
-	response, err := cleanService.ValidateUser("12345678", "Juan Pérez", "juan@email.com")
+	response, err := cleanService.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -218,7 +219,7 @@ func TestMockPLDServiceCoverage(t *testing.T) {
 
 	// Test blacklisted user
 	blacklistedService := NewMockPLDService(true)
-	response, err = blacklistedService.ValidateUser("12345678", "Juan Pérez", "juan@email.com")
+	response, err = blacklistedService.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan@email.com"})
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
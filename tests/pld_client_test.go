@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"context"
+	"crabi-test/internal/domain"
 	"crabi-test/internal/infrastructure/external"
 	"testing"
 )
@@ -10,7 +12,7 @@ func TestPLDClient_ValidateUser_Success(t *testing.T) {
 	pldClient := external.NewPLDClient()
 
 	// Act
-	response, err := pldClient.ValidateUser("12345678", "Juan Pérez", "juan.perez@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Pérez", Email: "juan.perez@email.com"})
 
 	// Assert
 	// Este test puede fallar si el servicio PLD no está disponible
@@ -34,7 +36,7 @@ func TestPLDClient_ValidateUser_WithComplexName(t *testing.T) {
 	pldClient := external.NewPLDClient()
 
 	// Act
-	response, err := pldClient.ValidateUser("12345678", "Juan Carlos Pérez González", "juan.perez@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan Carlos Pérez González", Email: "juan.perez@email.com"})
 
 	// Assert
 	// Este test puede fallar si el servicio PLD no está disponible
@@ -55,7 +57,7 @@ func TestPLDClient_ValidateUser_SingleName(t *testing.T) {
 	pldClient := external.NewPLDClient()
 
 	// Act
-	response, err := pldClient.ValidateUser("12345678", "Juan", "juan@email.com")
+	response, err := pldClient.ValidateUser(context.Background(), domain.PLDRequest{IDNumber: "12345678", Name: "Juan", Email: "juan@email.com"})
 
 	// Assert
 	// Este test puede fallar si el servicio PLD no está disponible
@@ -1,9 +1,11 @@
 package tests
 
 import (
+	"context"
 	"crabi-test/internal/application/services"
 	"crabi-test/internal/domain"
 	"testing"
+	"time"
 )
 
 // MockUserRepository implementa un repositorio mock para testing
@@ -19,34 +21,60 @@ func NewMockUserRepository() *MockUserRepository {
 	}
 }
 
-func (m *MockUserRepository) Create(user *domain.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	user.ID = uint(len(m.users) + 1)
 	m.users[user.ID] = user
 	m.emails[user.Email] = user
 	return nil
 }
 
-func (m *MockUserRepository) GetByID(id uint) (*domain.User, error) {
+func (m *MockUserRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	if user, exists := m.users[id]; exists {
 		return user, nil
 	}
 	return nil, nil
 }
 
-func (m *MockUserRepository) GetByEmail(email string) (*domain.User, error) {
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	if user, exists := m.emails[email]; exists {
 		return user, nil
 	}
 	return nil, nil
 }
 
-func (m *MockUserRepository) Update(user *domain.User) error {
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	m.users[user.ID] = user
 	m.emails[user.Email] = user
 	return nil
 }
 
-func (m *MockUserRepository) Delete(id uint) error {
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, user *domain.User, markForReview bool) error {
+	stored, exists := m.users[user.ID]
+	if !exists {
+		return domain.NewError(domain.ErrNotFound, "usuario no encontrado")
+	}
+	delete(m.emails, stored.Email)
+	stored.Name, stored.Email, stored.IDNumber = user.Name, user.Email, user.IDNumber
+	stored.UpdatedAt = user.UpdatedAt
+	m.emails[stored.Email] = stored
+	if markForReview && stored.Status == domain.UserStatusActive {
+		stored.Status = domain.UserStatusPendingReview
+		stored.ReviewReason = user.ReviewReason
+	}
+	return nil
+}
+
+func (m *MockUserRepository) Flag(ctx context.Context, id uint, reason string, flaggedAt time.Time) (bool, error) {
+	user, exists := m.users[id]
+	if !exists || user.FlaggedAt != nil {
+		return false, nil
+	}
+	user.FlaggedAt = &flaggedAt
+	user.FlagReason = reason
+	return true, nil
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
 	if user, exists := m.users[id]; exists {
 		delete(m.users, id)
 		delete(m.emails, user.Email)
@@ -65,7 +93,7 @@ func NewMockPLDService(shouldBlacklist bool) *MockPLDService {
 	}
 }
 
-func (m *MockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLDResponse, error) {
+func (m *MockPLDService) ValidateUser(ctx context.Context, request domain.PLDRequest) (*domain.PLDResponse, error) {
 	if m.shouldBlacklist {
 		return &domain.PLDResponse{
 			IsBlacklisted: true,
@@ -81,11 +109,75 @@ func (m *MockPLDService) ValidateUser(idNumber, name, email string) (*domain.PLD
 	}, nil
 }
 
+// MockScreeningRepository para testing
+type MockScreeningRepository struct {
+	screenings []*domain.Screening
+}
+
+func NewMockScreeningRepository() *MockScreeningRepository {
+	return &MockScreeningRepository{}
+}
+
+func (m *MockScreeningRepository) Create(ctx context.Context, screening *domain.Screening) error {
+	screening.ID = uint(len(m.screenings) + 1)
+	m.screenings = append(m.screenings, screening)
+	return nil
+}
+
+func (m *MockScreeningRepository) LinkUser(ctx context.Context, screeningID, userID uint) error {
+	for _, screening := range m.screenings {
+		if screening.ID == screeningID {
+			id := userID
+			screening.UserID = &id
+		}
+	}
+	return nil
+}
+
+func (m *MockScreeningRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.Screening, error) {
+	var result []*domain.Screening
+	for _, screening := range m.screenings {
+		if screening.UserID != nil && *screening.UserID == userID {
+			result = append(result, screening)
+		}
+	}
+	return result, nil
+}
+
+// MockRejectedApplicationRepository para testing
+type MockRejectedApplicationRepository struct {
+	applications []*domain.RejectedApplication
+}
+
+func NewMockRejectedApplicationRepository() *MockRejectedApplicationRepository {
+	return &MockRejectedApplicationRepository{}
+}
+
+func (m *MockRejectedApplicationRepository) Create(ctx context.Context, application *domain.RejectedApplication) error {
+	application.ID = uint(len(m.applications) + 1)
+	m.applications = append(m.applications, application)
+	return nil
+}
+
+func (m *MockRejectedApplicationRepository) List(ctx context.Context, filter domain.RejectedApplicationFilter) ([]*domain.RejectedApplication, error) {
+	var result []*domain.RejectedApplication
+	for _, application := range m.applications {
+		if !filter.From.IsZero() && application.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && application.CreatedAt.After(filter.To) {
+			continue
+		}
+		result = append(result, application)
+	}
+	return result, nil
+}
+
 func TestUserService_CreateUser_Success(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := services.NewUserService(userRepo, pldService)
+	userService := services.NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -95,7 +187,7 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 	}
 
 	// Act
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 
 	// Assert
 	if err != nil {
@@ -119,7 +211,7 @@ func TestUserService_CreateUser_Blacklisted(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(true)
-	userService := services.NewUserService(userRepo, pldService)
+	userService := services.NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	user := &domain.User{
 		Name:     "Juan Pérez",
@@ -129,7 +221,7 @@ func TestUserService_CreateUser_Blacklisted(t *testing.T) {
 	}
 
 	// Act
-	err := userService.CreateUser(user)
+	err := userService.CreateUser(context.Background(), user)
 
 	// Assert
 	if err == nil {
@@ -145,7 +237,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 	// Arrange
 	userRepo := NewMockUserRepository()
 	pldService := NewMockPLDService(false)
-	userService := services.NewUserService(userRepo, pldService)
+	userService := services.NewUserService(userRepo, userRepo, pldService, NewMockScreeningRepository(), NewMockRejectedApplicationRepository())
 
 	// Crear usuario existente
 	existingUser := &domain.User{
@@ -154,7 +246,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 		Password: "password123",
 		IDNumber: "12345678",
 	}
-	userRepo.Create(existingUser)
+	userRepo.Create(context.Background(), existingUser)
 
 	// Intentar crear usuario con mismo email
 	newUser := &domain.User{
@@ -165,7 +257,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 	}
 
 	// Act
-	err := userService.CreateUser(newUser)
+	err := userService.CreateUser(context.Background(), newUser)
 
 	// Assert
 	if err == nil {
@@ -176,3 +268,53 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 		t.Errorf("Expected duplicate email error, got %v", err)
 	}
 }
+
+// MockTokenRevocationRepository para testing
+type MockTokenRevocationRepository struct {
+	tokens map[string]domain.TokenRevocation
+	users  map[uint]domain.UserTokenRevocation
+}
+
+func NewMockTokenRevocationRepository() *MockTokenRevocationRepository {
+	return &MockTokenRevocationRepository{
+		tokens: make(map[string]domain.TokenRevocation),
+		users:  make(map[uint]domain.UserTokenRevocation),
+	}
+}
+
+func (m *MockTokenRevocationRepository) RevokeToken(ctx context.Context, revocation *domain.TokenRevocation) error {
+	m.tokens[revocation.JTI] = *revocation
+	return nil
+}
+
+func (m *MockTokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	_, revoked := m.tokens[jti]
+	return revoked, nil
+}
+
+func (m *MockTokenRevocationRepository) ListRevokedTokenIDs(ctx context.Context, now time.Time) ([]string, error) {
+	var ids []string
+	for jti, revocation := range m.tokens {
+		if revocation.ExpiresAt.After(now) {
+			ids = append(ids, jti)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockTokenRevocationRepository) RevokeUserTokens(ctx context.Context, revocation *domain.UserTokenRevocation) error {
+	m.users[revocation.UserID] = *revocation
+	return nil
+}
+
+func (m *MockTokenRevocationRepository) GetUserTokenRevocation(ctx context.Context, userID uint) (*domain.UserTokenRevocation, error) {
+	revocation, exists := m.users[userID]
+	if !exists {
+		return nil, nil
+	}
+	return &revocation, nil
+}
+
+func (m *MockTokenRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}